@@ -0,0 +1,131 @@
+package accounts
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/neuronlabs/neuron/database"
+)
+
+// APIKey is the API key model used for the machine clients authentication. The key is linked to the account,
+// and only the hash of the key is stored. The KeyPrefix is the public part of the key used for the lookup.
+type APIKey struct {
+	ID uuid.UUID
+	// Timestamps for the api key.
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// AccountID is the identifier of the account that owns the key.
+	AccountID uuid.UUID `db:";index"`
+	// Name is the human readable key name.
+	Name string
+	// KeyPrefix is the unique, public part of the key used for the lookup.
+	KeyPrefix string `db:";unique"`
+	// KeyHash is the hash of the whole key.
+	KeyHash []byte `codec:"-" json:"-"`
+	// Scopes are the authorization scopes the key is limited to. Empty scopes means no limitation.
+	Scopes []string
+	// ExpiresAt is the optional key expiration time.
+	ExpiresAt *time.Time `codec:";omitempty"`
+	// LastUsedAt is the time when the key was used for the last time.
+	LastUsedAt *time.Time `codec:";omitempty"`
+}
+
+// BeforeInsert is a hook before insertion of the api key.
+func (a *APIKey) BeforeInsert(context.Context, database.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// GetAccountID gets the owner account identifier string value.
+func (a *APIKey) GetAccountID() string {
+	return a.AccountID.String()
+}
+
+// SetAccountID sets the owner account identifier from its string value.
+func (a *APIKey) SetAccountID(accountID string) error {
+	id, err := uuid.Parse(accountID)
+	if err != nil {
+		return err
+	}
+	a.AccountID = id
+	return nil
+}
+
+// AccountIDField gets the owner account identifier field name.
+func (a *APIKey) AccountIDField() string {
+	return "AccountID"
+}
+
+// GetName gets the api key name.
+func (a *APIKey) GetName() string {
+	return a.Name
+}
+
+// SetName sets the api key name.
+func (a *APIKey) SetName(name string) {
+	a.Name = name
+}
+
+// GetKeyPrefix gets the public key prefix.
+func (a *APIKey) GetKeyPrefix() string {
+	return a.KeyPrefix
+}
+
+// SetKeyPrefix sets the public key prefix.
+func (a *APIKey) SetKeyPrefix(prefix string) {
+	a.KeyPrefix = prefix
+}
+
+// KeyPrefixField gets the key prefix field name.
+func (a *APIKey) KeyPrefixField() string {
+	return "KeyPrefix"
+}
+
+// GetKeyHash gets the key hash.
+func (a *APIKey) GetKeyHash() []byte {
+	return a.KeyHash
+}
+
+// SetKeyHash sets the key hash.
+func (a *APIKey) SetKeyHash(hash []byte) {
+	a.KeyHash = hash
+}
+
+// GetScopes gets the authorization scopes of the key.
+func (a *APIKey) GetScopes() []string {
+	return a.Scopes
+}
+
+// SetScopes sets the authorization scopes of the key.
+func (a *APIKey) SetScopes(scopes []string) {
+	a.Scopes = scopes
+}
+
+// GetExpiresAt gets the key expiration time.
+func (a *APIKey) GetExpiresAt() *time.Time {
+	return a.ExpiresAt
+}
+
+// SetExpiresAt sets the key expiration time.
+func (a *APIKey) SetExpiresAt(expiresAt *time.Time) {
+	a.ExpiresAt = expiresAt
+}
+
+// GetLastUsedAt gets the time when the key was used for the last time.
+func (a *APIKey) GetLastUsedAt() *time.Time {
+	return a.LastUsedAt
+}
+
+// SetLastUsedAt sets the time when the key was used for the last time.
+func (a *APIKey) SetLastUsedAt(lastUsedAt time.Time) {
+	a.LastUsedAt = &lastUsedAt
+}
+
+// LastUsedAtField gets the last used at field name.
+func (a *APIKey) LastUsedAtField() string {
+	return "LastUsedAt"
+}
@@ -0,0 +1,560 @@
+// Code generated by neurogonesis. DO NOT EDIT.
+// This file was generated at:
+// Mon, 19 Oct 2026 05:15:11 +0000
+
+package accounts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// Compile time check if APIKey implements mapping.Model interface.
+var _ mapping.Model = &APIKey{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (a *APIKey) IsPrimaryKeyZero() bool {
+	return a.ID == uuid.UUID([16]byte{})
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (a *APIKey) GetPrimaryKeyValue() interface{} {
+	return a.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (a *APIKey) GetPrimaryKeyStringValue() (string, error) {
+	id, err := a.ID.MarshalText()
+	if err != nil {
+		return "", errors.Wrapf(mapping.ErrFieldValue, "invalid primary field value: %v to parse string. Err: %v", a.ID, err)
+	}
+	return string(id), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (a *APIKey) GetPrimaryKeyAddress() interface{} {
+	return &a.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (a *APIKey) GetPrimaryKeyHashableValue() interface{} {
+	return a.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (a *APIKey) GetPrimaryKeyZeroValue() interface{} {
+	return uuid.UUID([16]byte{})
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (a *APIKey) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(uuid.UUID); ok {
+		a.ID = _v
+		return nil
+	} else if _v, ok := value.([16]byte); ok {
+		a.ID = uuid.UUID(_v)
+	}
+	return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: '%T'",
+		value, a)
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (a *APIKey) SetPrimaryKeyStringValue(value string) error {
+	if err := a.ID.UnmarshalText([]byte(value)); err != nil {
+		return errors.Wrapf(mapping.ErrFieldValue, "invalid primary field value: %v to parse string. Err: %v", a.ID, err)
+	}
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (a *APIKey) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrNilModel, "provided nil model to set from")
+	}
+	from, ok := model.(*APIKey)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*a = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (a *APIKey) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return a.ID, nil
+	case 1: // CreatedAt
+		return a.CreatedAt, nil
+	case 2: // UpdatedAt
+		return a.UpdatedAt, nil
+	case 3: // AccountID
+		return a.AccountID, nil
+	case 4: // Name
+		return a.Name, nil
+	case 5: // KeyPrefix
+		return a.KeyPrefix, nil
+	case 6: // KeyHash
+		return a.KeyHash, nil
+	case 7: // Scopes
+		return a.Scopes, nil
+	case 8: // ExpiresAt
+		return a.ExpiresAt, nil
+	case 9: // LastUsedAt
+		return a.LastUsedAt, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: APIKey'", field.Name())
+	}
+}
+
+// Compile time check if APIKey implements mapping.Fielder interface.
+var _ mapping.Fielder = &APIKey{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (a *APIKey) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &a.ID, nil
+	case 1: // CreatedAt
+		return &a.CreatedAt, nil
+	case 2: // UpdatedAt
+		return &a.UpdatedAt, nil
+	case 3: // AccountID
+		return &a.AccountID, nil
+	case 4: // Name
+		return &a.Name, nil
+	case 5: // KeyPrefix
+		return &a.KeyPrefix, nil
+	case 6: // KeyHash
+		return &a.KeyHash, nil
+	case 7: // Scopes
+		return &a.Scopes, nil
+	case 8: // ExpiresAt
+		return &a.ExpiresAt, nil
+	case 9: // LastUsedAt
+		return &a.LastUsedAt, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: APIKey'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (a *APIKey) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return uuid.UUID([16]byte{}), nil
+	case 1: // CreatedAt
+		return time.Time{}, nil
+	case 2: // UpdatedAt
+		return time.Time{}, nil
+	case 3: // AccountID
+		return uuid.UUID([16]byte{}), nil
+	case 4: // Name
+		return "", nil
+	case 5: // KeyPrefix
+		return "", nil
+	case 6: // KeyHash
+		return nil, nil
+	case 7: // Scopes
+		return nil, nil
+	case 8: // ExpiresAt
+		return nil, nil
+	case 9: // LastUsedAt
+		return nil, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (a *APIKey) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return a.ID == uuid.UUID([16]byte{}), nil
+	case 1: // CreatedAt
+		return a.CreatedAt == time.Time{}, nil
+	case 2: // UpdatedAt
+		return a.UpdatedAt == time.Time{}, nil
+	case 3: // AccountID
+		return a.AccountID == uuid.UUID([16]byte{}), nil
+	case 4: // Name
+		return a.Name == "", nil
+	case 5: // KeyPrefix
+		return a.KeyPrefix == "", nil
+	case 6: // KeyHash
+		return len(a.KeyHash) == 0, nil
+	case 7: // Scopes
+		return len(a.Scopes) == 0, nil
+	case 8: // ExpiresAt
+		return a.ExpiresAt == nil, nil
+	case 9: // LastUsedAt
+		return a.LastUsedAt == nil, nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (a *APIKey) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		a.ID = uuid.UUID([16]byte{})
+	case 1: // CreatedAt
+		a.CreatedAt = time.Time{}
+	case 2: // UpdatedAt
+		a.UpdatedAt = time.Time{}
+	case 3: // AccountID
+		a.AccountID = uuid.UUID([16]byte{})
+	case 4: // Name
+		a.Name = ""
+	case 5: // KeyPrefix
+		a.KeyPrefix = ""
+	case 6: // KeyHash
+		a.KeyHash = nil
+	case 7: // Scopes
+		a.Scopes = nil
+	case 8: // ExpiresAt
+		a.ExpiresAt = nil
+	case 9: // LastUsedAt
+		a.LastUsedAt = nil
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (a *APIKey) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return a.ID, nil
+	case 1: // CreatedAt
+		return a.CreatedAt, nil
+	case 2: // UpdatedAt
+		return a.UpdatedAt, nil
+	case 3: // AccountID
+		return a.AccountID, nil
+	case 4: // Name
+		return a.Name, nil
+	case 5: // KeyPrefix
+		return a.KeyPrefix, nil
+	case 6: // KeyHash
+		return string(a.KeyHash), nil
+	case 7: // Scopes
+		return a.Scopes, nil
+	case 8: // ExpiresAt
+		if a.ExpiresAt == nil {
+			return nil, nil
+		}
+		return *a.ExpiresAt, nil
+	case 9: // LastUsedAt
+		if a.LastUsedAt == nil {
+			return nil, nil
+		}
+		return *a.LastUsedAt, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'APIKey'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (a *APIKey) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return a.ID, nil
+	case 1: // CreatedAt
+		return a.CreatedAt, nil
+	case 2: // UpdatedAt
+		return a.UpdatedAt, nil
+	case 3: // AccountID
+		return a.AccountID, nil
+	case 4: // Name
+		return a.Name, nil
+	case 5: // KeyPrefix
+		return a.KeyPrefix, nil
+	case 6: // KeyHash
+		return a.KeyHash, nil
+	case 7: // Scopes
+		return a.Scopes, nil
+	case 8: // ExpiresAt
+		return a.ExpiresAt, nil
+	case 9: // LastUsedAt
+		return a.LastUsedAt, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: APIKey'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (a *APIKey) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(uuid.UUID); ok {
+			a.ID = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			if len(generic) > 16 {
+				return errors.Wrapf(mapping.ErrFieldValue, "provided too many values for the field: 'ID")
+			}
+			for i, item := range generic {
+				if _v, ok := item.(byte); ok {
+					a.ID[i] = _v
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Checked wrapped types.
+		if _v, ok := value.([16]byte); ok {
+			a.ID = uuid.UUID(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 1: // CreatedAt
+		if _v, ok := value.(time.Time); ok {
+			a.CreatedAt = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			a.CreatedAt = time.Time{}
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // UpdatedAt
+		if _v, ok := value.(time.Time); ok {
+			a.UpdatedAt = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			a.UpdatedAt = time.Time{}
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 3: // AccountID
+		if _v, ok := value.(uuid.UUID); ok {
+			a.AccountID = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			if len(generic) > 16 {
+				return errors.Wrapf(mapping.ErrFieldValue, "provided too many values for the field: 'AccountID")
+			}
+			for i, item := range generic {
+				if _v, ok := item.(byte); ok {
+					a.AccountID[i] = _v
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Checked wrapped types.
+		if _v, ok := value.([16]byte); ok {
+			a.AccountID = uuid.UUID(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 4: // Name
+		if _v, ok := value.(string); ok {
+			a.Name = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			a.Name = ""
+			return nil
+		}
+
+		// Check alternate types for the Name.
+		if _v, ok := value.([]byte); ok {
+			a.Name = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 5: // KeyPrefix
+		if _v, ok := value.(string); ok {
+			a.KeyPrefix = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			a.KeyPrefix = ""
+			return nil
+		}
+
+		// Check alternate types for the KeyPrefix.
+		if _v, ok := value.([]byte); ok {
+			a.KeyPrefix = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 6: // KeyHash
+		if value == nil {
+			a.KeyHash = nil
+			return nil
+		}
+		if _v, ok := value.([]byte); ok {
+			a.KeyHash = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(byte); ok {
+					a.KeyHash = append(a.KeyHash, _v)
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Check alternate types for the KeyHash.
+		if _v, ok := value.(string); ok {
+			a.KeyHash = []byte(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 7: // Scopes
+		if value == nil {
+			a.Scopes = nil
+			return nil
+		}
+		if _v, ok := value.([]string); ok {
+			a.Scopes = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(string); ok {
+					a.Scopes = append(a.Scopes, _v)
+					continue
+				}
+				// Check alternate types for the Scopes.
+				if _v, ok := item.([]byte); ok {
+					a.Scopes = append(a.Scopes, string(_v))
+					continue
+				}
+				return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+			}
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 8: // ExpiresAt
+		if value == nil {
+			a.ExpiresAt = nil
+			return nil
+		}
+		if _v, ok := value.(*time.Time); ok {
+			a.ExpiresAt = _v
+			return nil
+		}
+		// Check if it is non-pointer value.
+		if _v, ok := value.(time.Time); ok {
+			a.ExpiresAt = &_v
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 9: // LastUsedAt
+		if value == nil {
+			a.LastUsedAt = nil
+			return nil
+		}
+		if _v, ok := value.(*time.Time); ok {
+			a.LastUsedAt = _v
+			return nil
+		}
+		// Check if it is non-pointer value.
+		if _v, ok := value.(time.Time); ok {
+			a.LastUsedAt = &_v
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'APIKey'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (a *APIKey) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		temp := a.ID
+		if err := a.ID.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'ID' value: '%v' to parse string. Err: %v", a.ID, err)
+		}
+		bt, err := a.ID.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'ID' value: '%v' to parse string. Err: %v", a.ID, err)
+		}
+		a.ID = temp
+		return string(bt), nil
+	case 1: // CreatedAt
+		temp := a.CreatedAt
+		if err := a.CreatedAt.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'CreatedAt' value: '%v' to parse string. Err: %v", a.CreatedAt, err)
+		}
+		bt, err := a.CreatedAt.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'CreatedAt' value: '%v' to parse string. Err: %v", a.CreatedAt, err)
+		}
+		a.CreatedAt = temp
+		return string(bt), nil
+	case 2: // UpdatedAt
+		temp := a.UpdatedAt
+		if err := a.UpdatedAt.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'UpdatedAt' value: '%v' to parse string. Err: %v", a.UpdatedAt, err)
+		}
+		bt, err := a.UpdatedAt.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'UpdatedAt' value: '%v' to parse string. Err: %v", a.UpdatedAt, err)
+		}
+		a.UpdatedAt = temp
+		return string(bt), nil
+	case 3: // AccountID
+		temp := a.AccountID
+		if err := a.AccountID.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'AccountID' value: '%v' to parse string. Err: %v", a.AccountID, err)
+		}
+		bt, err := a.AccountID.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'AccountID' value: '%v' to parse string. Err: %v", a.AccountID, err)
+		}
+		a.AccountID = temp
+		return string(bt), nil
+	case 4: // Name
+		return value, nil
+	case 5: // KeyPrefix
+		return value, nil
+	case 6: // KeyHash
+		return "", errors.Wrap(mapping.ErrFieldNotParser, "field 'KeyHash' doesn't have string setter.")
+	case 7: // Scopes
+		return value, nil
+	case 8: // ExpiresAt
+		var base time.Time
+		temp := &base
+		if err := temp.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'ExpiresAt' value: '%v' to parse string. Err: %v", a.ExpiresAt, err)
+		}
+		bt, err := temp.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'ExpiresAt' value: '%v' to parse string. Err: %v", a.ExpiresAt, err)
+		}
+
+		return string(bt), nil
+	case 9: // LastUsedAt
+		var base time.Time
+		temp := &base
+		if err := temp.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'LastUsedAt' value: '%v' to parse string. Err: %v", a.LastUsedAt, err)
+		}
+		bt, err := temp.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'LastUsedAt' value: '%v' to parse string. Err: %v", a.LastUsedAt, err)
+		}
+
+		return string(bt), nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: APIKey'", field.Name())
+}
@@ -0,0 +1,95 @@
+package accounts
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+)
+
+// Compile time check if Client implements auth.Account interface.
+var (
+	_ auth.Account = &Client{}
+	_ auth.Salter  = &Client{}
+)
+
+// Client is the OAuth2 client model used for the service accounts authentication with the client credentials grant.
+// It implements auth.Account interface, where the client identifier is the username and the hashed client secret
+// is the password hash. This allows to hash and compare the secrets using auth.Authenticator.
+type Client struct {
+	ID uuid.UUID
+	// Timestamps for the client.
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt *time.Time `codec:";omitempty"`
+	// ClientID is the unique client identifier.
+	ClientID string `db:";unique"`
+	// Name is the human readable client name.
+	Name string
+	// SecretHash is the hash obtained by hashing the client secret.
+	SecretHash []byte `codec:"-" json:"-"`
+	SecretSalt []byte `codec:"-" json:"-"`
+	// Scopes are the authorization scopes the client is allowed to request.
+	Scopes []string
+}
+
+// BeforeInsert is a hook before insertion of the client.
+func (c *Client) BeforeInsert(context.Context, database.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// AllowedScopes gets the authorization scopes the client is allowed to request.
+func (c *Client) AllowedScopes() []string {
+	return c.Scopes
+}
+
+// GetSalt implements auth.SaltGetter interface.
+func (c *Client) GetSalt() []byte {
+	return c.SecretSalt
+}
+
+// SetSalt implements auth.SaltSetter interface.
+func (c *Client) SetSalt(salt []byte) {
+	c.SecretSalt = salt
+}
+
+// SaltField implements auth.SaltFielder interface.
+func (c *Client) SaltField() string {
+	return "SecretSalt"
+}
+
+// GetUsername implements auth.Account.
+func (c *Client) GetUsername() string {
+	return c.ClientID
+}
+
+// SetUsername implements auth.Account.
+func (c *Client) SetUsername(username string) {
+	c.ClientID = username
+}
+
+// GetPasswordHash implements auth.Account.
+func (c *Client) GetPasswordHash() []byte {
+	return c.SecretHash
+}
+
+// SetPasswordHash implements auth.Account.
+func (c *Client) SetPasswordHash(hash []byte) {
+	c.SecretHash = hash
+}
+
+// UsernameField implements auth.Account.
+func (c *Client) UsernameField() string {
+	return "ClientID"
+}
+
+// PasswordHashField implements auth.Account.
+func (c *Client) PasswordHashField() string {
+	return "SecretHash"
+}
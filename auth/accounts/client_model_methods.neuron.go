@@ -0,0 +1,507 @@
+// Code generated by neurogonesis. DO NOT EDIT.
+// This file was generated at:
+// Mon, 19 Oct 2026 05:01:39 +0000
+
+package accounts
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// Compile time check if Client implements mapping.Model interface.
+var _ mapping.Model = &Client{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (c *Client) IsPrimaryKeyZero() bool {
+	return c.ID == uuid.UUID([16]byte{})
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (c *Client) GetPrimaryKeyValue() interface{} {
+	return c.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (c *Client) GetPrimaryKeyStringValue() (string, error) {
+	id, err := c.ID.MarshalText()
+	if err != nil {
+		return "", errors.Wrapf(mapping.ErrFieldValue, "invalid primary field value: %v to parse string. Err: %v", c.ID, err)
+	}
+	return string(id), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (c *Client) GetPrimaryKeyAddress() interface{} {
+	return &c.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (c *Client) GetPrimaryKeyHashableValue() interface{} {
+	return c.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (c *Client) GetPrimaryKeyZeroValue() interface{} {
+	return uuid.UUID([16]byte{})
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (c *Client) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(uuid.UUID); ok {
+		c.ID = _v
+		return nil
+	} else if _v, ok := value.([16]byte); ok {
+		c.ID = uuid.UUID(_v)
+	}
+	return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: '%T'",
+		value, c)
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (c *Client) SetPrimaryKeyStringValue(value string) error {
+	if err := c.ID.UnmarshalText([]byte(value)); err != nil {
+		return errors.Wrapf(mapping.ErrFieldValue, "invalid primary field value: %v to parse string. Err: %v", c.ID, err)
+	}
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (c *Client) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrNilModel, "provided nil model to set from")
+	}
+	from, ok := model.(*Client)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*c = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (c *Client) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return c.ID, nil
+	case 1: // CreatedAt
+		return c.CreatedAt, nil
+	case 2: // UpdatedAt
+		return c.UpdatedAt, nil
+	case 3: // DeletedAt
+		return c.DeletedAt, nil
+	case 4: // ClientID
+		return c.ClientID, nil
+	case 5: // Name
+		return c.Name, nil
+	case 6: // SecretHash
+		return c.SecretHash, nil
+	case 7: // SecretSalt
+		return c.SecretSalt, nil
+	case 8: // Scopes
+		return c.Scopes, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Client'", field.Name())
+	}
+}
+
+// Compile time check if Client implements mapping.Fielder interface.
+var _ mapping.Fielder = &Client{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (c *Client) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &c.ID, nil
+	case 1: // CreatedAt
+		return &c.CreatedAt, nil
+	case 2: // UpdatedAt
+		return &c.UpdatedAt, nil
+	case 3: // DeletedAt
+		return &c.DeletedAt, nil
+	case 4: // ClientID
+		return &c.ClientID, nil
+	case 5: // Name
+		return &c.Name, nil
+	case 6: // SecretHash
+		return &c.SecretHash, nil
+	case 7: // SecretSalt
+		return &c.SecretSalt, nil
+	case 8: // Scopes
+		return &c.Scopes, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Client'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (c *Client) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return uuid.UUID([16]byte{}), nil
+	case 1: // CreatedAt
+		return time.Time{}, nil
+	case 2: // UpdatedAt
+		return time.Time{}, nil
+	case 3: // DeletedAt
+		return nil, nil
+	case 4: // ClientID
+		return "", nil
+	case 5: // Name
+		return "", nil
+	case 6: // SecretHash
+		return nil, nil
+	case 7: // SecretSalt
+		return nil, nil
+	case 8: // Scopes
+		return nil, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (c *Client) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return c.ID == uuid.UUID([16]byte{}), nil
+	case 1: // CreatedAt
+		return c.CreatedAt == time.Time{}, nil
+	case 2: // UpdatedAt
+		return c.UpdatedAt == time.Time{}, nil
+	case 3: // DeletedAt
+		return c.DeletedAt == nil, nil
+	case 4: // ClientID
+		return c.ClientID == "", nil
+	case 5: // Name
+		return c.Name == "", nil
+	case 6: // SecretHash
+		return len(c.SecretHash) == 0, nil
+	case 7: // SecretSalt
+		return len(c.SecretSalt) == 0, nil
+	case 8: // Scopes
+		return len(c.Scopes) == 0, nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (c *Client) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		c.ID = uuid.UUID([16]byte{})
+	case 1: // CreatedAt
+		c.CreatedAt = time.Time{}
+	case 2: // UpdatedAt
+		c.UpdatedAt = time.Time{}
+	case 3: // DeletedAt
+		c.DeletedAt = nil
+	case 4: // ClientID
+		c.ClientID = ""
+	case 5: // Name
+		c.Name = ""
+	case 6: // SecretHash
+		c.SecretHash = nil
+	case 7: // SecretSalt
+		c.SecretSalt = nil
+	case 8: // Scopes
+		c.Scopes = nil
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (c *Client) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return c.ID, nil
+	case 1: // CreatedAt
+		return c.CreatedAt, nil
+	case 2: // UpdatedAt
+		return c.UpdatedAt, nil
+	case 3: // DeletedAt
+		if c.DeletedAt == nil {
+			return nil, nil
+		}
+		return *c.DeletedAt, nil
+	case 4: // ClientID
+		return c.ClientID, nil
+	case 5: // Name
+		return c.Name, nil
+	case 6: // SecretHash
+		return string(c.SecretHash), nil
+	case 7: // SecretSalt
+		return string(c.SecretSalt), nil
+	case 8: // Scopes
+		return c.Scopes, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'Client'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (c *Client) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return c.ID, nil
+	case 1: // CreatedAt
+		return c.CreatedAt, nil
+	case 2: // UpdatedAt
+		return c.UpdatedAt, nil
+	case 3: // DeletedAt
+		return c.DeletedAt, nil
+	case 4: // ClientID
+		return c.ClientID, nil
+	case 5: // Name
+		return c.Name, nil
+	case 6: // SecretHash
+		return c.SecretHash, nil
+	case 7: // SecretSalt
+		return c.SecretSalt, nil
+	case 8: // Scopes
+		return c.Scopes, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Client'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (c *Client) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(uuid.UUID); ok {
+			c.ID = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			if len(generic) > 16 {
+				return errors.Wrapf(mapping.ErrFieldValue, "provided too many values for the field: 'ID")
+			}
+			for i, item := range generic {
+				if _v, ok := item.(byte); ok {
+					c.ID[i] = _v
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Checked wrapped types.
+		if _v, ok := value.([16]byte); ok {
+			c.ID = uuid.UUID(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 1: // CreatedAt
+		if _v, ok := value.(time.Time); ok {
+			c.CreatedAt = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			c.CreatedAt = time.Time{}
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // UpdatedAt
+		if _v, ok := value.(time.Time); ok {
+			c.UpdatedAt = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			c.UpdatedAt = time.Time{}
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 3: // DeletedAt
+		if value == nil {
+			c.DeletedAt = nil
+			return nil
+		}
+		if _v, ok := value.(*time.Time); ok {
+			c.DeletedAt = _v
+			return nil
+		}
+		// Check if it is non-pointer value.
+		if _v, ok := value.(time.Time); ok {
+			c.DeletedAt = &_v
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 4: // ClientID
+		if _v, ok := value.(string); ok {
+			c.ClientID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			c.ClientID = ""
+			return nil
+		}
+
+		// Check alternate types for the ClientID.
+		if _v, ok := value.([]byte); ok {
+			c.ClientID = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 5: // Name
+		if _v, ok := value.(string); ok {
+			c.Name = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			c.Name = ""
+			return nil
+		}
+
+		// Check alternate types for the Name.
+		if _v, ok := value.([]byte); ok {
+			c.Name = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 6: // SecretHash
+		if value == nil {
+			c.SecretHash = nil
+			return nil
+		}
+		if _v, ok := value.([]byte); ok {
+			c.SecretHash = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(byte); ok {
+					c.SecretHash = append(c.SecretHash, _v)
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Check alternate types for the SecretHash.
+		if _v, ok := value.(string); ok {
+			c.SecretHash = []byte(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 7: // SecretSalt
+		if value == nil {
+			c.SecretSalt = nil
+			return nil
+		}
+		if _v, ok := value.([]byte); ok {
+			c.SecretSalt = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(byte); ok {
+					c.SecretSalt = append(c.SecretSalt, _v)
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Check alternate types for the SecretSalt.
+		if _v, ok := value.(string); ok {
+			c.SecretSalt = []byte(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 8: // Scopes
+		if value == nil {
+			c.Scopes = nil
+			return nil
+		}
+		if _v, ok := value.([]string); ok {
+			c.Scopes = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(string); ok {
+					c.Scopes = append(c.Scopes, _v)
+					continue
+				}
+				// Check alternate types for the Scopes.
+				if _v, ok := item.([]byte); ok {
+					c.Scopes = append(c.Scopes, string(_v))
+					continue
+				}
+				return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+			}
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'Client'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (c *Client) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		temp := c.ID
+		if err := c.ID.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'ID' value: '%v' to parse string. Err: %v", c.ID, err)
+		}
+		bt, err := c.ID.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'ID' value: '%v' to parse string. Err: %v", c.ID, err)
+		}
+		c.ID = temp
+		return string(bt), nil
+	case 1: // CreatedAt
+		temp := c.CreatedAt
+		if err := c.CreatedAt.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'CreatedAt' value: '%v' to parse string. Err: %v", c.CreatedAt, err)
+		}
+		bt, err := c.CreatedAt.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'CreatedAt' value: '%v' to parse string. Err: %v", c.CreatedAt, err)
+		}
+		c.CreatedAt = temp
+		return string(bt), nil
+	case 2: // UpdatedAt
+		temp := c.UpdatedAt
+		if err := c.UpdatedAt.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'UpdatedAt' value: '%v' to parse string. Err: %v", c.UpdatedAt, err)
+		}
+		bt, err := c.UpdatedAt.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'UpdatedAt' value: '%v' to parse string. Err: %v", c.UpdatedAt, err)
+		}
+		c.UpdatedAt = temp
+		return string(bt), nil
+	case 3: // DeletedAt
+		var base time.Time
+		temp := &base
+		if err := temp.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'DeletedAt' value: '%v' to parse string. Err: %v", c.DeletedAt, err)
+		}
+		bt, err := temp.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'DeletedAt' value: '%v' to parse string. Err: %v", c.DeletedAt, err)
+		}
+
+		return string(bt), nil
+	case 4: // ClientID
+		return value, nil
+	case 5: // Name
+		return value, nil
+	case 6: // SecretHash
+		return "", errors.Wrap(mapping.ErrFieldNotParser, "field 'SecretHash' doesn't have string setter.")
+	case 7: // SecretSalt
+		return "", errors.Wrap(mapping.ErrFieldNotParser, "field 'SecretSalt' doesn't have string setter.")
+	case 8: // Scopes
+		return value, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Client'", field.Name())
+}
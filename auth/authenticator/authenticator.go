@@ -1,11 +1,9 @@
 package authenticator
 
 import (
-	"crypto/md5"
-	"crypto/sha256"
-	"crypto/sha512"
-	"hash"
+	"crypto/subtle"
 
+	"golang.org/x/crypto/argon2"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/neuronlabs/neuron/auth"
@@ -20,44 +18,51 @@ var (
 // It is used to provide full authentication process for the
 type Authenticator struct {
 	Options *auth.AuthenticatorOptions
+	// Argon2Params are the parameters used for hashing the passwords with the Argon2id method.
+	Argon2Params *Argon2Params
 }
 
 // HashAndSetPassword implements auth.PasswordHasher interface.
+// The password hash is stored in a self describing format, so that the ComparePassword could detect the algorithm
+// used for each account.
 func (a *Authenticator) HashAndSetPassword(acc auth.Account, password *auth.Password) error {
 	switch a.Options.AuthenticateMethod {
 	case auth.BCrypt:
 		return a.setBCryptPassword(acc, password)
+	case Argon2id:
+		return a.setArgon2Password(acc, password)
 	default:
 		return a.setHashedPassword(acc, password)
 	}
 }
 
 // ComparePassword compares the password (with optional salt) hash with the provided 'password'.
+// The hashing algorithm is detected from the account's password hash. Legacy raw salted MD5, SHA256 and SHA512 hashes
+// are recognized by their length.
 func (a *Authenticator) ComparePassword(acc auth.Account, password string) error {
-	if a.Options.AuthenticateMethod == auth.BCrypt {
-		err := bcrypt.CompareHashAndPassword(acc.GetPasswordHash(), []byte(password))
-		if err != nil {
+	h, err := decodeHash(acc)
+	if err != nil {
+		return err
+	}
+	switch h.method {
+	case methodBCrypt:
+		if err = bcrypt.CompareHashAndPassword(h.key, []byte(password)); err != nil {
 			return errors.Wrap(auth.ErrInvalidPassword, "passwords doesn't match")
 		}
 		return nil
+	case methodArgon2id:
+		key := argon2.IDKey([]byte(password), h.salt, h.argon2.Time, h.argon2.Memory, h.argon2.Threads, h.argon2.KeyLength)
+		if subtle.ConstantTimeCompare(key, h.key) != 1 {
+			return errors.Wrap(auth.ErrInvalidPassword, "password doesn't match")
+		}
+		return nil
 	}
 	// The password is based on the hash + salt.
-	var h hash.Hash
-	switch a.Options.AuthenticateMethod {
-	case auth.MD5:
-		h = md5.New()
-	case auth.SHA256:
-		h = sha256.New()
-	case auth.SHA512:
-		h = sha512.New()
-	default:
+	hf := newHash(h.method)
+	if hf == nil {
 		return errors.Wrap(auth.ErrInternalError, "unsupported authentication method")
 	}
-	var salt []byte
-	if salter, ok := acc.(auth.SaltGetter); ok {
-		salt = salter.GetSalt()
-	}
-	match, err := auth.CompareHashPassword(h, password, acc.GetPasswordHash(), salt)
+	match, err := auth.CompareHashPassword(hf, password, h.key, h.salt)
 	if err != nil {
 		return err
 	}
@@ -67,6 +72,32 @@ func (a *Authenticator) ComparePassword(acc auth.Account, password string) error
 	return errors.Wrap(auth.ErrInvalidPassword, "password doesn't match")
 }
 
+// NeedsRehash checks if the account's password hash should be recomputed. This is the case when the hash was created
+// using different method or parameters than the ones currently set for the authenticator, or if it is stored in
+// the legacy raw format.
+func (a *Authenticator) NeedsRehash(acc auth.Account) bool {
+	h, err := decodeHash(acc)
+	if err != nil {
+		return false
+	}
+	if !h.selfDescribing {
+		return true
+	}
+	if h.method != methodName(a.Options.AuthenticateMethod) {
+		return true
+	}
+	switch h.method {
+	case methodBCrypt:
+		return h.bcryptCost != a.Options.BCryptCost
+	case methodArgon2id:
+		p := a.argon2Params()
+		return h.argon2.Time != p.Time || h.argon2.Memory != p.Memory || h.argon2.Threads != p.Threads ||
+			h.argon2.KeyLength != p.KeyLength || h.argon2.SaltLength != p.SaltLength
+	default:
+		return len(h.salt) != a.Options.SaltLength
+	}
+}
+
 // New creates new authenticator for provided options.
 // By default it uses in-memory store for the revoked tokens.
 func New(options ...auth.AuthenticatorOption) *Authenticator {
@@ -78,7 +109,8 @@ func New(options ...auth.AuthenticatorOption) *Authenticator {
 		op(o)
 	}
 	return &Authenticator{
-		Options: o,
+		Options:      o,
+		Argon2Params: DefaultArgon2Params(),
 	}
 }
 
@@ -89,6 +121,11 @@ func (a *Authenticator) validate() error {
 		if a.Options.BCryptCost < bcrypt.MinCost || a.Options.BCryptCost > bcrypt.MaxCost {
 			return errors.Wrapf(auth.ErrInitialization, "provided cost is out of possible values range <%d, %d>}", bcrypt.MinCost, bcrypt.MaxCost)
 		}
+	case Argon2id:
+		p := a.argon2Params()
+		if p.Time == 0 || p.Memory == 0 || p.Threads == 0 || p.KeyLength == 0 || p.SaltLength == 0 {
+			return errors.Wrap(auth.ErrInitialization, "provided zero value argon2id parameter")
+		}
 	case auth.MD5, auth.SHA256, auth.SHA512:
 		if a.Options.SaltLength == 0 {
 			return errors.Wrap(auth.ErrInitialization, "provided 0 value for the authentication salt length")
@@ -99,6 +136,13 @@ func (a *Authenticator) validate() error {
 	return nil
 }
 
+func (a *Authenticator) argon2Params() *Argon2Params {
+	if a.Argon2Params == nil {
+		return DefaultArgon2Params()
+	}
+	return a.Argon2Params
+}
+
 func (a *Authenticator) setBCryptPassword(acc auth.Account, password *auth.Password) error {
 	hashed, err := bcrypt.GenerateFromPassword([]byte(password.Password), a.Options.BCryptCost)
 	if err != nil {
@@ -108,6 +152,17 @@ func (a *Authenticator) setBCryptPassword(acc auth.Account, password *auth.Passw
 	return nil
 }
 
+func (a *Authenticator) setArgon2Password(acc auth.Account, password *auth.Password) error {
+	p := a.argon2Params()
+	salt, err := auth.GenerateSalt(p.SaltLength)
+	if err != nil {
+		return err
+	}
+	key := argon2.IDKey([]byte(password.Password), salt, p.Time, p.Memory, p.Threads, p.KeyLength)
+	acc.SetPasswordHash(encodeArgon2Hash(p, salt, key))
+	return nil
+}
+
 func (a *Authenticator) setHashedPassword(acc auth.Account, password *auth.Password) error {
 	var (
 		salt []byte
@@ -122,19 +177,15 @@ func (a *Authenticator) setHashedPassword(acc auth.Account, password *auth.Passw
 			saltSetter.SetSalt(salt)
 		}
 	}
-	var h hash.Hash
-	switch a.Options.AuthenticateMethod {
-	case auth.MD5:
-		h = md5.New()
-	case auth.SHA256:
-		h = sha256.New()
-	case auth.SHA512:
-		h = sha512.New()
+	method := methodName(a.Options.AuthenticateMethod)
+	h := newHash(method)
+	if h == nil {
+		return errors.Wrap(auth.ErrInternalError, "unsupported authentication method")
 	}
 	hashed, err := password.Hash(h, salt)
 	if err != nil {
 		return err
 	}
-	acc.SetPasswordHash(hashed)
+	acc.SetPasswordHash(encodeSaltedHash(method, salt, hashed))
 	return nil
 }
@@ -4,5 +4,6 @@ go 1.13
 
 require (
 	github.com/neuronlabs/neuron v0.21.6
+	github.com/stretchr/testify v1.6.1
 	golang.org/x/crypto v0.0.0-20200728195943-123391ffb6de
 )
@@ -0,0 +1,204 @@
+package authenticator
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// MethodsBase is the first value of the authenticate methods defined by this package. The auth package enumerates
+// its methods (BCrypt, MD5, SHA256, SHA512) starting from zero, thus the methods defined here start far above them,
+// so that these would not collide with the methods added to the auth package in the future.
+const MethodsBase auth.AuthenticateMethod = 1 << 16
+
+const (
+	// Argon2id is the argon2id password hashing method. It is the recommended method for the new deployments.
+	Argon2id = MethodsBase + iota
+)
+
+// Argon2Params are the parameters used for the argon2id password hashing.
+// These parameters are encoded within the password hash, thus they could be changed without breaking stored hashes.
+type Argon2Params struct {
+	// Time is the number of passes over the memory.
+	Time uint32
+	// Memory is the size of the memory in KiB.
+	Memory uint32
+	// Threads is the number of the threads used by the algorithm.
+	Threads uint8
+	// KeyLength is the length of the resulting key.
+	KeyLength uint32
+	// SaltLength is the length of the random salt.
+	SaltLength int
+}
+
+// DefaultArgon2Params returns default argon2id parameters.
+func DefaultArgon2Params() *Argon2Params {
+	return &Argon2Params{
+		Time:       1,
+		Memory:     64 * 1024,
+		Threads:    4,
+		KeyLength:  32,
+		SaltLength: 16,
+	}
+}
+
+// passwordHash is the decoded password hash.
+type passwordHash struct {
+	method hashMethod
+	// selfDescribing defines if the hash was stored in the self describing format.
+	selfDescribing bool
+	salt, key      []byte
+	bcryptCost     int
+	argon2         Argon2Params
+}
+
+// hashMethod is the name of the hashing method used in the self describing hash format.
+type hashMethod string
+
+const (
+	methodBCrypt   hashMethod = "bcrypt"
+	methodArgon2id hashMethod = "argon2id"
+	methodMD5      hashMethod = "md5"
+	methodSHA256   hashMethod = "sha256"
+	methodSHA512   hashMethod = "sha512"
+)
+
+func methodName(method auth.AuthenticateMethod) hashMethod {
+	switch method {
+	case auth.BCrypt:
+		return methodBCrypt
+	case Argon2id:
+		return methodArgon2id
+	case auth.MD5:
+		return methodMD5
+	case auth.SHA256:
+		return methodSHA256
+	case auth.SHA512:
+		return methodSHA512
+	}
+	return ""
+}
+
+func newHash(method hashMethod) hash.Hash {
+	switch method {
+	case methodMD5:
+		return md5.New()
+	case methodSHA256:
+		return sha256.New()
+	case methodSHA512:
+		return sha512.New()
+	}
+	return nil
+}
+
+var b64 = base64.RawStdEncoding
+
+// encodeArgon2Hash encodes argon2id key in the PHC string format:
+//
+//	$argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>
+func encodeArgon2Hash(p *Argon2Params, salt, key []byte) []byte {
+	return []byte(fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", methodArgon2id, argon2.Version, p.Memory, p.Time, p.Threads,
+		b64.EncodeToString(salt), b64.EncodeToString(key)))
+}
+
+// encodeSaltedHash encodes salted hash in the format: $<method>$<salt>$<hash>
+func encodeSaltedHash(method hashMethod, salt, key []byte) []byte {
+	return []byte(fmt.Sprintf("$%s$%s$%s", method, b64.EncodeToString(salt), b64.EncodeToString(key)))
+}
+
+// decodeHash decodes the password hash stored in the account. Besides the self describing formats,
+// it supports also legacy raw md5, sha256 and sha512 digests, with the salt stored in the account.
+// Unrecognized or empty hashes results in the auth.ErrInvalidPassword error.
+func decodeHash(acc auth.Account) (*passwordHash, error) {
+	stored := acc.GetPasswordHash()
+	switch {
+	case bytes.HasPrefix(stored, []byte("$2a$")), bytes.HasPrefix(stored, []byte("$2b$")), bytes.HasPrefix(stored, []byte("$2y$")):
+		cost, err := bcrypt.Cost(stored)
+		if err != nil {
+			return nil, errors.Wrapf(auth.ErrInvalidPassword, "malformed bcrypt hash: %v", err)
+		}
+		return &passwordHash{method: methodBCrypt, selfDescribing: true, key: stored, bcryptCost: cost}, nil
+	case bytes.HasPrefix(stored, []byte("$"+string(methodArgon2id)+"$")):
+		return decodeArgon2Hash(stored)
+	case bytes.HasPrefix(stored, []byte("$")):
+		parts := strings.Split(string(stored), "$")
+		if len(parts) == 4 {
+			method := hashMethod(parts[1])
+			if newHash(method) != nil {
+				salt, err := b64.DecodeString(parts[2])
+				if err != nil {
+					return nil, errors.Wrapf(auth.ErrInvalidPassword, "malformed password hash salt: %v", err)
+				}
+				key, err := b64.DecodeString(parts[3])
+				if err != nil {
+					return nil, errors.Wrapf(auth.ErrInvalidPassword, "malformed password hash: %v", err)
+				}
+				return &passwordHash{method: method, selfDescribing: true, salt: salt, key: key}, nil
+			}
+		}
+	}
+
+	// Legacy raw digests - the method is recognized by the digest length.
+	h := &passwordHash{key: stored}
+	if saltGetter, ok := acc.(auth.SaltGetter); ok {
+		h.salt = saltGetter.GetSalt()
+	}
+	switch len(stored) {
+	case md5.Size:
+		h.method = methodMD5
+	case sha256.Size:
+		h.method = methodSHA256
+	case sha512.Size:
+		h.method = methodSHA512
+	default:
+		return nil, errors.Wrap(auth.ErrInvalidPassword, "unrecognized password hash encoding")
+	}
+	return h, nil
+}
+
+func decodeArgon2Hash(stored []byte) (*passwordHash, error) {
+	// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<key>
+	parts := strings.Split(string(stored), "$")
+	if len(parts) != 6 {
+		return nil, errors.Wrap(auth.ErrInvalidPassword, "malformed argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, errors.Wrapf(auth.ErrInvalidPassword, "malformed argon2id hash version: %v", err)
+	}
+	if version != argon2.Version {
+		return nil, errors.Wrapf(auth.ErrInvalidPassword, "unsupported argon2 version: %d", version)
+	}
+	h := &passwordHash{method: methodArgon2id, selfDescribing: true}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.argon2.Memory, &h.argon2.Time, &h.argon2.Threads); err != nil {
+		return nil, errors.Wrapf(auth.ErrInvalidPassword, "malformed argon2id hash parameters: %v", err)
+	}
+	// Invalid parameters makes the argon2 key derivation panic.
+	if h.argon2.Time == 0 || h.argon2.Threads == 0 || h.argon2.Memory == 0 {
+		return nil, errors.Wrap(auth.ErrInvalidPassword, "invalid argon2id hash parameters")
+	}
+	var err error
+	if h.salt, err = b64.DecodeString(parts[4]); err != nil {
+		return nil, errors.Wrapf(auth.ErrInvalidPassword, "malformed argon2id hash salt: %v", err)
+	}
+	if h.key, err = b64.DecodeString(parts[5]); err != nil {
+		return nil, errors.Wrapf(auth.ErrInvalidPassword, "malformed argon2id hash key: %v", err)
+	}
+	if len(h.salt) == 0 || len(h.key) == 0 {
+		return nil, errors.Wrap(auth.ErrInvalidPassword, "empty argon2id hash salt or key")
+	}
+	h.argon2.KeyLength = uint32(len(h.key))
+	h.argon2.SaltLength = len(h.salt)
+	return h, nil
+}
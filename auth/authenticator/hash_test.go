@@ -0,0 +1,123 @@
+package authenticator
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// testAccount is the account used by the tests. The authenticator uses only the password hash and salt methods.
+type testAccount struct {
+	mapping.Model
+	username   string
+	hash, salt []byte
+}
+
+func (t *testAccount) GetUsername() string         { return t.username }
+func (t *testAccount) SetUsername(username string) { t.username = username }
+func (t *testAccount) GetPasswordHash() []byte     { return t.hash }
+func (t *testAccount) SetPasswordHash(hash []byte) { t.hash = hash }
+func (t *testAccount) UsernameField() string       { return "Username" }
+func (t *testAccount) PasswordHashField() string   { return "PasswordHash" }
+func (t *testAccount) GetSalt() []byte             { return t.salt }
+func (t *testAccount) SetSalt(salt []byte)         { t.salt = salt }
+func (t *testAccount) SaltField() string           { return "Salt" }
+
+func TestArgon2id(t *testing.T) {
+	a := New(auth.AuthenticatorMethod(Argon2id))
+	require.NoError(t, a.validate())
+	a.Argon2Params.Memory = 1024
+
+	acc := &testAccount{}
+	require.NoError(t, a.HashAndSetPassword(acc, auth.NewPassword("Secret-Password1")))
+	assert.Contains(t, string(acc.hash), "$argon2id$v=19$m=1024,t=1,p=4$")
+
+	assert.NoError(t, a.ComparePassword(acc, "Secret-Password1"))
+	err := a.ComparePassword(acc, "Secret-Password2")
+	assert.True(t, errors.Is(err, auth.ErrInvalidPassword))
+
+	assert.False(t, a.NeedsRehash(acc))
+	// Changing parameters requires the rehash, but the old hash is still valid.
+	a.Argon2Params.Time = 2
+	assert.True(t, a.NeedsRehash(acc))
+	assert.NoError(t, a.ComparePassword(acc, "Secret-Password1"))
+}
+
+func TestMethods(t *testing.T) {
+	for _, method := range []auth.AuthenticateMethod{auth.BCrypt, auth.MD5, auth.SHA256, auth.SHA512} {
+		assert.NotEqual(t, method, Argon2id)
+	}
+	assert.Equal(t, methodArgon2id, methodName(Argon2id))
+}
+
+func TestRehash(t *testing.T) {
+	bcryptAuthenticator := New(auth.AuthenticatorMethod(auth.BCrypt), auth.AuthenticatorBCryptCost(4))
+	acc := &testAccount{}
+	require.NoError(t, bcryptAuthenticator.HashAndSetPassword(acc, auth.NewPassword("Secret-Password1")))
+	assert.False(t, bcryptAuthenticator.NeedsRehash(acc))
+
+	// The hash is self describing, thus the argon2id authenticator is able to compare it and requires the rehash.
+	a := New(auth.AuthenticatorMethod(Argon2id))
+	a.Argon2Params.Memory = 1024
+	assert.NoError(t, a.ComparePassword(acc, "Secret-Password1"))
+	assert.True(t, a.NeedsRehash(acc))
+
+	require.NoError(t, a.HashAndSetPassword(acc, auth.NewPassword("Secret-Password1")))
+	assert.False(t, a.NeedsRehash(acc))
+	assert.NoError(t, a.ComparePassword(acc, "Secret-Password1"))
+}
+
+func TestLegacyHashes(t *testing.T) {
+	salt := []byte("legacy-salt")
+	hashed, err := auth.NewPassword("Secret-Password1").Hash(sha256.New(), salt)
+	require.NoError(t, err)
+	require.Len(t, hashed, sha256.Size)
+
+	// The legacy sha256 digests are recognized by their length, and the salt is stored in the account.
+	acc := &testAccount{hash: hashed, salt: salt}
+	a := New(auth.AuthenticatorMethod(Argon2id))
+	assert.NoError(t, a.ComparePassword(acc, "Secret-Password1"))
+	assert.True(t, errors.Is(a.ComparePassword(acc, "Secret-Password2"), auth.ErrInvalidPassword))
+	assert.True(t, a.NeedsRehash(acc))
+
+	// Self describing salted hash.
+	sha := New(auth.AuthenticatorMethod(auth.SHA256))
+	acc = &testAccount{}
+	require.NoError(t, sha.HashAndSetPassword(acc, auth.NewPassword("Secret-Password1")))
+	assert.Contains(t, string(acc.hash), "$sha256$")
+	assert.NoError(t, sha.ComparePassword(acc, "Secret-Password1"))
+	assert.False(t, sha.NeedsRehash(acc))
+	assert.True(t, a.NeedsRehash(acc))
+}
+
+func TestMalformedHashes(t *testing.T) {
+	a := New(auth.AuthenticatorMethod(Argon2id))
+	hashes := map[string]string{
+		"Empty":             "",
+		"UnknownLength":     "not-a-hash",
+		"UnknownMethod":     "$unknown$c2FsdA$a2V5",
+		"BCrypt":            "$2a$10$invalid",
+		"Argon2Parts":       "$argon2id$v=19$m=1024,t=1,p=4$c2FsdA",
+		"Argon2Version":     "$argon2id$v=16$m=1024,t=1,p=4$c2FsdA$a2V5",
+		"Argon2ZeroParams":  "$argon2id$v=19$m=0,t=0,p=0$c2FsdA$a2V5",
+		"Argon2ZeroThreads": "$argon2id$v=19$m=1024,t=1,p=0$c2FsdA$a2V5",
+		"Argon2Params":      "$argon2id$v=19$m=a,t=1,p=4$c2FsdA$a2V5",
+		"Argon2EmptyKey":    "$argon2id$v=19$m=1024,t=1,p=4$c2FsdA$",
+		"Argon2Salt":        "$argon2id$v=19$m=1024,t=1,p=4$!!!$a2V5",
+	}
+	for name, hash := range hashes {
+		t.Run(name, func(t *testing.T) {
+			acc := &testAccount{hash: []byte(hash)}
+			err := a.ComparePassword(acc, "Secret-Password1")
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, auth.ErrInvalidPassword), "%v", err)
+			assert.False(t, a.NeedsRehash(acc))
+		})
+	}
+}
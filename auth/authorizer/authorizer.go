@@ -2,13 +2,17 @@ package authorizer
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/neuronlabs/neuron/auth"
 	"github.com/neuronlabs/neuron/core"
 	"github.com/neuronlabs/neuron/database"
 	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
 	"github.com/neuronlabs/neuron/query"
 	"github.com/neuronlabs/neuron/repository"
+	"github.com/neuronlabs/neuron/store"
 )
 
 var (
@@ -27,14 +31,30 @@ var (
 type Authorizer struct {
 	Options *Options
 
-	c  *core.Controller
-	db database.DB
+	c        *core.Controller
+	db       database.DB
+	policies map[*mapping.ModelStruct][]*Policy
+	// loadAuthorization loads the account authorization stored in the cache.
+	loadAuthorization func(ctx context.Context, accountID string) (*cachedAuthorization, error)
+}
+
+// ClientPrincipal is the principal of the OAuth2 client authorized with the client credentials grant.
+// The clients are not related with any roles, thus these are authorized only with the scopes granted for the client.
+// Any other account implementing auth.Scoper is authorized with the intersection of its scope and its roles scopes.
+type ClientPrincipal interface {
+	auth.Scoper
+	// ClientPrincipal marks the account as the OAuth2 client principal.
+	ClientPrincipal()
 }
 
 // Options are the Authorizer options.
 type Options struct {
 	Repository    repository.Repository
 	MigrateModels bool
+	Policies      []*Policy
+	// Cache is the optional store used for caching the account roles and effective scopes.
+	Cache    store.Store
+	CacheTTL time.Duration
 }
 
 // Option is the function that sets the Options.
@@ -55,6 +75,25 @@ func WithModelMigration() Option {
 	}
 }
 
+// WithPolicies adds the resource level authorization policies.
+func WithPolicies(policies ...*Policy) Option {
+	return func(o *Options) {
+		o.Policies = append(o.Policies, policies...)
+	}
+}
+
+// WithCache sets the store used for caching the account authorizations with provided 'ttl'.
+// If the 'ttl' is not greater than zero the DefaultCacheTTL is used.
+func WithCache(s store.Store, ttl time.Duration) Option {
+	return func(o *Options) {
+		if ttl <= 0 {
+			ttl = DefaultCacheTTL
+		}
+		o.Cache = s
+		o.CacheTTL = ttl
+	}
+}
+
 // New creates new Authorizer with provided creation options.
 func New(options ...Option) *Authorizer {
 	o := &Options{
@@ -63,20 +102,30 @@ func New(options ...Option) *Authorizer {
 	for _, option := range options {
 		option(o)
 	}
-	return &Authorizer{
-		Options: o,
+	a := &Authorizer{
+		Options:  o,
+		policies: map[*mapping.ModelStruct][]*Policy{},
 	}
+	a.loadAuthorization = a.loadAccountAuthorization
+	return a
 }
 
 // Initialize implements core.Initializer interface.
 func (a *Authorizer) Initialize(c *core.Controller) error {
 	a.c = c
 	a.db = database.New(c)
+	for _, policy := range a.Options.Policies {
+		if err := policy.initialize(c); err != nil {
+			return err
+		}
+		a.policies[policy.mStruct] = append(a.policies[policy.mStruct], policy)
+	}
 	return nil
 }
 
-// Authorize checks if the account with provided ID is allowed to use the 'resource'.
-// Provided account must be authorized for ALL provided scopes.
+// Verify checks if the account is allowed to use the 'resource'.
+// Provided account must be authorized for ALL provided scopes. The roles are hierarchical - a role inherits
+// the scopes of all the roles with lower hierarchy value, and fulfills the allowed roles lower in the hierarchy.
 func (a *Authorizer) Verify(ctx context.Context, account auth.Account, options ...auth.VerifyOption) error {
 	accountID, err := account.GetPrimaryKeyStringValue()
 	if err != nil {
@@ -88,62 +137,76 @@ func (a *Authorizer) Verify(ctx context.Context, account auth.Account, options .
 		option(o)
 	}
 
-	// Prepare the query with provided accountID.
-	q := NRN_AccountRoles.QueryCtx(ctx, a.db).
-		Where("AccountID = ?", accountID)
+	// The OAuth2 clients are not related with any roles - they are verified with the scopes granted for the client.
+	if client, ok := account.(ClientPrincipal); ok {
+		if len(o.AllowedRoles) > 0 {
+			return errors.WrapDetf(auth.ErrForbidden, "not authorized")
+		}
+		return verifyTokenScope(client.Scope(), o.Scopes)
+	}
 
-	// Check allowed roles.
-	if len(o.AllowedRoles) > 0 {
-		names := make([]interface{}, len(o.AllowedRoles))
-		for i, r := range o.AllowedRoles {
-			names[i] = r.RoleName()
+	var (
+		roles  []*Role
+		cached *cachedAuthorization
+	)
+	if a.Options.Cache != nil {
+		if cached, err = a.getCachedAuthorization(ctx, accountID); err != nil {
+			return err
 		}
-		q.Where("Role.Name IN ?", names...)
+		roles = cached.roles()
+	} else if roles, err = a.accountRoles(ctx, a.db, accountID); err != nil {
+		return err
 	}
 
 	// Check disallowed roles.
-	if len(o.DisallowedRoles) > 0 {
-		names := make([]interface{}, len(o.DisallowedRoles))
-		for i, r := range o.DisallowedRoles {
-			names[i] = r.RoleName()
+	for _, disallowed := range o.DisallowedRoles {
+		for _, r := range roles {
+			if r.Name == disallowed.RoleName() {
+				return errors.WrapDetf(auth.ErrForbidden, "not authorized for the role: '%s'", r.Name)
+			}
 		}
-		q.Where("Role.Name NOT IN ?", names...)
 	}
 
-	// Scope queries.
-	if len(o.Scopes) > 0 {
-		names := make([]interface{}, len(o.Scopes))
-		for i, s := range o.Scopes {
-			names[i] = s.ScopeName()
-		}
-		scopes, err := NRN_AuthorizeScopes.QueryCtx(ctx, a.db).
-			Where("Name IN ?", names...).
-			IncludeRoles("ID").
-			Find()
+	// Check allowed roles. The roles higher in the hierarchy are allowed as well.
+	if len(o.AllowedRoles) > 0 {
+		allowed, err := a.hasAllowedRole(ctx, roles, o.AllowedRoles)
 		if err != nil {
 			return err
 		}
-		roleIDs := map[uint]struct{}{}
-		for _, scope := range scopes {
-			for _, r := range scope.Roles {
-				roleIDs[r.ID] = struct{}{}
+		if !allowed {
+			return errors.WrapDetf(auth.ErrForbidden, "not authorized")
+		}
+	}
+
+	// Check the scopes granted for the account roles and the roles lower in the hierarchy.
+	if len(o.Scopes) > 0 {
+		// The account authorized with the scoped credentials (i.e. scoped api key) is limited to the intersection
+		// of the credential scopes and the scopes of its roles.
+		if scoper, ok := account.(auth.Scoper); ok && scoper.Scope() != "" {
+			if err = verifyTokenScope(scoper.Scope(), o.Scopes); err != nil {
+				return err
 			}
 		}
-		var filterIDs []interface{}
-		for id := range roleIDs {
-			filterIDs = append(filterIDs, id)
+		if cached != nil {
+			return cached.verifyScopes(o.Scopes)
 		}
-		q.Where("RoleID IN ?", filterIDs...)
+		return a.verifyRoleScopes(ctx, roles, o.Scopes)
 	}
+	return nil
+}
 
-	count, err := q.Count()
-	if err != nil {
-		return err
+// verifyTokenScope checks if all the 'scopes' are within the space separated 'tokenScope'.
+func verifyTokenScope(tokenScope string, scopes []auth.Scope) error {
+	granted := map[string]struct{}{}
+	for _, scope := range strings.Fields(tokenScope) {
+		granted[scope] = struct{}{}
 	}
-	if count == 0 {
-		return nil
+	for _, scope := range scopes {
+		if _, ok := granted[scope.ScopeName()]; !ok {
+			return errors.WrapDetf(auth.ErrForbidden, "not authorized for the scope: '%s'", scope.ScopeName())
+		}
 	}
-	return errors.WrapDetf(auth.ErrForbidden, "not authorized")
+	return nil
 }
 
 func (a *Authorizer) getRole(ctx context.Context, db database.DB, role auth.Role) (*Role, error) {
@@ -0,0 +1,212 @@
+package authorizer
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/core"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+)
+
+// testRoles are the roles stored in the test authorizer cache.
+var testRoles = []cachedRole{
+	{ID: 1, Name: "admin", Hierarchy: 100},
+	{ID: 2, Name: "editor", Hierarchy: 50},
+	{ID: 3, Name: "viewer", Hierarchy: 10},
+}
+
+// newTestAuthorizer creates the authorizer with the cache containing the testRoles. The account authorizations
+// are served from the cache, so that the verification doesn't require the repository.
+func newTestAuthorizer(t *testing.T, options ...Option) (*Authorizer, *testStore) {
+	t.Helper()
+	s := &testStore{records: map[string]*store.Record{}}
+	c := newTestController(t)
+	a := New(append([]Option{WithCache(s, 0)}, options...)...)
+	require.NoError(t, a.Initialize(c))
+	c.Verifier = a
+
+	generation, err := a.cacheGeneration(context.Background())
+	require.NoError(t, err)
+	s.setJSON(t, cacheRolesKeyPrefix+generation, testRoles)
+	return a, s
+}
+
+// newTestController creates the controller with the authorizer models.
+func newTestController(t *testing.T) *core.Controller {
+	t.Helper()
+	c := core.NewDefault()
+	require.NoError(t, c.RegisterModels(Neuron_Models...))
+	return c
+}
+
+// cacheAccount stores the account authorization with provided role names and effective scopes in the cache.
+func (a *Authorizer) cacheAccount(t *testing.T, accountID string, roleNames []string, scopes ...string) {
+	t.Helper()
+	cached := &cachedAuthorization{Scopes: scopes}
+	for _, name := range roleNames {
+		for _, r := range testRoles {
+			if r.Name == name {
+				cached.Roles = append(cached.Roles, r)
+			}
+		}
+	}
+	a.Options.Cache.(*testStore).setJSON(t, a.accountKey(t, accountID), cached)
+}
+
+// accountKey gets current cache key of the account authorization.
+func (a *Authorizer) accountKey(t *testing.T, accountID string) string {
+	t.Helper()
+	generation, err := a.cacheGeneration(context.Background())
+	require.NoError(t, err)
+	version, err := a.cacheAccountVersion(context.Background(), accountID)
+	require.NoError(t, err)
+	return cacheAccountKey(generation, version, accountID)
+}
+
+func TestVerifyMultipleRoles(t *testing.T) {
+	ctx := context.Background()
+	a, _ := newTestAuthorizer(t)
+	// The account with multiple roles has the scopes of all of them.
+	a.cacheAccount(t, "1", []string{"viewer", "editor"}, "articles.read", "articles.write", "comments.write")
+	account := &testAccount{ID: "1"}
+
+	assert.NoError(t, a.Verify(ctx, account, auth.VerifyAllowedRoles(roleName("viewer"))))
+	assert.NoError(t, a.Verify(ctx, account, auth.VerifyAllowedRoles(roleName("editor"))))
+	assert.True(t, errors.Is(a.Verify(ctx, account, auth.VerifyAllowedRoles(roleName("admin"))), auth.ErrForbidden))
+	// The highest role decides the minimum hierarchy.
+	assert.NoError(t, a.Verify(ctx, account, VerifyMinimumHierarchy(50)))
+	// Any of the account roles is disallowed.
+	assert.True(t, errors.Is(a.Verify(ctx, account, auth.VerifyDisallowedRoles(roleName("viewer"))), auth.ErrForbidden))
+
+	t.Run("Scopes", func(t *testing.T) {
+		assert.NoError(t, a.Verify(ctx, account, auth.VerifyScopes(scopeName("articles.read"), scopeName("comments.write"))))
+		err := a.Verify(ctx, account, auth.VerifyScopes(scopeName("articles.read"), scopeName("articles.delete")))
+		assert.True(t, errors.Is(err, auth.ErrForbidden))
+	})
+
+	t.Run("ScopedCredentials", func(t *testing.T) {
+		// The scoped credentials are limited to the intersection of their scope and the account roles scopes.
+		scoped := &testScopedAccount{testAccount: account, scope: "articles.read articles.delete"}
+		assert.NoError(t, a.Verify(ctx, scoped, auth.VerifyScopes(scopeName("articles.read"))))
+		assert.True(t, errors.Is(a.Verify(ctx, scoped, auth.VerifyScopes(scopeName("articles.write"))), auth.ErrForbidden))
+		assert.True(t, errors.Is(a.Verify(ctx, scoped, auth.VerifyScopes(scopeName("articles.delete"))), auth.ErrForbidden))
+	})
+
+	t.Run("ClientPrincipal", func(t *testing.T) {
+		// The clients are verified only with their scope - even if the account with the same identifier has roles.
+		client := &testClientPrincipal{testScopedAccount{testAccount: account, scope: "reports.read"}}
+		assert.NoError(t, a.Verify(ctx, client, auth.VerifyScopes(scopeName("reports.read"))))
+		assert.True(t, errors.Is(a.Verify(ctx, client, auth.VerifyScopes(scopeName("articles.read"))), auth.ErrForbidden))
+		assert.True(t, errors.Is(a.Verify(ctx, client, auth.VerifyAllowedRoles(roleName("viewer"))), auth.ErrForbidden))
+	})
+}
+
+// roleName is the auth.Role implementation that is not the authorizer Role model.
+type roleName string
+
+func (r roleName) RoleName() string { return string(r) }
+
+// scopeName is the auth.Scope implementation that is not the authorizer AuthorizeScope model.
+type scopeName string
+
+func (s scopeName) ScopeName() string { return string(s) }
+
+// testAccount is the auth.Account implementation with the string primary key.
+type testAccount struct {
+	ID string
+}
+
+func (a *testAccount) NeuronCollectionName() string              { return "accounts" }
+func (a *testAccount) GetPrimaryKeyStringValue() (string, error) { return a.ID, nil }
+func (a *testAccount) GetPrimaryKeyValue() interface{}           { return a.ID }
+func (a *testAccount) GetPrimaryKeyHashableValue() interface{}   { return a.ID }
+func (a *testAccount) GetPrimaryKeyZeroValue() interface{}       { return "" }
+func (a *testAccount) GetPrimaryKeyAddress() interface{}         { return &a.ID }
+func (a *testAccount) IsPrimaryKeyZero() bool                    { return a.ID == "" }
+func (a *testAccount) SetPrimaryKeyValue(src interface{}) error  { a.ID, _ = src.(string); return nil }
+func (a *testAccount) SetPrimaryKeyStringValue(src string) error { a.ID = src; return nil }
+func (a *testAccount) GetUsername() string                       { return "account-" + a.ID }
+func (a *testAccount) SetUsername(string)                        {}
+func (a *testAccount) GetPasswordHash() []byte                   { return nil }
+func (a *testAccount) SetPasswordHash([]byte)                    {}
+func (a *testAccount) UsernameField() string                     { return "Username" }
+func (a *testAccount) PasswordHashField() string                 { return "PasswordHash" }
+
+// testScopedAccount is the account authenticated with the scoped credentials.
+type testScopedAccount struct {
+	*testAccount
+	scope string
+}
+
+func (a *testScopedAccount) Scope() string { return a.scope }
+
+// testClientPrincipal is the OAuth2 client principal.
+type testClientPrincipal struct {
+	testScopedAccount
+}
+
+func (c *testClientPrincipal) ClientPrincipal() {}
+
+// testStore is the in-memory store.Store implementation.
+type testStore struct {
+	mu      sync.Mutex
+	records map[string]*store.Record
+}
+
+func (s *testStore) Set(_ context.Context, record *store.Record, _ ...store.SetOption) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.Key] = record.Copy()
+	return nil
+}
+
+func (s *testStore) Get(_ context.Context, key string) (*store.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[key]
+	if !ok {
+		return nil, store.ErrRecordNotFound
+	}
+	return record.Copy(), nil
+}
+
+func (s *testStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[key]; !ok {
+		return store.ErrRecordNotFound
+	}
+	delete(s.records, key)
+	return nil
+}
+
+func (s *testStore) Find(_ context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	o := &store.FindPattern{}
+	for _, option := range options {
+		option(o)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []*store.Record
+	for key, record := range s.records {
+		if strings.HasPrefix(key, o.Prefix) && strings.HasSuffix(key, o.Suffix) {
+			records = append(records, record.Copy())
+		}
+	}
+	return records, nil
+}
+
+func (s *testStore) setJSON(t *testing.T, key string, value interface{}) {
+	t.Helper()
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	require.NoError(t, s.Set(context.Background(), &store.Record{Key: key, Value: data}))
+}
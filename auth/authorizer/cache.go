@@ -0,0 +1,297 @@
+package authorizer
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+)
+
+const (
+	cacheGenerationKey    = "nrn_authorizer_generation"
+	cacheAccountKeyPrefix = "nrn_authorizer_account_"
+	cacheVersionKeyPrefix = "nrn_authorizer_version_"
+	cacheRolesKeyPrefix   = "nrn_authorizer_roles_"
+)
+
+// DefaultCacheTTL is the default time to live for the cached account authorizations.
+const DefaultCacheTTL = 5 * time.Minute
+
+// cachedAuthorization is the cached account roles and its effective scopes.
+type cachedAuthorization struct {
+	Roles  []cachedRole `json:"roles"`
+	Scopes []string     `json:"scopes"`
+}
+
+type cachedRole struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Hierarchy int    `json:"hierarchy"`
+}
+
+func (c *cachedAuthorization) roles() []*Role {
+	roles := make([]*Role, len(c.Roles))
+	for i, r := range c.Roles {
+		roles[i] = &Role{ID: r.ID, Name: r.Name, Hierarchy: r.Hierarchy}
+	}
+	return roles
+}
+
+// verifyScopes checks if all the 'scopes' are within the cached effective scopes.
+func (c *cachedAuthorization) verifyScopes(scopes []auth.Scope) error {
+	granted := make(map[string]struct{}, len(c.Scopes))
+	for _, scope := range c.Scopes {
+		granted[scope] = struct{}{}
+	}
+	for _, scope := range scopes {
+		if _, ok := granted[scope.ScopeName()]; !ok {
+			return errors.WrapDetf(auth.ErrForbidden, "not authorized for the scope: '%s'", scope.ScopeName())
+		}
+	}
+	return nil
+}
+
+// getCachedAuthorization gets the account authorization from the cache. If it is not cached yet, the account roles
+// and its effective scopes are loaded and stored in the cache. The cache key contains the account version taken
+// before the roles are loaded, so that an authorization loaded concurrently with the account invalidation
+// is stored under the outdated key and is never served.
+func (a *Authorizer) getCachedAuthorization(ctx context.Context, accountID string) (*cachedAuthorization, error) {
+	generation, err := a.cacheGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	version, err := a.cacheAccountVersion(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	key := cacheAccountKey(generation, version, accountID)
+	record, err := a.Options.Cache.Get(ctx, key)
+	if err == nil {
+		cached := &cachedAuthorization{}
+		if err = json.Unmarshal(record.Value, cached); err == nil {
+			return cached, nil
+		}
+		log.Warningf("Unmarshal cached account: '%s' authorization failed: %v", accountID, err)
+	} else if !errors.Is(err, store.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	cached, err := a.loadAuthorization(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	value, err := json.Marshal(cached)
+	if err != nil {
+		return nil, errors.Wrapf(auth.ErrInternalError, "marshal cached authorization failed: %v", err)
+	}
+	if err = a.Options.Cache.Set(ctx, &store.Record{Key: key, Value: value}, store.SetWithTTL(a.Options.CacheTTL)); err != nil {
+		log.Warningf("Caching account: '%s' authorization failed: %v", accountID, err)
+	}
+	return cached, nil
+}
+
+// loadAccountAuthorization loads the roles of the account with provided 'accountID' and its effective scopes.
+func (a *Authorizer) loadAccountAuthorization(ctx context.Context, accountID string) (*cachedAuthorization, error) {
+	roles, err := a.accountRoles(ctx, a.db, accountID)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := a.effectiveScopes(ctx, roles)
+	if err != nil {
+		return nil, err
+	}
+	cached := &cachedAuthorization{Scopes: scopes}
+	for _, r := range roles {
+		cached.Roles = append(cached.Roles, cachedRole{ID: r.ID, Name: r.Name, Hierarchy: r.Hierarchy})
+	}
+	return cached, nil
+}
+
+// getCachedRoles gets all the roles from the cache. If these are not cached yet, the roles are loaded and stored
+// in the cache. The roles are related to the cache generation, which is changed whenever any role is changed.
+func (a *Authorizer) getCachedRoles(ctx context.Context) ([]cachedRole, error) {
+	generation, err := a.cacheGeneration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key := cacheRolesKeyPrefix + generation
+	record, err := a.Options.Cache.Get(ctx, key)
+	if err == nil {
+		var cached []cachedRole
+		if err = json.Unmarshal(record.Value, &cached); err == nil {
+			return cached, nil
+		}
+		log.Warningf("Unmarshal cached roles failed: %v", err)
+	} else if !errors.Is(err, store.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	roles, err := NRN_Roles.QueryCtx(ctx, a.db).Select("ID", "Name", "Hierarchy").Find()
+	if err != nil {
+		return nil, err
+	}
+	cached := make([]cachedRole, len(roles))
+	for i, r := range roles {
+		cached[i] = cachedRole{ID: r.ID, Name: r.Name, Hierarchy: r.Hierarchy}
+	}
+	value, err := json.Marshal(cached)
+	if err != nil {
+		return nil, errors.Wrapf(auth.ErrInternalError, "marshal cached roles failed: %v", err)
+	}
+	if err = a.Options.Cache.Set(ctx, &store.Record{Key: key, Value: value}, store.SetWithTTL(a.Options.CacheTTL)); err != nil {
+		log.Warningf("Caching roles failed: %v", err)
+	}
+	return cached, nil
+}
+
+// effectiveScopes gets the names of the scopes granted to the 'roles' and all the roles lower in the hierarchy.
+func (a *Authorizer) effectiveScopes(ctx context.Context, roles []*Role) ([]string, error) {
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	roleIDs := map[uint]struct{}{}
+	for _, r := range roles {
+		roleIDs[r.ID] = struct{}{}
+	}
+	lowerRoles, err := NRN_Roles.QueryCtx(ctx, a.db).
+		Where("Hierarchy < ?", maxRoleHierarchy(roles)).
+		Select("ID").
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range lowerRoles {
+		roleIDs[r.ID] = struct{}{}
+	}
+	ids := make([]interface{}, 0, len(roleIDs))
+	for id := range roleIDs {
+		ids = append(ids, id)
+	}
+	scopes, err := NRN_AuthorizeScopes.QueryCtx(ctx, a.db).
+		Where("Roles.ID IN ?", ids...).
+		Select("ID", "Name").
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(scopes))
+	for i, s := range scopes {
+		names[i] = s.Name
+	}
+	return names, nil
+}
+
+// cacheGeneration gets current cache generation. All the cached authorizations are related to the generation,
+// so that changing it invalidates whole cache.
+func (a *Authorizer) cacheGeneration(ctx context.Context) (string, error) {
+	record, err := a.Options.Cache.Get(ctx, cacheGenerationKey)
+	if err == nil {
+		return string(record.Value), nil
+	}
+	if !errors.Is(err, store.ErrRecordNotFound) {
+		return "", err
+	}
+	return a.newCacheGeneration(ctx)
+}
+
+func (a *Authorizer) newCacheGeneration(ctx context.Context) (string, error) {
+	generation := strconv.FormatInt(time.Now().UnixNano(), 36)
+	if err := a.Options.Cache.Set(ctx, &store.Record{Key: cacheGenerationKey, Value: []byte(generation)}); err != nil {
+		return "", err
+	}
+	return generation, nil
+}
+
+// cacheAccountVersion gets current cache version of the account with provided 'accountID'. The cached account
+// authorization is related to the version, so that changing it invalidates the account authorization.
+func (a *Authorizer) cacheAccountVersion(ctx context.Context, accountID string) (string, error) {
+	record, err := a.Options.Cache.Get(ctx, cacheVersionKeyPrefix+accountID)
+	if err == nil {
+		return string(record.Value), nil
+	}
+	if !errors.Is(err, store.ErrRecordNotFound) {
+		return "", err
+	}
+	return a.newCacheAccountVersion(ctx, accountID)
+}
+
+// newCacheAccountVersion sets new cache version for the account. The version expires together with the cached
+// authorizations - a version created after the expiration differs from the previous one.
+func (a *Authorizer) newCacheAccountVersion(ctx context.Context, accountID string) (string, error) {
+	version := strconv.FormatInt(time.Now().UnixNano(), 36)
+	record := &store.Record{Key: cacheVersionKeyPrefix + accountID, Value: []byte(version)}
+	if err := a.Options.Cache.Set(ctx, record, store.SetWithTTL(a.Options.CacheTTL)); err != nil {
+		return "", err
+	}
+	return version, nil
+}
+
+// invalidateAccountCache invalidates the cached authorization of the account with provided 'accountID'.
+// Instead of removing the cached authorization, the account cache version is changed. This discards also
+// the authorizations being loaded concurrently, which would otherwise be stored after the removal.
+func (a *Authorizer) invalidateAccountCache(ctx context.Context, accountID string) {
+	if a.Options.Cache == nil {
+		return
+	}
+	if _, err := a.newCacheAccountVersion(ctx, accountID); err != nil {
+		log.Errorf("Invalidating account: '%s' cached authorization failed: %v", accountID, err)
+	}
+}
+
+// InvalidateCache invalidates all the cached account authorizations. It is called automatically when the role scopes
+// or the role and scope models are changed.
+func (a *Authorizer) InvalidateCache(ctx context.Context) {
+	if a.Options.Cache == nil {
+		return
+	}
+	if _, err := a.newCacheGeneration(ctx); err != nil {
+		log.Errorf("Invalidating authorizer cache failed: %v", err)
+	}
+}
+
+func cacheAccountKey(generation, version, accountID string) string {
+	return cacheAccountKeyPrefix + generation + "_" + version + "_" + accountID
+}
+
+// invalidateControllerCache invalidates the cache of the authorizer set as the controller's verifier.
+func invalidateControllerCache(ctx context.Context, db database.DB) {
+	if a, ok := db.Controller().Verifier.(*Authorizer); ok {
+		a.InvalidateCache(ctx)
+	}
+}
+
+// AfterInsert implements database.AfterInserter interface. Inserting the role invalidates the authorizer cache.
+func (r *Role) AfterInsert(ctx context.Context, db database.DB) error {
+	invalidateControllerCache(ctx, db)
+	return nil
+}
+
+// AfterUpdate implements database.AfterUpdater interface. Changing the role invalidates the authorizer cache.
+func (r *Role) AfterUpdate(ctx context.Context, db database.DB) error {
+	invalidateControllerCache(ctx, db)
+	return nil
+}
+
+// AfterDelete implements database.AfterDeleter interface. Deleting the role invalidates the authorizer cache.
+func (r *Role) AfterDelete(ctx context.Context, db database.DB) error {
+	invalidateControllerCache(ctx, db)
+	return nil
+}
+
+// AfterUpdate implements database.AfterUpdater interface. Changing the scope invalidates the authorizer cache.
+func (a *AuthorizeScope) AfterUpdate(ctx context.Context, db database.DB) error {
+	invalidateControllerCache(ctx, db)
+	return nil
+}
+
+// AfterDelete implements database.AfterDeleter interface. Deleting the scope invalidates the authorizer cache.
+func (a *AuthorizeScope) AfterDelete(ctx context.Context, db database.DB) error {
+	invalidateControllerCache(ctx, db)
+	return nil
+}
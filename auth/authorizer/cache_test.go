@@ -0,0 +1,127 @@
+package authorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+)
+
+func TestCacheGeneration(t *testing.T) {
+	ctx := context.Background()
+	a, s := newTestAuthorizer(t)
+	a.cacheAccount(t, "1", []string{"editor"}, "articles.read")
+	account := &testAccount{ID: "1"}
+	require.NoError(t, a.Verify(ctx, account, auth.VerifyScopes(scopeName("articles.read"))))
+
+	generation, err := a.cacheGeneration(ctx)
+	require.NoError(t, err)
+	again, err := a.cacheGeneration(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, generation, again)
+
+	t.Run("Invalidate", func(t *testing.T) {
+		a.InvalidateCache(ctx)
+		newGeneration, err := a.cacheGeneration(ctx)
+		require.NoError(t, err)
+		assert.NotEqual(t, generation, newGeneration)
+		// The authorizations cached for the previous generation are no longer used.
+		_, err = s.Get(ctx, a.accountKey(t, "1"))
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+		_, err = s.Get(ctx, cacheRolesKeyPrefix+newGeneration)
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+	})
+
+	t.Run("RoleHooks", func(t *testing.T) {
+		// Changing the roles and the scopes invalidates the cache of the controller's authorizer.
+		for name, hook := range map[string]func() error{
+			"RoleInsert":  func() error { return (&Role{}).AfterInsert(ctx, a.db) },
+			"RoleUpdate":  func() error { return (&Role{}).AfterUpdate(ctx, a.db) },
+			"RoleDelete":  func() error { return (&Role{}).AfterDelete(ctx, a.db) },
+			"ScopeUpdate": func() error { return (&AuthorizeScope{}).AfterUpdate(ctx, a.db) },
+			"ScopeDelete": func() error { return (&AuthorizeScope{}).AfterDelete(ctx, a.db) },
+		} {
+			before, err := a.cacheGeneration(ctx)
+			require.NoError(t, err)
+			require.NoError(t, hook(), name)
+			after, err := a.cacheGeneration(ctx)
+			require.NoError(t, err)
+			assert.NotEqual(t, before, after, name)
+		}
+	})
+}
+
+func TestCachedAuthorization(t *testing.T) {
+	ctx := context.Background()
+	a, s := newTestAuthorizer(t)
+	a.cacheAccount(t, "1", []string{"viewer"}, "articles.read")
+	account := &testAccount{ID: "1"}
+
+	assert.True(t, errors.Is(a.Verify(ctx, account, VerifyMinimumHierarchy(50)), auth.ErrForbidden))
+	// The verification is served from the cache.
+	a.cacheAccount(t, "1", []string{"editor"}, "articles.read")
+	assert.NoError(t, a.Verify(ctx, account, VerifyMinimumHierarchy(50)))
+
+	t.Run("InvalidateAccount", func(t *testing.T) {
+		a.cacheAccount(t, "2", []string{"viewer"})
+		a.invalidateAccountCache(ctx, "1")
+
+		_, err := s.Get(ctx, a.accountKey(t, "1"))
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+		// Other accounts remain cached.
+		_, err = s.Get(ctx, a.accountKey(t, "2"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("InvalidateDuringLoad", func(t *testing.T) {
+		// The role is revoked after the account authorization is loaded, but before it is stored in the cache.
+		var loads int
+		a.loadAuthorization = func(ctx context.Context, accountID string) (*cachedAuthorization, error) {
+			loads++
+			if loads == 1 {
+				a.invalidateAccountCache(ctx, accountID)
+				return &cachedAuthorization{Roles: []cachedRole{testRoles[0]}}, nil
+			}
+			return &cachedAuthorization{Roles: []cachedRole{testRoles[2]}}, nil
+		}
+		defer func() { a.loadAuthorization = a.loadAccountAuthorization }()
+
+		account := &testAccount{ID: "3"}
+		// The verification in progress uses the authorization it has loaded.
+		assert.NoError(t, a.Verify(ctx, account, VerifyMinimumHierarchy(100)))
+		// The stale authorization is not served by the next verification.
+		assert.True(t, errors.Is(a.Verify(ctx, account, VerifyMinimumHierarchy(100)), auth.ErrForbidden))
+		assert.Equal(t, 2, loads)
+		// The authorization loaded after the revoke is cached.
+		assert.True(t, errors.Is(a.Verify(ctx, account, VerifyMinimumHierarchy(100)), auth.ErrForbidden))
+		assert.Equal(t, 2, loads)
+	})
+
+	t.Run("CachedRoles", func(t *testing.T) {
+		roles, err := a.getCachedRoles(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, testRoles, roles)
+	})
+
+	t.Run("VerifyScopes", func(t *testing.T) {
+		cached := &cachedAuthorization{Scopes: []string{"articles.read", "articles.write"}}
+		assert.NoError(t, cached.verifyScopes([]auth.Scope{scopeName("articles.read"), scopeName("articles.write")}))
+		assert.True(t, errors.Is(cached.verifyScopes([]auth.Scope{scopeName("articles.delete")}), auth.ErrForbidden))
+		assert.True(t, errors.Is((&cachedAuthorization{}).verifyScopes([]auth.Scope{scopeName("articles.read")}), auth.ErrForbidden))
+	})
+}
+
+func TestNoCache(t *testing.T) {
+	ctx := context.Background()
+	a := New()
+	require.NoError(t, a.Initialize(newTestController(t)))
+	// The invalidation without the cache is a no-op.
+	a.InvalidateCache(ctx)
+	a.invalidateAccountCache(ctx, "1")
+	assert.Equal(t, DefaultCacheTTL, New(WithCache(&testStore{}, 0)).Options.CacheTTL)
+}
@@ -5,4 +5,5 @@ go 1.13
 require (
 	github.com/google/uuid v1.1.1
 	github.com/neuronlabs/neuron v0.20.3
+	github.com/stretchr/testify v1.4.0
 )
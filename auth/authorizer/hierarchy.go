@@ -0,0 +1,182 @@
+package authorizer
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// MinimumHierarchy is the hierarchical role requirement. It is fulfilled by any role with the hierarchy value greater
+// or equal to its value. It could be used as the allowed role in the Verify options.
+type MinimumHierarchy int
+
+// RoleName implements auth.Role interface.
+func (m MinimumHierarchy) RoleName() string {
+	return "hierarchy>=" + strconv.Itoa(int(m))
+}
+
+// HierarchyValue implements auth.HierarchicalRole interface.
+func (m MinimumHierarchy) HierarchyValue() int {
+	return int(m)
+}
+
+// VerifyMinimumHierarchy is the verify option that requires the account to have a role with the hierarchy value
+// greater or equal to provided 'hierarchy'.
+func VerifyMinimumHierarchy(hierarchy int) auth.VerifyOption {
+	return auth.VerifyAllowedRoles(MinimumHierarchy(hierarchy))
+}
+
+// accountRoles gets the roles granted for the account with provided 'accountID'.
+func (a *Authorizer) accountRoles(ctx context.Context, db database.DB, accountID string) ([]*Role, error) {
+	accountRoles, err := NRN_AccountRoles.QueryCtx(ctx, db).
+		Where("AccountID = ?", accountID).
+		IncludeRole().
+		Find()
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]*Role, 0, len(accountRoles))
+	for _, accountRole := range accountRoles {
+		if accountRole.Role != nil {
+			roles = append(roles, accountRole.Role)
+		}
+	}
+	return roles, nil
+}
+
+// hasAllowedRole checks if any of the account 'roles' is one of the 'allowed' roles or is higher in the hierarchy
+// than one of them.
+func (a *Authorizer) hasAllowedRole(ctx context.Context, roles []*Role, allowed []auth.Role) (bool, error) {
+	for _, allowedRole := range allowed {
+		if minimum, ok := allowedRole.(MinimumHierarchy); ok {
+			for _, r := range roles {
+				if r.Hierarchy >= minimum.HierarchyValue() {
+					return true, nil
+				}
+			}
+			continue
+		}
+		for _, r := range roles {
+			if r.Name == allowedRole.RoleName() {
+				return true, nil
+			}
+		}
+		hierarchy, err := a.roleHierarchy(ctx, allowedRole)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidRole) {
+				continue
+			}
+			return false, err
+		}
+		for _, r := range roles {
+			if r.Hierarchy > hierarchy {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// roleHierarchy gets the hierarchy value of provided 'role'. If the cache is set, the role is taken from the cached
+// roles, otherwise it is taken from the repository.
+func (a *Authorizer) roleHierarchy(ctx context.Context, role auth.Role) (int, error) {
+	if a.Options.Cache == nil {
+		r, err := a.getRole(ctx, a.db, role)
+		if err != nil {
+			return 0, err
+		}
+		return r.Hierarchy, nil
+	}
+	roles, err := a.getCachedRoles(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var id uint
+	if r, ok := role.(*Role); ok && r.Name == "" {
+		id = r.ID
+	}
+	if id == 0 && role.RoleName() == "" {
+		return 0, errors.Wrap(auth.ErrInvalidRole, "provided role without name")
+	}
+	for _, r := range roles {
+		if (id != 0 && r.ID == id) || (id == 0 && r.Name == role.RoleName()) {
+			return r.Hierarchy, nil
+		}
+	}
+	return 0, errors.Wrap(auth.ErrInvalidRole, "no such role")
+}
+
+// verifyRoleScopes checks if the account 'roles' are granted all provided 'scopes'. A role inherits the scopes
+// of all the roles with lower hierarchy value.
+func (a *Authorizer) verifyRoleScopes(ctx context.Context, roles []*Role, scopes []auth.Scope) error {
+	if len(roles) == 0 {
+		return errors.WrapDetf(auth.ErrForbidden, "not authorized")
+	}
+	names := make([]interface{}, len(scopes))
+	for i, s := range scopes {
+		names[i] = s.ScopeName()
+	}
+	authorizeScopes, err := NRN_AuthorizeScopes.QueryCtx(ctx, a.db).
+		Where("Name IN ?", names...).
+		IncludeRoles("ID", "Hierarchy").
+		Find()
+	if err != nil {
+		return err
+	}
+	roleIDs := map[uint]struct{}{}
+	for _, r := range roles {
+		roleIDs[r.ID] = struct{}{}
+	}
+	maxHierarchy := maxRoleHierarchy(roles)
+
+	granted := map[string]struct{}{}
+	for _, scope := range authorizeScopes {
+		for _, r := range scope.Roles {
+			if _, ok := roleIDs[r.ID]; ok || r.Hierarchy < maxHierarchy {
+				granted[scope.Name] = struct{}{}
+				break
+			}
+		}
+	}
+	for _, scope := range scopes {
+		if _, ok := granted[scope.ScopeName()]; !ok {
+			return errors.WrapDetf(auth.ErrForbidden, "not authorized for the scope: '%s'", scope.ScopeName())
+		}
+	}
+	return nil
+}
+
+// checkGrantorHierarchy checks if the account stored in the context is allowed to grant or revoke the role 'r'.
+// An account could not manage the roles that are above its own highest role in the hierarchy.
+// If no account is stored in the context the check is omitted.
+func (a *Authorizer) checkGrantorHierarchy(ctx context.Context, db database.DB, r *Role) error {
+	grantor, ok := auth.CtxGetAccount(ctx)
+	if !ok {
+		return nil
+	}
+	grantorID, err := grantor.GetPrimaryKeyStringValue()
+	if err != nil {
+		return errors.Wrapf(auth.ErrAccountNotValid, "getting grantor primary key value failed: %v", err)
+	}
+	roles, err := a.accountRoles(ctx, db, grantorID)
+	if err != nil {
+		return err
+	}
+	if len(roles) == 0 || maxRoleHierarchy(roles) < r.Hierarchy {
+		return errors.WrapDetf(auth.ErrForbidden, "not allowed to manage the role: '%s' above own hierarchy", r.Name)
+	}
+	return nil
+}
+
+func maxRoleHierarchy(roles []*Role) int {
+	var max int
+	for i, r := range roles {
+		if i == 0 || r.Hierarchy > max {
+			max = r.Hierarchy
+		}
+	}
+	return max
+}
@@ -0,0 +1,71 @@
+package authorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+func TestVerifyHierarchy(t *testing.T) {
+	ctx := context.Background()
+	a, _ := newTestAuthorizer(t)
+	a.cacheAccount(t, "1", []string{"editor"})
+	a.cacheAccount(t, "2", nil)
+	editor, noRoles := &testAccount{ID: "1"}, &testAccount{ID: "2"}
+
+	isForbidden := func(err error) bool {
+		return errors.Is(err, auth.ErrForbidden)
+	}
+
+	t.Run("AllowedRole", func(t *testing.T) {
+		assert.NoError(t, a.Verify(ctx, editor, auth.VerifyAllowedRoles(roleName("editor"))))
+		assert.NoError(t, a.Verify(ctx, editor, auth.VerifyAllowedRoles(&Role{Name: "editor"})))
+		// The roles higher in the hierarchy fulfill the lower ones.
+		assert.NoError(t, a.Verify(ctx, editor, auth.VerifyAllowedRoles(roleName("viewer"))))
+		assert.NoError(t, a.Verify(ctx, editor, auth.VerifyAllowedRoles(&Role{ID: 3})))
+		assert.True(t, isForbidden(a.Verify(ctx, editor, auth.VerifyAllowedRoles(roleName("admin")))))
+		assert.True(t, isForbidden(a.Verify(ctx, noRoles, auth.VerifyAllowedRoles(roleName("viewer")))))
+	})
+
+	t.Run("UnknownRole", func(t *testing.T) {
+		assert.True(t, isForbidden(a.Verify(ctx, editor, auth.VerifyAllowedRoles(roleName("unknown")))))
+		// Any of the allowed roles is sufficient.
+		assert.NoError(t, a.Verify(ctx, editor, auth.VerifyAllowedRoles(roleName("unknown"), roleName("viewer"))))
+	})
+
+	t.Run("MinimumHierarchy", func(t *testing.T) {
+		assert.NoError(t, a.Verify(ctx, editor, VerifyMinimumHierarchy(50)))
+		assert.NoError(t, a.Verify(ctx, editor, VerifyMinimumHierarchy(10)))
+		assert.True(t, isForbidden(a.Verify(ctx, editor, VerifyMinimumHierarchy(51))))
+		assert.True(t, isForbidden(a.Verify(ctx, noRoles, VerifyMinimumHierarchy(0))))
+		assert.Equal(t, "hierarchy>=50", MinimumHierarchy(50).RoleName())
+	})
+
+	t.Run("DisallowedRole", func(t *testing.T) {
+		assert.True(t, isForbidden(a.Verify(ctx, editor, auth.VerifyDisallowedRoles(roleName("editor")))))
+		// The disallowed roles are not hierarchical.
+		assert.NoError(t, a.Verify(ctx, editor, auth.VerifyDisallowedRoles(roleName("viewer"))))
+	})
+
+	t.Run("RoleHierarchy", func(t *testing.T) {
+		hierarchy, err := a.roleHierarchy(ctx, &Role{ID: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 100, hierarchy)
+
+		_, err = a.roleHierarchy(ctx, roleName(""))
+		assert.True(t, errors.Is(err, auth.ErrInvalidRole))
+		_, err = a.roleHierarchy(ctx, &Role{ID: 10})
+		assert.True(t, errors.Is(err, auth.ErrInvalidRole))
+	})
+}
+
+func TestMaxRoleHierarchy(t *testing.T) {
+	assert.Equal(t, 0, maxRoleHierarchy(nil))
+	assert.Equal(t, -5, maxRoleHierarchy([]*Role{{Hierarchy: -10}, {Hierarchy: -5}}))
+	assert.Equal(t, 100, maxRoleHierarchy([]*Role{{Hierarchy: 10}, {Hierarchy: 100}, {Hierarchy: 50}}))
+}
@@ -0,0 +1,295 @@
+package authorizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/core"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// CurrentAccountID is the policy value placeholder, replaced with the verified account primary key value.
+const CurrentAccountID policyValue = "$account.id"
+
+type policyValue string
+
+// PolicyCheckFunc checks if the 'account' is allowed to access provided 'model'.
+// The function should return auth.ErrForbidden error if the access is not allowed.
+type PolicyCheckFunc func(ctx context.Context, account auth.Account, model mapping.Model) error
+
+// PolicyFilterFunc returns the filters that restricts the query to the models accessible by the 'account'.
+type PolicyFilterFunc func(ctx context.Context, account auth.Account, mStruct *mapping.ModelStruct) ([]filter.Filter, error)
+
+// Policy is the resource level authorization policy for given model. The policy could be defined with the Go
+// functions or declaratively with the query where clause (i.e. "OwnerID = ?" with the CurrentAccountID value).
+// All the policies matching the model and the query method must be fulfilled.
+type Policy struct {
+	// Model is the model the policy applies to.
+	Model mapping.Model
+	// Methods are the query methods the policy applies to. Empty methods means all the methods.
+	Methods []query.Method
+	// BypassRoles are the roles (or the roles higher in the hierarchy) that are not the subject of the policy.
+	BypassRoles []auth.Role
+	// Check is the function that verifies the loaded model.
+	Check PolicyCheckFunc
+	// Filter is the function that converts the policy into the query filters.
+	Filter PolicyFilterFunc
+
+	where    string
+	values   []interface{}
+	template filter.Filter
+	mStruct  *mapping.ModelStruct
+}
+
+// NewPolicy creates new Go function based policy for the 'model'.
+func NewPolicy(model mapping.Model, check PolicyCheckFunc, filterFunc PolicyFilterFunc) *Policy {
+	return &Policy{Model: model, Check: check, Filter: filterFunc}
+}
+
+// NewWherePolicy creates new declarative policy for the 'model'. The 'where' clause is of the query.Scope Where form
+// i.e.: "OwnerID = ?", "Status IN ?". The CurrentAccountID value is replaced with the account primary key.
+// A where policy is converted into the query filters and evaluated against the loaded models. The loaded models are
+// checked only for the '=', '!=' and 'IN' operators.
+func NewWherePolicy(model mapping.Model, where string, values ...interface{}) *Policy {
+	return &Policy{Model: model, where: where, values: values}
+}
+
+// NewOwnerPolicy creates new policy that allows the account to access only the models which 'ownerField'
+// is equal to the account primary key.
+func NewOwnerPolicy(model mapping.Model, ownerField string) *Policy {
+	return NewWherePolicy(model, ownerField+" = ?", CurrentAccountID)
+}
+
+// ForMethods sets the query methods the policy applies to.
+func (p *Policy) ForMethods(methods ...query.Method) *Policy {
+	p.Methods = methods
+	return p
+}
+
+// BypassFor sets the roles that are not the subject of the policy.
+func (p *Policy) BypassFor(roles ...auth.Role) *Policy {
+	p.BypassRoles = append(p.BypassRoles, roles...)
+	return p
+}
+
+func (p *Policy) initialize(c *core.Controller) (err error) {
+	if p.Model == nil {
+		return errors.Wrap(auth.ErrInitialization, "provided policy without model")
+	}
+	if p.mStruct, err = c.ModelStruct(p.Model); err != nil {
+		return err
+	}
+	if p.where == "" {
+		if p.Check == nil && p.Filter == nil {
+			return errors.Wrapf(auth.ErrInitialization, "policy for the model: '%s' has no check nor filter defined", p.mStruct)
+		}
+		return nil
+	}
+	if p.template, err = filter.NewFilter(p.mStruct, p.where, p.values...); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *Policy) appliesTo(method query.Method) bool {
+	if len(p.Methods) == 0 {
+		return true
+	}
+	for _, m := range p.Methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// filters gets the policy query filters for the 'account'.
+func (p *Policy) filters(ctx context.Context, account auth.Account) ([]filter.Filter, error) {
+	var filters []filter.Filter
+	if p.template != nil {
+		f, err := p.resolveFilter(account, p.template)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	if p.Filter != nil {
+		custom, err := p.Filter(ctx, account, p.mStruct)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, custom...)
+	}
+	return filters, nil
+}
+
+// check verifies if the 'account' is allowed to access the 'model'.
+func (p *Policy) check(ctx context.Context, account auth.Account, model mapping.Model) error {
+	if p.template != nil {
+		f, err := p.resolveFilter(account, p.template)
+		if err != nil {
+			return err
+		}
+		matches, err := matchFilter(model, f)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			return errors.WrapDetf(auth.ErrForbidden, "not authorized for the model: '%s'", p.mStruct)
+		}
+	}
+	if p.Check != nil {
+		return p.Check(ctx, account, model)
+	}
+	return nil
+}
+
+// resolveFilter replaces the policy placeholder values within the filter 'f'.
+func (p *Policy) resolveFilter(account auth.Account, f filter.Filter) (filter.Filter, error) {
+	switch ft := f.(type) {
+	case filter.Simple:
+		resolved := ft.Copy().(filter.Simple)
+		for i, value := range resolved.Values {
+			if value != CurrentAccountID {
+				continue
+			}
+			accountID, err := account.GetPrimaryKeyStringValue()
+			if err != nil {
+				return nil, errors.Wrapf(auth.ErrAccountNotValid, "getting primary key value failed: %v", err)
+			}
+			fielder, ok := mapping.NewModel(ft.StructField.ModelStruct()).(mapping.Fielder)
+			if !ok {
+				return nil, errors.Wrapf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement Fielder interface", ft.StructField.ModelStruct())
+			}
+			if resolved.Values[i], err = fielder.ParseFieldsStringValue(ft.StructField, accountID); err != nil {
+				return nil, err
+			}
+		}
+		return resolved, nil
+	case filter.Relation:
+		resolved := ft.Copy().(filter.Relation)
+		for i, nested := range resolved.Nested {
+			var err error
+			if resolved.Nested[i], err = p.resolveFilter(account, nested); err != nil {
+				return nil, err
+			}
+		}
+		return resolved, nil
+	default:
+		return f, nil
+	}
+}
+
+// matchFilter checks if the 'model' field values matches the simple filter 'f'.
+func matchFilter(model mapping.Model, f filter.Filter) (bool, error) {
+	simple, ok := f.(filter.Simple)
+	if !ok {
+		return false, errors.Wrapf(auth.ErrInternalError, "policy filter: '%s' could not be evaluated against the model", f)
+	}
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return false, errors.Wrapf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement Fielder interface", model)
+	}
+	value, err := fielder.GetFieldValue(simple.StructField)
+	if err != nil {
+		return false, err
+	}
+	equal := func() bool {
+		for _, v := range simple.Values {
+			if fmt.Sprint(v) == fmt.Sprint(value) {
+				return true
+			}
+		}
+		return false
+	}
+	switch simple.Operator {
+	case filter.OpEqual, filter.OpIn:
+		return equal(), nil
+	case filter.OpNotEqual, filter.OpNotIn:
+		return !equal(), nil
+	default:
+		return false, errors.Wrapf(auth.ErrInternalError, "policy filter operator: '%s' could not be evaluated against the model", simple.Operator)
+	}
+}
+
+// ApplyPolicyFilters adds the filters of the policies for the query 'method' to the query scope 's', so that
+// the query is restricted to the models accessible by the 'account'.
+func (a *Authorizer) ApplyPolicyFilters(ctx context.Context, account auth.Account, method query.Method, s *query.Scope) error {
+	policies, err := a.accountPolicies(ctx, account, method, s.ModelStruct)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		filters, err := policy.filters(ctx, account)
+		if err != nil {
+			return err
+		}
+		for _, f := range filters {
+			s.Filter(f)
+		}
+	}
+	return nil
+}
+
+// VerifyModel checks if the 'account' is allowed to execute the query 'method' on the loaded 'model'.
+func (a *Authorizer) VerifyModel(ctx context.Context, account auth.Account, method query.Method, model mapping.Model) error {
+	mStruct, err := a.c.ModelStruct(model)
+	if err != nil {
+		return err
+	}
+	policies, err := a.accountPolicies(ctx, account, method, mStruct)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		if err = policy.check(ctx, account, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accountPolicies gets the policies for the model and query method that applies to the 'account'.
+func (a *Authorizer) accountPolicies(ctx context.Context, account auth.Account, method query.Method, mStruct *mapping.ModelStruct) ([]*Policy, error) {
+	var policies []*Policy
+	for _, policy := range a.policies[mStruct] {
+		if policy.appliesTo(method) {
+			policies = append(policies, policy)
+		}
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+	if account == nil {
+		return nil, errors.WrapDetf(auth.ErrForbidden, "no account provided for the model: '%s' policies", mStruct)
+	}
+
+	var roles []*Role
+	result := policies[:0]
+	for _, policy := range policies {
+		if len(policy.BypassRoles) > 0 {
+			if roles == nil {
+				accountID, err := account.GetPrimaryKeyStringValue()
+				if err != nil {
+					return nil, errors.Wrapf(auth.ErrAccountNotValid, "getting primary key value failed: %v", err)
+				}
+				if roles, err = a.accountRoles(ctx, a.db, accountID); err != nil {
+					return nil, err
+				}
+			}
+			bypass, err := a.hasAllowedRole(ctx, roles, policy.BypassRoles)
+			if err != nil {
+				return nil, err
+			}
+			if bypass {
+				continue
+			}
+		}
+		result = append(result, policy)
+	}
+	return result, nil
+}
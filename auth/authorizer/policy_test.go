@@ -0,0 +1,115 @@
+package authorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+func TestOwnerPolicy(t *testing.T) {
+	ctx := context.Background()
+	a, _ := newTestAuthorizer(t, WithPolicies(NewOwnerPolicy(&AccountRoles{}, "AccountID").ForMethods(query.Get, query.List, query.Update)))
+	owner, other := &testAccount{ID: "1"}, &testAccount{ID: "2"}
+	model := &AccountRoles{AccountID: "1", RoleID: 1}
+
+	t.Run("VerifyModel", func(t *testing.T) {
+		assert.NoError(t, a.VerifyModel(ctx, owner, query.Get, model))
+		assert.True(t, errors.Is(a.VerifyModel(ctx, other, query.Update, model), auth.ErrForbidden))
+		assert.True(t, errors.Is(a.VerifyModel(ctx, nil, query.Get, model), auth.ErrForbidden))
+		// The policy doesn't apply to the delete method.
+		assert.NoError(t, a.VerifyModel(ctx, other, query.Delete, model))
+		// The models without policies are not restricted.
+		assert.NoError(t, a.VerifyModel(ctx, other, query.Get, &Role{ID: 1}))
+	})
+
+	t.Run("ApplyPolicyFilters", func(t *testing.T) {
+		s := newAccountRolesScope(t, a)
+		require.NoError(t, a.ApplyPolicyFilters(ctx, other, query.List, s))
+		require.Len(t, s.Filters, 1)
+		simple, ok := s.Filters[0].(filter.Simple)
+		require.True(t, ok)
+		assert.Equal(t, "AccountID", simple.StructField.Name())
+		assert.Equal(t, filter.OpEqual, simple.Operator)
+		assert.Equal(t, []interface{}{"2"}, simple.Values)
+
+		s = newAccountRolesScope(t, a)
+		require.NoError(t, a.ApplyPolicyFilters(ctx, other, query.Delete, s))
+		assert.Empty(t, s.Filters)
+	})
+}
+
+func TestWherePolicy(t *testing.T) {
+	ctx := context.Background()
+	a, _ := newTestAuthorizer(t, WithPolicies(NewWherePolicy(&AccountRoles{}, "RoleID IN ?", uint(2), uint(3))))
+	account := &testAccount{ID: "1"}
+
+	assert.NoError(t, a.VerifyModel(ctx, account, query.Get, &AccountRoles{RoleID: 2}))
+	assert.NoError(t, a.VerifyModel(ctx, account, query.Get, &AccountRoles{RoleID: 3}))
+	assert.True(t, errors.Is(a.VerifyModel(ctx, account, query.Get, &AccountRoles{RoleID: 1}), auth.ErrForbidden))
+
+	s := newAccountRolesScope(t, a)
+	require.NoError(t, a.ApplyPolicyFilters(ctx, account, query.List, s))
+	require.Len(t, s.Filters, 1)
+	assert.Equal(t, filter.OpIn, s.Filters[0].(filter.Simple).Operator)
+}
+
+func TestFuncPolicy(t *testing.T) {
+	ctx := context.Background()
+	check := func(_ context.Context, account auth.Account, model mapping.Model) error {
+		accountID, _ := account.GetPrimaryKeyStringValue()
+		if model.(*AccountRoles).AccountID != accountID {
+			return errors.Wrap(auth.ErrForbidden, "not the owner")
+		}
+		return nil
+	}
+	filterFunc := func(_ context.Context, account auth.Account, mStruct *mapping.ModelStruct) ([]filter.Filter, error) {
+		field, ok := mStruct.FieldByName("AccountID")
+		require.True(t, ok)
+		accountID, _ := account.GetPrimaryKeyStringValue()
+		return []filter.Filter{filter.New(field, filter.OpEqual, accountID)}, nil
+	}
+	a, _ := newTestAuthorizer(t, WithPolicies(
+		NewPolicy(&AccountRoles{}, check, filterFunc),
+		// All the matching policies must be fulfilled.
+		NewWherePolicy(&AccountRoles{}, "RoleID != ?", uint(1)),
+	))
+	account := &testAccount{ID: "1"}
+
+	assert.NoError(t, a.VerifyModel(ctx, account, query.Get, &AccountRoles{AccountID: "1", RoleID: 2}))
+	assert.True(t, errors.Is(a.VerifyModel(ctx, account, query.Get, &AccountRoles{AccountID: "2", RoleID: 2}), auth.ErrForbidden))
+	assert.True(t, errors.Is(a.VerifyModel(ctx, account, query.Get, &AccountRoles{AccountID: "1", RoleID: 1}), auth.ErrForbidden))
+
+	s := newAccountRolesScope(t, a)
+	require.NoError(t, a.ApplyPolicyFilters(ctx, account, query.List, s))
+	assert.Len(t, s.Filters, 2)
+}
+
+func TestPolicyInitialize(t *testing.T) {
+	for name, policy := range map[string]*Policy{
+		"NoModel":         {},
+		"NoCheckNoFilter": {Model: &AccountRoles{}},
+		"InvalidWhere":    NewWherePolicy(&AccountRoles{}, "Unknown = ?", 1),
+	} {
+		policy := policy
+		t.Run(name, func(t *testing.T) {
+			c := newTestController(t)
+			err := New(WithPolicies(policy)).Initialize(c)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func newAccountRolesScope(t *testing.T, a *Authorizer) *query.Scope {
+	t.Helper()
+	mStruct, err := a.c.ModelStruct(&AccountRoles{})
+	require.NoError(t, err)
+	return query.NewScope(mStruct)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/neuronlabs/neuron/auth"
 	"github.com/neuronlabs/neuron/database"
 	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
 )
 
 //go:generate neurogonesis models methods --format=goimports --single-file .
@@ -48,9 +49,9 @@ type AccountRoles struct {
 	CreatedAt time.Time
 	// Relations
 	Role   *Role
-	RoleID uint
-	// AccountID is account foreign key converted to string.
-	AccountID string `neuron:"type=foreign" db:"unique,index"`
+	RoleID uint `db:";unique_index=idx_nrn_account_roles_unique"`
+	// AccountID is account foreign key converted to string. An account could have multiple distinct roles.
+	AccountID string `neuron:"type=foreign" db:";unique_index=idx_nrn_account_roles_unique"`
 }
 
 // BeforeInsert implements database.BeforeInserter interface.
@@ -69,7 +70,18 @@ func (a *Authorizer) FindRoles(ctx context.Context, options ...auth.ListRoleOpti
 		option(o)
 	}
 	if o.Account != nil {
-		q = q.Where("Accounts.AccountID IN", o.Account.GetPrimaryKeyValue())
+		accountID, err := o.Account.GetPrimaryKeyStringValue()
+		if err != nil {
+			return nil, errors.Wrapf(auth.ErrAccountNotValid, "getting primary key value failed: %v", err)
+		}
+		q = q.Where("Accounts.AccountID = ?", accountID)
+	}
+	if o.SortByHierarchy {
+		field := "Hierarchy"
+		if o.SortOrder == query.DescendingOrder {
+			field = "-" + field
+		}
+		q.OrderBy(field)
 	}
 	if o.Limit > 0 {
 		q.Limit(int64(o.Limit))
@@ -92,68 +104,159 @@ func (a *Authorizer) FindRoles(ctx context.Context, options ...auth.ListRoleOpti
 	return roleInterfaces, nil
 }
 
-// GrantRole implements authorization.Roler interface.
+// GrantRole implements authorization.Roler interface. If the context contains an account, it needs to have
+// a role with the hierarchy at least equal to the granted role.
 func (a *Authorizer) GrantRole(ctx context.Context, account auth.Account, role auth.Role) error {
-	r, err := a.getRole(ctx, a.db, role)
+	accountID, err := a.grantRole(ctx, a.db, account, role)
 	if err != nil {
 		return err
 	}
-	if account.IsPrimaryKeyZero() {
-		return errors.Wrap(auth.ErrAccountNotFound, "provided account has zero value primary key")
-	}
+	a.invalidateAccountCache(ctx, accountID)
+	return nil
+}
 
-	accountID, err := account.GetPrimaryKeyStringValue()
+// GrantRoles grants all provided 'roles' to the 'account' within a single transaction.
+// If any of the roles is already granted, none of them is granted.
+func (a *Authorizer) GrantRoles(ctx context.Context, account auth.Account, roles ...auth.Role) error {
+	if len(roles) == 0 {
+		return errors.Wrap(auth.ErrInvalidRole, "provided no roles to grant")
+	}
+	var accountID string
+	err := database.RunInTransaction(ctx, a.db, nil, func(db database.DB) (err error) {
+		for _, role := range roles {
+			if accountID, err = a.grantRole(ctx, db, account, role); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		return errors.Wrapf(auth.ErrAccountNotValid, "getting primary key value failed: %v", err)
+		return err
 	}
+	// The cached authorization is invalidated after the transaction is committed, so that it could not be
+	// reloaded with the roles not yet visible for the other transactions.
+	a.invalidateAccountCache(ctx, accountID)
+	return nil
+}
 
-	cnt, err := NRN_AccountRoles.QueryCtx(ctx, a.db).Where("AccountID = ?", accountID).Count()
+// RevokeRole implements authorization.Roler interface. If the context contains an account, it needs to have
+// a role with the hierarchy at least equal to the revoked role.
+func (a *Authorizer) RevokeRole(ctx context.Context, account auth.Account, role auth.Role) error {
+	accountID, err := a.revokeRole(ctx, a.db, account, role)
 	if err != nil {
 		return err
 	}
+	a.invalidateAccountCache(ctx, accountID)
+	return nil
+}
 
-	if cnt > 0 {
-		return errors.Wrapf(auth.ErrRoleAlreadyGranted, "account already has role: %s", r.Name)
+// RevokeRoles revokes all provided 'roles' from the 'account' within a single transaction.
+func (a *Authorizer) RevokeRoles(ctx context.Context, account auth.Account, roles ...auth.Role) error {
+	if len(roles) == 0 {
+		return errors.Wrap(auth.ErrInvalidRole, "provided no roles to revoke")
 	}
-
-	// Insert account roles.
-	accRole := &AccountRoles{RoleID: r.ID, AccountID: accountID}
-	if err = NRN_AccountRoles.Insert(ctx, a.db, accRole); err != nil {
+	var accountID string
+	err := database.RunInTransaction(ctx, a.db, nil, func(db database.DB) (err error) {
+		for _, role := range roles {
+			if accountID, err = a.revokeRole(ctx, db, account, role); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return err
 	}
+	a.invalidateAccountCache(ctx, accountID)
 	return nil
 }
 
-// RevokeRole implements authorization.Roler interface.
-func (a *Authorizer) RevokeRole(ctx context.Context, account auth.Account, role auth.Role) error {
-	r, err := a.getRole(ctx, a.db, role)
+// ListAccountRoles lists all the roles granted to provided 'account' sorted by the hierarchy in descending order.
+func (a *Authorizer) ListAccountRoles(ctx context.Context, account auth.Account) ([]auth.Role, error) {
+	return a.FindRoles(ctx, func(o *auth.ListRoleOptions) {
+		o.Account = account
+		o.SortByHierarchy = true
+		o.SortOrder = query.DescendingOrder
+	})
+}
+
+// ClearRoles implements authorization.Roler interface.
+func (a *Authorizer) ClearRoles(ctx context.Context, account auth.Account) error {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return errors.Wrapf(auth.ErrAccountNotValid, "getting primary key value failed: %v", err)
+	}
+	deleted, err := NRN_AccountRoles.QueryCtx(ctx, a.db).Where("AccountID = ?", accountID).Delete()
 	if err != nil {
 		return err
 	}
+	log.Debugf("Cleared: '%d' roles for the account: '%v'", deleted, account.GetPrimaryKeyValue())
+	a.invalidateAccountCache(ctx, accountID)
+	return nil
+}
+
+// grantRole grants the 'role' to the 'account' using provided 'db' and returns the account's primary key string value.
+// The cached account authorization needs to be invalidated by the caller, once the 'db' transaction is committed.
+func (a *Authorizer) grantRole(ctx context.Context, db database.DB, account auth.Account, role auth.Role) (string, error) {
+	r, err := a.getRole(ctx, db, role)
+	if err != nil {
+		return "", err
+	}
+	if err = a.checkGrantorHierarchy(ctx, db, r); err != nil {
+		return "", err
+	}
 	if account.IsPrimaryKeyZero() {
-		return errors.Wrap(auth.ErrAccountNotFound, "provided account has zero value primary key")
+		return "", errors.Wrap(auth.ErrAccountNotFound, "provided account has zero value primary key")
 	}
 
 	accountID, err := account.GetPrimaryKeyStringValue()
 	if err != nil {
-		return errors.Wrapf(auth.ErrAccountNotValid, "getting primary key value failed: %v", err)
+		return "", errors.Wrapf(auth.ErrAccountNotValid, "getting primary key value failed: %v", err)
 	}
-	_, err = NRN_AccountRoles.QueryCtx(ctx, a.db).
+
+	cnt, err := NRN_AccountRoles.QueryCtx(ctx, db).
 		Where("RoleID = ?", r.ID).
 		Where("AccountID = ?", accountID).
-		Delete()
+		Count()
 	if err != nil {
-		return err
+		return "", err
 	}
-	return nil
+	if cnt > 0 {
+		return "", errors.Wrapf(auth.ErrRoleAlreadyGranted, "account already has role: %s", r.Name)
+	}
+
+	// Insert account roles.
+	accRole := &AccountRoles{RoleID: r.ID, AccountID: accountID}
+	if err = NRN_AccountRoles.Insert(ctx, db, accRole); err != nil {
+		return "", err
+	}
+	return accountID, nil
 }
 
-// ClearRoles implements authorization.Roler interface.
-func (a *Authorizer) ClearRoles(ctx context.Context, account auth.Account) error {
-	deleted, err := NRN_AccountRoles.QueryCtx(ctx, a.db).Where("AccountID = ?").Delete()
+// revokeRole revokes the 'role' from the 'account' using provided 'db' and returns the account's primary key string
+// value. The cached account authorization needs to be invalidated by the caller, once the 'db' transaction is committed.
+func (a *Authorizer) revokeRole(ctx context.Context, db database.DB, account auth.Account, role auth.Role) (string, error) {
+	r, err := a.getRole(ctx, db, role)
 	if err != nil {
-		return err
+		return "", err
 	}
-	log.Debugf("Cleared: '%d' roles for the account: '%v'", deleted, account.GetPrimaryKeyValue())
-	return nil
+	if err = a.checkGrantorHierarchy(ctx, db, r); err != nil {
+		return "", err
+	}
+	if account.IsPrimaryKeyZero() {
+		return "", errors.Wrap(auth.ErrAccountNotFound, "provided account has zero value primary key")
+	}
+
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return "", errors.Wrapf(auth.ErrAccountNotValid, "getting primary key value failed: %v", err)
+	}
+	_, err = NRN_AccountRoles.QueryCtx(ctx, db).
+		Where("RoleID = ?", r.ID).
+		Where("AccountID = ?", accountID).
+		Delete()
+	if err != nil {
+		return "", err
+	}
+	return accountID, nil
 }
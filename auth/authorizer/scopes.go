@@ -32,9 +32,9 @@ type RoleScopes struct {
 	CreatedAt time.Time
 	DeletedAt *time.Time
 	Scope     *AuthorizeScope
-	ScopeID   uint `db:";unique_index=idx_nrn_role_scopes_unique"`
+	ScopeID   uint `neuron:"type=foreign" db:";unique_index=idx_nrn_role_scopes_unique"`
 	Role      *Role
-	RoleID    uint `db:";unique_index=idx_nrn_role_scopes_unique"`
+	RoleID    uint `neuron:"type=foreign" db:";unique_index=idx_nrn_role_scopes_unique"`
 }
 
 // ListRoleScopes lists the scopes for provided options.
@@ -92,7 +92,11 @@ func (a *Authorizer) ClearRoleScopes(ctx context.Context, roles ...auth.Role) er
 		log.Debugf("Deleted: %d role scopes", deleted)
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	a.InvalidateCache(ctx)
+	return nil
 }
 
 // GrantRoleScope grants roles/accounts access for given scope.
@@ -127,7 +131,11 @@ func (a *Authorizer) GrantRoleScope(ctx context.Context, role auth.Role, scope a
 		}
 		return NRN_RoleScopes.Insert(ctx, db, rs)
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	a.InvalidateCache(ctx)
+	return nil
 }
 
 // RevokeRoleScope revokes the roles/accounts access for given scope.
@@ -159,5 +167,9 @@ func (a *Authorizer) RevokeRoleScope(ctx context.Context, role auth.Role, scope
 		}
 		return nil
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	a.InvalidateCache(ctx)
+	return nil
 }
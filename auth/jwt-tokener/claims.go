@@ -13,6 +13,8 @@ import (
 // It's validation returns neuron errors, and allows to check if the token was revoked.
 type Claims struct {
 	RevokedAt int64 `json:"revoked_at"`
+	// TokenScope contains space separated authorization scopes the token was issued for.
+	TokenScope string `json:"scope,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -21,6 +23,11 @@ func (c *Claims) Subject() string {
 	return c.StandardClaims.Subject
 }
 
+// Scope implements auth.Scoper interface.
+func (c *Claims) Scope() string {
+	return c.TokenScope
+}
+
 // Valid implements jwt.Claims and auth.Claims.
 func (c *Claims) Valid() error {
 	if c.RevokedAt != 0 {
@@ -79,10 +86,14 @@ func (c *Claims) fromMapClaims(m jwt.MapClaims) {
 		c.NotBefore, _ = nbf.Int64()
 	}
 	c.StandardClaims.Subject, _ = m["sub"].(string)
+	c.TokenScope, _ = m["scope"].(string)
 }
 
 // Compile time check if AccessClaims implements auth.AccessClaims.
-var _ auth.AccessClaims = &AccessClaims{}
+var (
+	_ auth.AccessClaims = &AccessClaims{}
+	_ auth.Scoper       = &AccessClaims{}
+)
 
 // AccessClaims is the jwt claims implementation that keeps the accountID stored in given token.
 type AccessClaims struct {
@@ -91,6 +102,28 @@ type AccessClaims struct {
 }
 
 // GetAccount implements auth.AccessClaims interface.
+// If the token was issued with the authorization scope, the account is wrapped into ScopedAccount.
 func (c *AccessClaims) GetAccount() auth.Account {
+	if c.TokenScope != "" && c.Account != nil {
+		return &ScopedAccount{Account: c.Account, TokenScope: c.TokenScope}
+	}
 	return c.Account
 }
+
+// Compile time check if ScopedAccount implements auth.Account and auth.Scoper.
+var (
+	_ auth.Account = &ScopedAccount{}
+	_ auth.Scoper  = &ScopedAccount{}
+)
+
+// ScopedAccount is the account obtained from the token that was issued for limited authorization scopes.
+// It implements auth.Scoper interface, thus the authorization verifiers could check the token scopes.
+type ScopedAccount struct {
+	auth.Account
+	TokenScope string
+}
+
+// Scope implements auth.Scoper interface.
+func (s *ScopedAccount) Scope() string {
+	return s.TokenScope
+}
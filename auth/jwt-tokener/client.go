@@ -0,0 +1,202 @@
+package tokener
+
+import (
+	"context"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// ClientAccount is the interface of the accounts that represents the OAuth2 client authorized with the client
+// credentials grant. The tokeners issue only the access tokens for the clients - without the refresh tokens
+// (RFC 6749 section 4.4.3). The client identifier is the account's username.
+type ClientAccount interface {
+	auth.Account
+	// ClientPrincipal marks the account as the OAuth2 client principal.
+	ClientPrincipal()
+}
+
+// Compile time check if ClientClaims implements auth.AccessClaims.
+var (
+	_ auth.AccessClaims = &ClientClaims{}
+	_ auth.Scoper       = &ClientClaims{}
+)
+
+// ClientClaims are the claims of the access token issued for the OAuth2 client. The subject of the token is the client
+// identifier, which is not related to any account.
+type ClientClaims struct {
+	ClientID string `json:"client_id"`
+	Claims
+}
+
+// GetAccount implements auth.AccessClaims interface. It returns the client principal.
+func (c *ClientClaims) GetAccount() auth.Account {
+	return &ClientPrincipal{ClientID: c.ClientID, ClientScope: c.TokenScope}
+}
+
+// Compile time check if ClientPrincipal implements ClientAccount and auth.Scoper.
+var (
+	_ ClientAccount = &ClientPrincipal{}
+	_ auth.Scoper   = &ClientPrincipal{}
+)
+
+// ClientPrincipal is the principal of the OAuth2 client obtained from the client access token. It is not a mapped
+// model - its primary key is the client identifier, and it has no password. The authorization verifiers should
+// check only the client scope for such principals.
+type ClientPrincipal struct {
+	ClientID    string
+	ClientScope string
+}
+
+// ClientPrincipal implements ClientAccount interface.
+func (c *ClientPrincipal) ClientPrincipal() {}
+
+// Scope implements auth.Scoper interface.
+func (c *ClientPrincipal) Scope() string {
+	return c.ClientScope
+}
+
+// NeuronCollectionName implements mapping.Model interface.
+func (c *ClientPrincipal) NeuronCollectionName() string {
+	return "client_principals"
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface.
+func (c *ClientPrincipal) GetPrimaryKeyStringValue() (string, error) {
+	return c.ClientID, nil
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface.
+func (c *ClientPrincipal) GetPrimaryKeyValue() interface{} {
+	return c.ClientID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface.
+func (c *ClientPrincipal) GetPrimaryKeyHashableValue() interface{} {
+	return c.ClientID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface.
+func (c *ClientPrincipal) GetPrimaryKeyZeroValue() interface{} {
+	return ""
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface.
+func (c *ClientPrincipal) GetPrimaryKeyAddress() interface{} {
+	return &c.ClientID
+}
+
+// IsPrimaryKeyZero implements mapping.Model interface.
+func (c *ClientPrincipal) IsPrimaryKeyZero() bool {
+	return c.ClientID == ""
+}
+
+// SetPrimaryKeyValue implements mapping.Model interface.
+func (c *ClientPrincipal) SetPrimaryKeyValue(src interface{}) error {
+	clientID, ok := src.(string)
+	if !ok {
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid client id value: '%T'", src)
+	}
+	c.ClientID = clientID
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface.
+func (c *ClientPrincipal) SetPrimaryKeyStringValue(src string) error {
+	c.ClientID = src
+	return nil
+}
+
+// GetUsername implements auth.Account interface. Returns the client identifier.
+func (c *ClientPrincipal) GetUsername() string {
+	return c.ClientID
+}
+
+// SetUsername implements auth.Account interface.
+func (c *ClientPrincipal) SetUsername(username string) {
+	c.ClientID = username
+}
+
+// GetPasswordHash implements auth.Account interface. The client principal has no password.
+func (c *ClientPrincipal) GetPasswordHash() []byte {
+	return nil
+}
+
+// SetPasswordHash implements auth.Account interface. The client principal has no password.
+func (c *ClientPrincipal) SetPasswordHash([]byte) {}
+
+// UsernameField implements auth.Account interface.
+func (c *ClientPrincipal) UsernameField() string {
+	return "ClientID"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (c *ClientPrincipal) PasswordHashField() string {
+	return ""
+}
+
+// clientID gets the client identifier of the 'client' account.
+func clientID(client ClientAccount) (string, error) {
+	clientID := client.GetUsername()
+	if clientID == "" {
+		return "", errors.Wrap(auth.ErrNoRequiredOption, "provided client with no client id")
+	}
+	return clientID, nil
+}
+
+// clientToken creates an access token for the OAuth2 'client'. No refresh token is issued.
+func (t *Tokener) clientToken(ctx context.Context, client ClientAccount, o *auth.TokenOptions) (auth.Token, error) {
+	id, err := clientID(client)
+	if err != nil {
+		return auth.Token{}, err
+	}
+	now := t.Options.TimeFunc()
+	expiresAt := now.Add(o.ExpirationTime)
+	claims := &ClientClaims{
+		ClientID: id,
+		Claims:   Claims{TokenScope: o.Scope, StandardClaims: jwt.StandardClaims{Subject: id, ExpiresAt: expiresAt.Unix()}},
+	}
+	tokenString, err := jwt.NewWithClaims(t.Options.SigningMethod, claims).SignedString(t.signingKey)
+	if err != nil {
+		return auth.Token{}, errors.Wrapf(auth.ErrInternalError, "writing signed string failed: %v", err)
+	}
+	if err = t.setStoreToken(ctx, tokenString, &StoreToken{ExpiresAt: expiresAt, IssuedAt: now}); err != nil {
+		return auth.Token{}, err
+	}
+	return auth.Token{
+		AccessToken: tokenString,
+		ExpiresIn:   int(o.ExpirationTime / time.Second),
+		TokenType:   "bearer",
+	}, nil
+}
+
+// clientToken creates an opaque access token for the OAuth2 'client'. No refresh token is issued.
+func (t *OpaqueTokener) clientToken(ctx context.Context, client ClientAccount, o *auth.TokenOptions) (auth.Token, error) {
+	id, err := clientID(client)
+	if err != nil {
+		return auth.Token{}, err
+	}
+	tokenString, err := t.newTokenString("")
+	if err != nil {
+		return auth.Token{}, err
+	}
+	now := t.Options.TimeFunc()
+	expiresAt := now.Add(o.ExpirationTime)
+	sToken := &OpaqueStoreToken{
+		StoreToken: StoreToken{ExpiresAt: expiresAt, IssuedAt: now},
+		Claims:     t.newClaims(id, now, expiresAt, o),
+		ClientID:   id,
+	}
+	if err = t.setStoreToken(ctx, t.tokenStoreKey(tokenString), sToken); err != nil {
+		return auth.Token{}, err
+	}
+	return auth.Token{
+		AccessToken: tokenString,
+		ExpiresIn:   int(o.ExpirationTime / time.Second),
+		TokenType:   "bearer",
+	}, nil
+}
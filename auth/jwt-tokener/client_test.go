@@ -0,0 +1,68 @@
+package tokener
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+)
+
+// accountRevoker is the interface of the tokeners that could revoke all the account tokens.
+type accountRevoker interface {
+	RevokeAccountTokens(ctx context.Context, account auth.Account) error
+}
+
+func TestClientToken(t *testing.T) {
+	ctx := context.Background()
+	for name, tokener := range testTokeners(t) {
+		t.Run(name, func(t *testing.T) {
+			// The account with the same primary key as the client id must not be affected by the client tokens.
+			account := &testAccount{ID: 7, Username: "john"}
+			client := &testClient{testAccount{ID: 7, Username: "client-7"}}
+
+			token, err := tokener.Token(ctx, client, auth.TokenScope("read write"))
+			require.NoError(t, err)
+			// The client credentials grant must not issue refresh tokens.
+			assert.Empty(t, token.RefreshToken)
+
+			claims, err := tokener.InspectToken(ctx, token.AccessToken)
+			require.NoError(t, err)
+			require.NoError(t, claims.Valid())
+
+			clientClaims, ok := claims.(*ClientClaims)
+			require.True(t, ok, "%T", claims)
+			assert.Equal(t, "client-7", clientClaims.ClientID)
+			assert.Equal(t, "client-7", clientClaims.Subject())
+
+			principal, ok := clientClaims.GetAccount().(*ClientPrincipal)
+			require.True(t, ok)
+			assert.Equal(t, "client-7", principal.ClientID)
+			assert.Equal(t, "read write", principal.Scope())
+
+			// Revoking all the account tokens doesn't revoke the client tokens.
+			require.NoError(t, tokener.(accountRevoker).RevokeAccountTokens(ctx, account))
+			claims, err = tokener.InspectToken(ctx, token.AccessToken)
+			require.NoError(t, err)
+			assert.NoError(t, claims.Valid())
+
+			// The client token could be revoked on its own.
+			require.NoError(t, tokener.RevokeToken(ctx, token.AccessToken))
+			claims, err = tokener.InspectToken(ctx, token.AccessToken)
+			require.NoError(t, err)
+			assert.True(t, errors.Is(claims.Valid(), auth.ErrTokenRevoked))
+		})
+	}
+}
+
+func TestClientTokenNoClientID(t *testing.T) {
+	for name, tokener := range testTokeners(t) {
+		t.Run(name, func(t *testing.T) {
+			_, err := tokener.Token(context.Background(), &testClient{testAccount{ID: 1}})
+			assert.True(t, errors.Is(err, auth.ErrNoRequiredOption))
+		})
+	}
+}
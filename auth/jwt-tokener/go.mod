@@ -5,4 +5,12 @@ go 1.13
 require (
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/neuronlabs/neuron v0.21.6
+	github.com/neuronlabs/neuron-extensions/store/memory v0.0.0
+	github.com/neuronlabs/neuron-extensions/store/xstore v0.0.0
+	github.com/stretchr/testify v1.4.0
+)
+
+replace (
+	github.com/neuronlabs/neuron-extensions/store/memory => ../../store/memory
+	github.com/neuronlabs/neuron-extensions/store/xstore => ../../store/xstore
 )
@@ -0,0 +1,381 @@
+package tokener
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if OpaqueTokener implements auth.Tokener.
+var _ auth.Tokener = &OpaqueTokener{}
+
+// DefaultOpaqueTokenLength is the default number of random bytes used to create an opaque token.
+const DefaultOpaqueTokenLength = 32
+
+// tokenFamilyLength is the number of random bytes of the token family, shared by the refresh token and all the access
+// tokens issued with it.
+const tokenFamilyLength = 8
+
+// OpaqueTokener is neuron auth.Tokener implementation that issues random, opaque reference tokens.
+// Contrary to the Tokener the token string doesn't contain any information - all the claims are kept only
+// in the store.Store under the hashed token key. This allows to revoke the tokens instantly and doesn't expose
+// any account data to the token holders.
+// The revocation and refresh semantics are the same as for the jwt Tokener. The refresh token and all the access
+// tokens issued with it share the random family prefix, which is the hash tag of their store keys.
+type OpaqueTokener struct {
+	Store   store.Store
+	Options auth.TokenerOptions
+	// TokenLength is the number of random bytes used for the token.
+	TokenLength int
+}
+
+// OpaqueStoreToken is the opaque token's store value. Besides the revocation data it contains token claims
+// and the account (for the access tokens) or the client id (for the client access tokens).
+type OpaqueStoreToken struct {
+	StoreToken
+	Claims   Claims          `json:"claims"`
+	Account  json.RawMessage `json:"account,omitempty"`
+	ClientID string          `json:"client_id,omitempty"`
+}
+
+// NewOpaque creates new OpaqueTokener with provided 'options'.
+// Opaque tokener requires the store and account model to be defined.
+func NewOpaque(options ...auth.TokenerOption) (*OpaqueTokener, error) {
+	o := &auth.TokenerOptions{
+		TokenExpiration:        time.Minute * 10,
+		RefreshTokenExpiration: time.Hour * 24,
+		TimeFunc:               time.Now,
+	}
+	for _, option := range options {
+		option(o)
+	}
+	if o.Model == nil {
+		return nil, errors.Wrap(auth.ErrInitialization, "no account model defined for the tokener")
+	}
+	if o.Store == nil {
+		return nil, errors.Wrap(auth.ErrInitialization, "no store defined for the opaque tokener")
+	}
+	t := &OpaqueTokener{
+		Store:       o.Store,
+		Options:     *o,
+		TokenLength: DefaultOpaqueTokenLength,
+	}
+	return t, nil
+}
+
+// InspectToken inspects given token string and returns the claims stored for it.
+func (t *OpaqueTokener) InspectToken(ctx context.Context, token string) (auth.Claims, error) {
+	claims, _, err := t.inspectToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// Token creates an auth.Token from provided options.
+func (t *OpaqueTokener) Token(ctx context.Context, account auth.Account, options ...auth.TokenOption) (auth.Token, error) {
+	o := &auth.TokenOptions{
+		ExpirationTime:        t.Options.TokenExpiration,
+		RefreshExpirationTime: t.Options.RefreshTokenExpiration,
+	}
+	for _, option := range options {
+		option(o)
+	}
+
+	if account == nil {
+		return auth.Token{}, errors.Wrap(auth.ErrNoRequiredOption, "provided no account in the token creation")
+	}
+	if client, ok := account.(ClientAccount); ok {
+		return t.clientToken(ctx, client, o)
+	}
+	if account.IsPrimaryKeyZero() {
+		return auth.Token{}, errors.Wrap(auth.ErrNoRequiredOption, "provided account with zero value primary key")
+	}
+
+	// Get string value for the account's primary key.
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return auth.Token{}, errors.Wrapf(auth.ErrInternalError, "getting account primary key string value failed: %v", err)
+	}
+	accountValue, err := json.Marshal(account)
+	if err != nil {
+		return auth.Token{}, errors.Wrapf(auth.ErrInternalError, "marshaling account failed: %v", err)
+	}
+
+	// The access tokens shares the family with its refresh token, so that their store keys have the same hash tag.
+	refreshToken := o.RefreshToken
+	family := tokenFamily(refreshToken)
+	if refreshToken == "" {
+		if family, err = randomString(tokenFamilyLength); err != nil {
+			return auth.Token{}, err
+		}
+	}
+
+	now := t.Options.TimeFunc()
+	tokenString, err := t.newTokenString(family)
+	if err != nil {
+		return auth.Token{}, err
+	}
+	accessKey := t.tokenStoreKey(tokenString)
+
+	// Check if the refresh token is provided.
+	var refreshStoreToken *OpaqueStoreToken
+	if refreshToken == "" {
+		refreshToken, err = t.newTokenString(family)
+		if err != nil {
+			return auth.Token{}, err
+		}
+		refreshTokenExpiration := now.Add(o.RefreshExpirationTime)
+		refreshStoreToken = &OpaqueStoreToken{
+			StoreToken: StoreToken{ExpiresAt: refreshTokenExpiration, IssuedAt: now},
+			Claims:     t.newClaims(accountID, now, refreshTokenExpiration, o),
+		}
+	} else {
+		refreshStoreToken, err = t.getStoreToken(ctx, t.tokenStoreKey(refreshToken))
+		if err != nil {
+			if errors.Is(err, store.ErrRecordNotFound) {
+				return auth.Token{}, errors.Wrap(auth.ErrToken, "refresh token not found")
+			}
+			return auth.Token{}, err
+		}
+		if !refreshStoreToken.ExpiresAt.After(now) {
+			return auth.Token{}, errors.Wrap(auth.ErrTokenExpired, "refresh token expired")
+		}
+		if len(refreshStoreToken.Account) != 0 || refreshStoreToken.ClientID != "" {
+			return auth.Token{}, errors.Wrap(auth.ErrToken, "provided access token as the refresh token")
+		}
+		if refreshStoreToken.RevokedAt != nil {
+			return auth.Token{}, errors.Wrap(auth.ErrTokenRevoked, "refresh token was revoked")
+		}
+	}
+	refreshKey := t.tokenStoreKey(refreshToken)
+	// Add the access token to the refresh mapped tokens, and store it.
+	refreshStoreToken.MappedTokens = append(refreshStoreToken.MappedTokens, accessKey)
+	if err = t.setStoreToken(ctx, refreshKey, refreshStoreToken); err != nil {
+		return auth.Token{}, err
+	}
+
+	// Create and set the store token for the access token with the mapped refresh token.
+	expiresAt := now.Add(o.ExpirationTime)
+	sToken := &OpaqueStoreToken{
+		StoreToken: StoreToken{ExpiresAt: expiresAt, IssuedAt: now, MappedTokens: []string{refreshKey}},
+		Claims:     t.newClaims(accountID, now, expiresAt, o),
+		Account:    accountValue,
+	}
+	if err = t.setStoreToken(ctx, accessKey, sToken); err != nil {
+		return auth.Token{}, err
+	}
+
+	return auth.Token{
+		AccessToken:  tokenString,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(o.ExpirationTime / time.Second),
+		TokenType:    "bearer",
+	}, nil
+}
+
+// RevokeToken invalidates provided 'token' and all the tokens mapped to it.
+func (t *OpaqueTokener) RevokeToken(ctx context.Context, token string) error {
+	claims, sToken, err := t.inspectToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if sToken.RevokedAt != nil {
+		return errors.Wrap(auth.ErrTokenRevoked, "token was already revoked")
+	}
+	if err = claims.Valid(); err != nil {
+		return err
+	}
+
+	now := t.Options.TimeFunc()
+	sToken.RevokedAt = &now
+	key := t.tokenStoreKey(token)
+	if err = t.setStoreToken(ctx, key, sToken); err != nil {
+		return err
+	}
+	alreadyRevoked := map[string]struct{}{key: {}}
+	for _, mappedKey := range sToken.MappedTokens {
+		if err = t.revokeTokenKey(ctx, mappedKey, now, alreadyRevoked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevokeAccountTokens revokes all the tokens issued for the 'account' until now.
+func (t *OpaqueTokener) RevokeAccountTokens(ctx context.Context, account auth.Account) error {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return errors.Wrapf(auth.ErrInternalError, "getting account primary key string value failed: %v", err)
+	}
+	return setAccountRevocation(ctx, t.Store, accountID, t.Options.TimeFunc(), &t.Options)
+}
+
+func (t *OpaqueTokener) revokeTokenKey(ctx context.Context, key string, now time.Time, alreadyRevoked map[string]struct{}) error {
+	if _, ok := alreadyRevoked[key]; ok {
+		return nil
+	}
+	sToken, err := t.getStoreToken(ctx, key)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			// The mapped token has already expired.
+			return nil
+		}
+		return err
+	}
+	if sToken.RevokedAt != nil {
+		return nil
+	}
+	sToken.RevokedAt = &now
+	if err = t.setStoreToken(ctx, key, sToken); err != nil {
+		return err
+	}
+	alreadyRevoked[key] = struct{}{}
+	for _, mappedKey := range sToken.MappedTokens {
+		if err = t.revokeTokenKey(ctx, mappedKey, now, alreadyRevoked); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *OpaqueTokener) inspectToken(ctx context.Context, token string) (auth.Claims, *OpaqueStoreToken, error) {
+	if token == "" {
+		return nil, nil, errors.Wrap(auth.ErrToken, "provided empty token")
+	}
+	sToken, err := t.getStoreToken(ctx, t.tokenStoreKey(token))
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, nil, errors.Wrap(auth.ErrToken, "token not found")
+		}
+		return nil, nil, err
+	}
+	// The client tokens are not revoked with the account tokens - the subject is not an account.
+	if sToken.RevokedAt == nil && sToken.ClientID == "" {
+		// Check if all the account tokens were not revoked.
+		if sToken.RevokedAt, err = accountRevokedAt(ctx, t.Store, sToken.Claims.StandardClaims.Subject, &sToken.StoreToken); err != nil {
+			return nil, nil, err
+		}
+	}
+	claims := sToken.Claims
+	if sToken.RevokedAt != nil {
+		claims.RevokedAt = sToken.RevokedAt.Unix()
+	}
+
+	if sToken.ClientID != "" {
+		return &ClientClaims{ClientID: sToken.ClientID, Claims: claims}, sToken, nil
+	}
+	// The refresh tokens doesn't contain the account.
+	if len(sToken.Account) == 0 {
+		return &claims, sToken, nil
+	}
+	account := t.newAccount()
+	if err = json.Unmarshal(sToken.Account, account); err != nil {
+		log.Errorf("[jwt-tokener] unmarshal opaque token account failed: %v", err)
+		return nil, nil, errors.Wrap(auth.ErrInternalError, "opaque token account malformed")
+	}
+	return &AccessClaims{Account: account, Claims: claims}, sToken, nil
+}
+
+func (t *OpaqueTokener) newClaims(subject string, issuedAt, expiresAt time.Time, o *auth.TokenOptions) Claims {
+	claims := Claims{TokenScope: o.Scope, StandardClaims: jwt.StandardClaims{
+		Subject:   subject,
+		IssuedAt:  issuedAt.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		Audience:  o.Audience,
+		Issuer:    o.Issuer,
+	}}
+	if !o.NotBefore.IsZero() {
+		claims.NotBefore = o.NotBefore.Unix()
+	}
+	return claims
+}
+
+// newTokenString creates new random token string. If the 'family' is not empty, the token is prefixed with it.
+func (t *OpaqueTokener) newTokenString(family string) (string, error) {
+	length := t.TokenLength
+	if length <= 0 {
+		length = DefaultOpaqueTokenLength
+	}
+	token, err := randomString(length)
+	if err != nil {
+		return "", err
+	}
+	if family != "" {
+		token = family + "." + token
+	}
+	return token, nil
+}
+
+// tokenFamily gets the family of the opaque 'token'. The tokens issued before the families were introduced
+// have no family.
+func tokenFamily(token string) string {
+	if i := strings.IndexByte(token, '.'); i > 0 {
+		return token[:i]
+	}
+	return ""
+}
+
+// randomString creates the base64 url encoded string of 'length' random bytes.
+func randomString(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrapf(auth.ErrInternalError, "generating random token failed: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (t *OpaqueTokener) getStoreToken(ctx context.Context, key string) (*OpaqueStoreToken, error) {
+	record, err := t.Store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	st := &OpaqueStoreToken{}
+	if err := json.Unmarshal(record.Value, st); err != nil {
+		log.Errorf("[jwt-tokener] unmarshal opaque store token failed: %v", err)
+		return nil, errors.Wrap(store.ErrInternal, "store token malformed")
+	}
+	return st, nil
+}
+
+func (t *OpaqueTokener) setStoreToken(ctx context.Context, key string, sToken *OpaqueStoreToken) error {
+	ttl := sToken.ExpiresAt.Sub(t.Options.TimeFunc())
+	value, err := json.Marshal(sToken)
+	if err != nil {
+		log.Errorf("[jwt-tokener] marshal opaque store token failed: %v", err)
+		return errors.Wrap(store.ErrInternal, "tokener marshal store token failed")
+	}
+	record := &store.Record{
+		Key:       key,
+		Value:     value,
+		ExpiresAt: sToken.ExpiresAt,
+	}
+	return t.Store.Set(ctx, record, store.SetWithTTL(ttl))
+}
+
+// tokenStoreKey gets the store key of the opaque 'token'. The keys of the tokens from the same family are tagged
+// with the family hash tag, so that the token and its mapped tokens are stored i.e. in the same redis cluster slot.
+func (t *OpaqueTokener) tokenStoreKey(token string) string {
+	if family := tokenFamily(token); family != "" {
+		return xstore.HashTag(family, hashToken(token))
+	}
+	return hashToken(token)
+}
+
+func (t *OpaqueTokener) newAccount() auth.Account {
+	tp := reflect.TypeOf(t.Options.Model)
+	return reflect.New(tp.Elem()).Interface().(auth.Account)
+}
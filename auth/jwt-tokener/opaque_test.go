@@ -0,0 +1,138 @@
+package tokener
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+)
+
+func TestOpaqueToken(t *testing.T) {
+	ctx := context.Background()
+	tokener := testTokeners(t)["Opaque"].(*OpaqueTokener)
+	account := &testAccount{ID: 1, Username: "john"}
+
+	token, err := tokener.Token(ctx, account, auth.TokenScope("read"))
+	require.NoError(t, err)
+	require.NotEmpty(t, token.AccessToken)
+	require.NotEmpty(t, token.RefreshToken)
+	assert.NotEqual(t, token.AccessToken, token.RefreshToken)
+	assert.Equal(t, "bearer", token.TokenType)
+
+	t.Run("Access", func(t *testing.T) {
+		claims, err := tokener.InspectToken(ctx, token.AccessToken)
+		require.NoError(t, err)
+		require.NoError(t, claims.Valid())
+		accessClaims, ok := claims.(auth.AccessClaims)
+		require.True(t, ok, "%T", claims)
+		assert.Equal(t, "1", accessClaims.Subject())
+		assert.Equal(t, "john", accessClaims.GetAccount().GetUsername())
+		assert.Equal(t, "read", claims.(auth.Scoper).Scope())
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		claims, err := tokener.InspectToken(ctx, token.RefreshToken)
+		require.NoError(t, err)
+		require.NoError(t, claims.Valid())
+		_, ok := claims.(auth.AccessClaims)
+		assert.False(t, ok)
+		assert.Equal(t, "1", claims.Subject())
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		// The store contains neither the raw tokens nor the account in plain keys.
+		records, err := tokener.Store.Find(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, records)
+		for _, record := range records {
+			assert.False(t, strings.Contains(record.Key, token.AccessToken))
+			assert.False(t, strings.Contains(string(record.Value), token.AccessToken))
+			assert.False(t, strings.Contains(string(record.Value), token.RefreshToken))
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := tokener.InspectToken(ctx, "unknown")
+		assert.True(t, errors.Is(err, auth.ErrToken))
+		_, err = tokener.InspectToken(ctx, "")
+		assert.True(t, errors.Is(err, auth.ErrToken))
+	})
+
+	t.Run("AccessAsRefresh", func(t *testing.T) {
+		_, err := tokener.Token(ctx, account, auth.TokenRefreshToken(token.AccessToken))
+		assert.True(t, errors.Is(err, auth.ErrToken))
+	})
+
+	t.Run("UnknownRefresh", func(t *testing.T) {
+		_, err := tokener.Token(ctx, account, auth.TokenRefreshToken("unknown"))
+		assert.True(t, errors.Is(err, auth.ErrToken))
+		assert.False(t, errors.Is(err, store.ErrRecordNotFound))
+	})
+
+	t.Run("ExpiredRefresh", func(t *testing.T) {
+		// The refresh token is expired, even if its record is still in the store.
+		tokener.Options.TimeFunc = func() time.Time { return time.Now().Add(tokener.Options.RefreshTokenExpiration + time.Minute) }
+		defer func() { tokener.Options.TimeFunc = time.Now }()
+		_, err := tokener.Token(ctx, account, auth.TokenRefreshToken(token.RefreshToken))
+		assert.True(t, errors.Is(err, auth.ErrTokenExpired))
+	})
+}
+
+func TestOpaqueRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	tokener := testTokeners(t)["Opaque"].(*OpaqueTokener)
+	account := &testAccount{ID: 1, Username: "john"}
+
+	isRevoked := func(t *testing.T, token string) bool {
+		claims, err := tokener.InspectToken(ctx, token)
+		require.NoError(t, err)
+		return errors.Is(claims.Valid(), auth.ErrTokenRevoked)
+	}
+
+	t.Run("Refresh", func(t *testing.T) {
+		token, err := tokener.Token(ctx, account)
+		require.NoError(t, err)
+		refreshed, err := tokener.Token(ctx, account, auth.TokenRefreshToken(token.RefreshToken))
+		require.NoError(t, err)
+		assert.Equal(t, token.RefreshToken, refreshed.RefreshToken)
+
+		// Revoking the refresh token revokes all the access tokens issued with it.
+		require.NoError(t, tokener.RevokeToken(ctx, token.RefreshToken))
+		assert.True(t, isRevoked(t, token.RefreshToken))
+		assert.True(t, isRevoked(t, token.AccessToken))
+		assert.True(t, isRevoked(t, refreshed.AccessToken))
+
+		err = tokener.RevokeToken(ctx, token.RefreshToken)
+		assert.True(t, errors.Is(err, auth.ErrTokenRevoked))
+		_, err = tokener.Token(ctx, account, auth.TokenRefreshToken(token.RefreshToken))
+		assert.True(t, errors.Is(err, auth.ErrTokenRevoked))
+	})
+
+	t.Run("Access", func(t *testing.T) {
+		token, err := tokener.Token(ctx, account)
+		require.NoError(t, err)
+
+		require.NoError(t, tokener.RevokeToken(ctx, token.AccessToken))
+		assert.True(t, isRevoked(t, token.AccessToken))
+		assert.True(t, isRevoked(t, token.RefreshToken))
+	})
+
+	t.Run("Account", func(t *testing.T) {
+		token, err := tokener.Token(ctx, account)
+		require.NoError(t, err)
+		other, err := tokener.Token(ctx, &testAccount{ID: 2, Username: "jane"})
+		require.NoError(t, err)
+
+		require.NoError(t, tokener.RevokeAccountTokens(ctx, account))
+		assert.True(t, isRevoked(t, token.AccessToken))
+		assert.True(t, isRevoked(t, token.RefreshToken))
+		assert.False(t, isRevoked(t, other.AccessToken))
+	})
+}
@@ -7,9 +7,14 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/neuronlabs/neuron/auth"
 	"github.com/neuronlabs/neuron/errors"
 	"github.com/neuronlabs/neuron/log"
 	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
 )
 
 // StoreToken is the token's store value.
@@ -17,11 +22,16 @@ type StoreToken struct {
 	MappedTokens []string   `json:"mapped_tokens"`
 	RevokedAt    *time.Time `json:"is_revoked"`
 	ExpiresAt    time.Time  `json:"expires_at"`
+	IssuedAt     time.Time  `json:"issued_at,omitempty"`
 }
 
 func (t *Tokener) getStoreToken(ctx context.Context, token string) (*StoreToken, error) {
 	key := t.tokenStoreKey(token)
 	record, err := t.Store.Get(ctx, key)
+	if errors.Is(err, store.ErrRecordNotFound) && key != hashToken(token) {
+		// The tokens stored before the keys were tagged with the subject are kept under the token hash.
+		record, err = t.Store.Get(ctx, hashToken(token))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -34,7 +44,7 @@ func (t *Tokener) getStoreToken(ctx context.Context, token string) (*StoreToken,
 }
 
 func (t *Tokener) setStoreToken(ctx context.Context, token string, sToken *StoreToken) error {
-	ttl := t.Options.TimeFunc().Sub(sToken.ExpiresAt)
+	ttl := sToken.ExpiresAt.Sub(t.Options.TimeFunc())
 	value, err := json.Marshal(sToken)
 	if err != nil {
 		log.Errorf("[jwt-tokener] marshal store token failed: %v", err)
@@ -51,8 +61,64 @@ func (t *Tokener) setStoreToken(ctx context.Context, token string, sToken *Store
 
 }
 
+// tokenStoreKey gets the store key of the 'token'. The key is tagged with the token subject hash tag, so that all
+// the account tokens, including the mapped ones, are stored i.e. in the same redis cluster slot.
 func (t *Tokener) tokenStoreKey(token string) string {
+	claims := &jwt.StandardClaims{}
+	if _, _, err := t.Parser.ParseUnverified(token, claims); err != nil || claims.Subject == "" {
+		return hashToken(token)
+	}
+	return xstore.HashTag(claims.Subject, hashToken(token))
+}
+
+// hashToken creates the store key for provided token, so that the raw token value is never kept in the store.
+func hashToken(token string) string {
 	h := sha256.New()
 	h.Write([]byte(token))
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+// accountRevocationKey is the store key of the time before which all the account tokens are revoked.
+func accountRevocationKey(accountID string) string {
+	return "nrn_account_tokens_revoked_" + accountID
+}
+
+// setAccountRevocation marks all the tokens of the account with 'accountID' issued until 'now' as revoked.
+// The record must live as long as the longest lived token issued until 'now', thus its time to live is the maximum
+// of the access and refresh token expiration.
+func setAccountRevocation(ctx context.Context, s store.Store, accountID string, now time.Time, o *auth.TokenerOptions) error {
+	value, err := now.MarshalText()
+	if err != nil {
+		return errors.Wrap(store.ErrInternal, "marshal account revocation time failed")
+	}
+	ttl := o.TokenExpiration
+	if o.RefreshTokenExpiration > ttl {
+		ttl = o.RefreshTokenExpiration
+	}
+	record := &store.Record{Key: accountRevocationKey(accountID), Value: value, ExpiresAt: now.Add(ttl)}
+	return s.Set(ctx, record, store.SetWithTTL(ttl))
+}
+
+// accountRevokedAt checks if the token with the 'sToken' value, issued for the account with 'accountID' was revoked
+// with all the account tokens. Returns nil if the token is not revoked this way.
+func accountRevokedAt(ctx context.Context, s store.Store, accountID string, sToken *StoreToken) (*time.Time, error) {
+	if accountID == "" {
+		return nil, nil
+	}
+	record, err := s.Get(ctx, accountRevocationKey(accountID))
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	revokedAt := time.Time{}
+	if err = revokedAt.UnmarshalText(record.Value); err != nil {
+		log.Errorf("[jwt-tokener] unmarshal account revocation time failed: %v", err)
+		return nil, errors.Wrap(store.ErrInternal, "account revocation time malformed")
+	}
+	if sToken.IssuedAt.After(revokedAt) {
+		return nil, nil
+	}
+	return &revokedAt, nil
+}
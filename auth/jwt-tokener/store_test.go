@@ -0,0 +1,102 @@
+package tokener
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+
+	"github.com/neuronlabs/neuron-extensions/store/memory"
+)
+
+func TestAccountRevocationTTL(t *testing.T) {
+	ctx := context.Background()
+	s, err := memory.New()
+	require.NoError(t, err)
+
+	now := time.Now()
+	o := &auth.TokenerOptions{TokenExpiration: time.Minute * 10, RefreshTokenExpiration: time.Hour * 24}
+	require.NoError(t, setAccountRevocation(ctx, s, "1", now, o))
+
+	// The revocation record must outlive the longest lived token.
+	record, err := s.Get(ctx, accountRevocationKey("1"))
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.Add(o.RefreshTokenExpiration), record.ExpiresAt, time.Second)
+
+	revokedAt, err := accountRevokedAt(ctx, s, "1", &StoreToken{IssuedAt: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	require.NotNil(t, revokedAt)
+	assert.True(t, revokedAt.Equal(now))
+
+	// The tokens issued after the revocation are valid.
+	revokedAt, err = accountRevokedAt(ctx, s, "1", &StoreToken{IssuedAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	assert.Nil(t, revokedAt)
+}
+
+func TestTokenStoreKeyHashTag(t *testing.T) {
+	ctx := context.Background()
+	tokeners := testTokeners(t)
+	account := &testAccount{ID: 1, Username: "john"}
+
+	t.Run("JWT", func(t *testing.T) {
+		tokener := tokeners["JWT"].(*Tokener)
+		token, err := tokener.Token(ctx, account)
+		require.NoError(t, err)
+		refreshed, err := tokener.Token(ctx, account, auth.TokenRefreshToken(token.RefreshToken))
+		require.NoError(t, err)
+
+		// All the account tokens are tagged with the account id.
+		for _, tk := range []string{token.AccessToken, token.RefreshToken, refreshed.AccessToken} {
+			key := tokener.tokenStoreKey(tk)
+			assert.True(t, strings.HasPrefix(key, "{1}"), key)
+			_, err = tokener.Store.Get(ctx, key)
+			assert.NoError(t, err)
+		}
+	})
+
+	t.Run("JWTUntagged", func(t *testing.T) {
+		tokener := tokeners["JWT"].(*Tokener)
+		token, err := tokener.Token(ctx, account)
+		require.NoError(t, err)
+
+		// The tokens stored with the untagged keys are still valid.
+		key := tokener.tokenStoreKey(token.AccessToken)
+		record, err := tokener.Store.Get(ctx, key)
+		require.NoError(t, err)
+		require.NoError(t, tokener.Store.Delete(ctx, key))
+		record.Key = hashToken(token.AccessToken)
+		require.NoError(t, tokener.Store.Set(ctx, record))
+
+		claims, err := tokener.InspectToken(ctx, token.AccessToken)
+		require.NoError(t, err)
+		assert.NoError(t, claims.Valid())
+	})
+
+	t.Run("Opaque", func(t *testing.T) {
+		tokener := tokeners["Opaque"].(*OpaqueTokener)
+		token, err := tokener.Token(ctx, account)
+		require.NoError(t, err)
+		refreshed, err := tokener.Token(ctx, account, auth.TokenRefreshToken(token.RefreshToken))
+		require.NoError(t, err)
+
+		// The refresh token and the access tokens issued with it share the family hash tag.
+		family := tokenFamily(token.RefreshToken)
+		require.NotEmpty(t, family)
+		for _, tk := range []string{token.AccessToken, token.RefreshToken, refreshed.AccessToken} {
+			key := tokener.tokenStoreKey(tk)
+			assert.True(t, strings.HasPrefix(key, "{"+family+"}"), key)
+			_, err = tokener.Store.Get(ctx, key)
+			assert.NoError(t, err)
+		}
+
+		other, err := tokener.Token(ctx, account)
+		require.NoError(t, err)
+		assert.NotEqual(t, family, tokenFamily(other.RefreshToken))
+	})
+}
@@ -92,6 +92,9 @@ func (t *Tokener) Token(ctx context.Context, account auth.Account, options ...au
 	if account == nil {
 		return auth.Token{}, errors.Wrap(auth.ErrNoRequiredOption, "provided no account in the token creation")
 	}
+	if client, ok := account.(ClientAccount); ok {
+		return t.clientToken(ctx, client, o)
+	}
 	if account.IsPrimaryKeyZero() {
 		return auth.Token{}, errors.Wrap(auth.ErrNoRequiredOption, "provided account with zero value primary key")
 	}
@@ -103,11 +106,12 @@ func (t *Tokener) Token(ctx context.Context, account auth.Account, options ...au
 	}
 
 	// Set the claims for the full token.
-	expiresAt := t.Options.TimeFunc().Add(o.ExpirationTime)
+	now := t.Options.TimeFunc()
+	expiresAt := now.Add(o.ExpirationTime)
 	claims := &AccessClaims{
 		Account: account,
 		// Set the claims with current accountID and expiresAt.
-		Claims: Claims{StandardClaims: jwt.StandardClaims{Subject: accountID, ExpiresAt: expiresAt.Unix()}},
+		Claims: Claims{TokenScope: o.Scope, StandardClaims: jwt.StandardClaims{Subject: accountID, ExpiresAt: expiresAt.Unix()}},
 	}
 
 	token := jwt.NewWithClaims(t.Options.SigningMethod, claims)
@@ -121,8 +125,9 @@ func (t *Tokener) Token(ctx context.Context, account auth.Account, options ...au
 	refreshToken := o.RefreshToken
 	if refreshToken == "" {
 		// Create and sign refresh token.
-		refreshTokenExpiration := t.Options.TimeFunc().Add(t.Options.RefreshTokenExpiration)
+		refreshTokenExpiration := now.Add(t.Options.RefreshTokenExpiration)
 		refClaims := &Claims{
+			TokenScope: o.Scope,
 			StandardClaims: jwt.StandardClaims{
 				// Token subject should be account ID.
 				Subject:   accountID,
@@ -134,7 +139,7 @@ func (t *Tokener) Token(ctx context.Context, account auth.Account, options ...au
 		if err != nil {
 			return auth.Token{}, errors.Wrapf(auth.ErrInternalError, "writing refresh token signed string failed: %v", err)
 		}
-		refreshStoreToken = &StoreToken{ExpiresAt: refreshTokenExpiration}
+		refreshStoreToken = &StoreToken{ExpiresAt: refreshTokenExpiration, IssuedAt: now}
 	} else {
 		refreshStoreToken, err = t.getStoreToken(ctx, refreshToken)
 		if err != nil {
@@ -148,7 +153,7 @@ func (t *Tokener) Token(ctx context.Context, account auth.Account, options ...au
 	}
 
 	// Create and set the store token for the access token with the mapped refresh token.
-	sToken := &StoreToken{ExpiresAt: expiresAt, MappedTokens: []string{refreshToken}}
+	sToken := &StoreToken{ExpiresAt: expiresAt, IssuedAt: now, MappedTokens: []string{refreshToken}}
 	if err = t.setStoreToken(ctx, tokenString, sToken); err != nil {
 		return auth.Token{}, err
 	}
@@ -191,6 +196,15 @@ func (t *Tokener) RevokeToken(ctx context.Context, token string) error {
 	return nil
 }
 
+// RevokeAccountTokens revokes all the tokens issued for the 'account' until now.
+func (t *Tokener) RevokeAccountTokens(ctx context.Context, account auth.Account) error {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return errors.Wrapf(auth.ErrInternalError, "getting account primary key string value failed: %v", err)
+	}
+	return setAccountRevocation(ctx, t.Store, accountID, t.Options.TimeFunc(), &t.Options)
+}
+
 func (t *Tokener) revokeToken(ctx context.Context, token string, now time.Time, alreadyRevoked map[string]struct{}) error {
 	if _, ok := alreadyRevoked[token]; ok {
 		return nil
@@ -217,7 +231,7 @@ func (t *Tokener) revokeToken(ctx context.Context, token string, now time.Time,
 
 func (t *Tokener) inspectToken(ctx context.Context, token string) (auth.Claims, *StoreToken, error) {
 	// Initialize jwt.MapClaims.
-	claims := &AccessClaims{Account: t.newAccount()}
+	claims := &parsedClaims{AccessClaims: AccessClaims{Account: t.newAccount()}}
 	_, err := t.Parser.ParseWithClaims(token, claims, func(tk *jwt.Token) (interface{}, error) {
 		if tk.Method != t.Options.SigningMethod {
 			return nil, errors.Wrap(auth.ErrToken, "provided invalid signing algorithm for the token")
@@ -234,15 +248,31 @@ func (t *Tokener) inspectToken(ctx context.Context, token string) (auth.Claims,
 	if err != nil {
 		return nil, nil, err
 	}
+	// The client tokens are not revoked with the account tokens - the subject is not an account.
+	if sToken.RevokedAt == nil && claims.ClientID == "" {
+		// Check if all the account tokens were not revoked.
+		if sToken.RevokedAt, err = accountRevokedAt(ctx, t.Store, claims.StandardClaims.Subject, sToken); err != nil {
+			return nil, nil, err
+		}
+	}
 	if sToken.RevokedAt != nil {
 		claims.RevokedAt = sToken.RevokedAt.Unix()
 	}
 
+	if claims.ClientID != "" {
+		return &ClientClaims{ClientID: claims.ClientID, Claims: claims.Claims}, sToken, nil
+	}
 	// Check if there is account with valid ID. Otherwise set it as refresh token.
 	if claims.Account.IsPrimaryKeyZero() {
 		return &claims.Claims, sToken, nil
 	}
-	return claims, sToken, nil
+	return &claims.AccessClaims, sToken, nil
+}
+
+// parsedClaims are the claims of any token issued by the Tokener. The client tokens contains the client id.
+type parsedClaims struct {
+	AccessClaims
+	ClientID string `json:"client_id,omitempty"`
 }
 
 func (t *Tokener) newAccount() auth.Account {
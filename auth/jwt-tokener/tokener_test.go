@@ -0,0 +1,75 @@
+package tokener
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+
+	"github.com/neuronlabs/neuron-extensions/store/memory"
+)
+
+// testAccount is the account model used by the tests.
+type testAccount struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+func (t *testAccount) NeuronCollectionName() string { return "test_accounts" }
+func (t *testAccount) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.Itoa(t.ID), nil
+}
+func (t *testAccount) GetPrimaryKeyValue() interface{}         { return t.ID }
+func (t *testAccount) GetPrimaryKeyHashableValue() interface{} { return t.ID }
+func (t *testAccount) GetPrimaryKeyZeroValue() interface{}     { return 0 }
+func (t *testAccount) GetPrimaryKeyAddress() interface{}       { return &t.ID }
+func (t *testAccount) IsPrimaryKeyZero() bool                  { return t.ID == 0 }
+func (t *testAccount) SetPrimaryKeyValue(src interface{}) error {
+	id, ok := src.(int)
+	if !ok {
+		return errors.Wrap(mapping.ErrFieldValue, "invalid primary key value")
+	}
+	t.ID = id
+	return nil
+}
+func (t *testAccount) SetPrimaryKeyStringValue(src string) (err error) {
+	t.ID, err = strconv.Atoi(src)
+	return err
+}
+func (t *testAccount) GetUsername() string         { return t.Username }
+func (t *testAccount) SetUsername(username string) { t.Username = username }
+func (t *testAccount) GetPasswordHash() []byte     { return nil }
+func (t *testAccount) SetPasswordHash([]byte)      {}
+func (t *testAccount) UsernameField() string       { return "Username" }
+func (t *testAccount) PasswordHashField() string   { return "PasswordHash" }
+
+// testClient is the OAuth2 client account used by the tests.
+type testClient struct {
+	testAccount
+}
+
+func (t *testClient) ClientPrincipal() {}
+
+func testTokeners(t *testing.T) map[string]auth.Tokener {
+	t.Helper()
+	s, err := memory.New()
+	require.NoError(t, err)
+	jwtTokener, err := New(
+		auth.TokenerAccount(&testAccount{}),
+		auth.TokenerSecret([]byte("secret-key")),
+		auth.TokenerSigningMethod(jwt.SigningMethodHS256),
+		auth.TokenerStore(s),
+	)
+	require.NoError(t, err)
+
+	s, err = memory.New()
+	require.NoError(t, err)
+	opaque, err := NewOpaque(auth.TokenerAccount(&testAccount{}), auth.TokenerStore(s))
+	require.NoError(t, err)
+	return map[string]auth.Tokener{"JWT": jwtTokener, "Opaque": opaque}
+}
@@ -363,7 +363,7 @@ func bindataTemplates08singlerelationertmpl() (*asset, error) {
 	return a, nil
 }
 
-var _bindataTemplates09multirelationertmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x58\x4d\x6f\xe3\x36\x10\xbd\xfb\x57\x4c\x8d\x6d\x2c\x05\xb2\x92\x5e\x0d\xb8\x40\xba\x68\x8b\x00\x49\x51\x6c\xb6\xed\xc1\x30\x16\xaa\x34\x4a\x88\x48\xa4\x40\xd1\x4e\x17\x04\xff\x7b\xc1\x0f\xc9\xa6\x24\x6f\x94\xac\xb3\x48\x8a\xe6\x14\x8b\xe4\xcc\xf0\xcd\x9b\x47\x72\xa4\xcc\x30\x27\x14\x61\x5a\x6e\x0a\x41\xe6\x1c\x8b\x44\x10\x46\x91\x4f\x61\xae\xd4\x44\xca\x39\xbc\x63\x1b\x01\x8b\x25\xc4\xe6\xcb\xd9\x19\xbc\x67\x65\x45\x0a\x04\x41\x4a\x84\xf4\x0e\xd3\x7b\xc8\x19\x07\x71\x87\x20\xa5\x9e\x1d\x5f\x27\x55\x45\xe8\xad\x52\xd7\xda\xea\x87\xd6\x28\x10\x2a\x90\xe7\x49\x8a\x40\xca\xaa\xc0\x12\xa9\x30\x43\xf1\x64\x9b\x70\xf8\xf4\xe8\xfa\x25\x9c\x48\x19\xff\x96\x94\xa8\x94\x54\x13\x1d\xcd\x45\x96\x35\x13\xae\x59\x86\xc5\xce\x72\x3d\x3e\x9c\x78\x92\x6f\x68\x0a\x81\x94\xf1\x07\x4c\x91\x6c\x91\x2b\x05\xa7\xad\xaf\xb0\xe7\x26\x68\xa0\xd2\xb3\x7c\x2f\x37\x82\x6f\x52\xf1\x0b\xc1\x22\x8b\xa0\x34\x31\xf5\x02\xd1\x5f\x43\x40\xce\x19\x07\x39\x01\x00\xa8\x1f\x88\x48\xef\xa0\x31\x1b\x5f\xd2\x0c\xff\x59\x9d\xaf\xdd\xb0\x94\x3c\xa1\xb7\x08\xef\x5a\xbf\x3a\x25\x4d\x48\xb5\xce\x8d\x94\x24\xdf\x8d\xc7\x97\xf5\x4d\x41\x52\x34\x59\xd3\x16\xd2\xa4\x36\x09\xf2\x3d\x28\xb5\x80\xb3\x33\xef\xbb\xdd\xb2\x59\x63\x3d\xef\x8c\xea\x21\xed\x38\x10\xec\x8a\x3d\x20\x7f\x9f\x94\x58\xec\xf9\xfc\x29\xa9\xf1\xe3\xe7\x0a\x43\x1b\x8f\xb7\x4e\xa9\x08\xd8\xbd\x5e\x6d\x30\x89\x83\xd3\x7d\xa7\x7a\xe5\x1f\xf4\x81\x27\x55\x85\x99\x36\xa1\x54\xd8\x86\x40\x72\xf8\x8e\xdd\x3b\x24\x9a\x3f\x8e\x62\xc3\xa9\xc5\xb0\x8e\xff\xe2\x49\x95\x07\x5d\x9c\x7f\xe6\xfc\x92\x6e\x93\x82\xb4\xc9\xfb\x33\x29\x36\x18\xc1\xb4\xe2\x6c\x4b\x32\xcc\x80\xd8\x71\xd8\xea\x01\x10\x9f\x2b\x5c\xc0\xec\xfb\x8f\x33\x68\x49\x9d\xeb\x4c\x2e\x60\xd6\xc7\x68\x36\x75\x09\xde\x85\xba\x8f\x5b\x27\x1d\xbf\x33\xc3\xb7\x36\x21\x6e\x67\x2e\xe6\x1d\xef\xe2\xbe\x23\x58\x2e\x81\x92\xa2\x83\x80\xc0\xb2\xd2\x78\xee\xcf\xb7\xd0\x49\xe5\x4d\x1c\xe7\x02\x4e\xb4\xc1\xc1\x9d\x00\xd2\xcc\x8b\xfb\xe0\xe6\x94\x3a\x95\x12\x69\x66\xd2\x3f\xc6\xa9\x4e\x38\xcd\x82\x43\x06\x9f\x66\x2f\xb2\x81\x51\x26\x3c\x5b\x9a\x5c\x0d\x01\x86\xec\xfa\x34\x0d\x5d\xc1\xb9\x3d\xb7\xff\x98\xcf\x19\xe6\xc9\xa6\x10\x8b\xc9\xd7\xd1\xd0\xa9\x43\x9f\x86\x4d\x28\x8e\x84\x0d\x07\xad\x8c\xcc\x5a\x41\xea\x30\xcf\xc6\xe6\x42\xa1\xa4\x98\x58\x6d\xfc\x15\x85\x27\x5a\xf5\x0b\x89\x63\xcf\xcf\x38\x75\x0c\x21\x28\x6d\x58\xab\xf5\xa0\x42\x46\x1a\x56\x0b\x6d\xf8\xfa\x65\xb2\x63\x76\x80\x74\x5e\x09\xe9\xd4\x7e\x6a\x0e\x88\xc5\x12\x6c\xe0\xa3\xaa\xc6\x97\x01\x87\x61\x5b\x4a\xf6\xf7\x17\x95\x69\x0e\x58\xd4\xd8\x0b\x87\xbc\x48\x1c\x27\xc1\x18\x63\x2b\xb2\x0e\x0f\x06\xab\xeb\xf4\x39\x55\x49\x89\xe5\xd0\xd1\x4b\xb3\xb6\xa5\x69\xb6\x68\x4b\x75\x1a\xb5\x13\xb4\x0a\xed\x6d\x76\xa0\x44\x1b\x68\x0e\x97\xea\x85\xf8\x46\xb5\x7a\x21\xc6\x5e\x65\x88\x2e\x04\xed\x6a\x57\xb7\xff\xbd\xaa\x3d\xfa\x21\xfd\x15\x4c\x34\x7b\x88\x60\x6a\x81\xd7\xd7\x70\x96\x43\xc5\xea\x9a\xfc\x5d\xa0\xad\xd3\x18\xae\x1d\x05\x9d\x39\x77\x3c\x44\x60\x92\x36\xb0\xf5\xe9\x70\x91\x75\x0f\x78\x92\xbb\x7c\xff\x08\x05\xd2\x23\x1d\xcf\x21\xcc\xe1\x87\xb7\x88\x8f\x0b\x72\xfc\xe5\xe2\xa4\x45\xe5\x10\x6e\xc1\x53\x80\x3b\x6c\x26\x74\x56\x56\x06\x84\xb5\x95\x94\x37\xa5\x94\x9d\xdd\x3f\x55\x2d\xaf\x90\xbe\xbc\x52\x5e\x21\x1d\x7d\xa5\x21\x54\x44\xff\xab\x9f\xcf\xae\xf3\x1d\x2f\x1f\x13\x1e\xb7\xe2\x88\xa2\xf3\xec\x9a\x38\x7f\x3d\x77\x07\x4b\xfb\x9b\x6f\x74\x9f\xef\xf9\x79\x52\xb7\xa3\x86\x38\x8e\xdf\x72\xc7\xa3\x79\x57\x97\xc9\x3d\x06\xfa\x69\xf2\xf8\xcd\x7e\xf0\x39\x39\xd4\xd4\x88\x0c\xb3\x2d\x4e\x7b\xf7\xdd\xce\x23\xf4\xb1\x1e\x8b\x67\x34\xec\xdf\xe3\x7b\xcf\x8a\xe6\xbe\xd6\x6d\x0b\x1c\xa9\x41\x03\x87\x9a\x34\xf0\x6a\x1b\x35\xbe\x0e\xb5\x6a\x38\xe2\x74\xf7\xe0\xde\x29\xa4\x0f\xe5\xb0\x1c\x1a\x56\x32\x2a\x08\xdd\xa0\x1f\x89\x63\x4f\xaf\xbf\xb3\x22\x6b\x58\x1e\xa3\xab\xf1\xdc\x0e\xd5\xb3\x7a\x47\x83\x2f\xcc\x91\x96\x3a\x86\xf6\x85\xfa\x15\xf4\x64\xea\x2f\xf7\x64\xda\xed\xdc\x08\x4e\xe8\x6d\x10\x1e\xec\xcf\xb8\x3c\xfd\x1b\x00\x00\xff\xff\xd7\x61\xce\x82\x82\x17\x00\x00")
+var _bindataTemplates09multirelationertmpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xed\x58\xdb\x4e\xdb\x40\x10\x7d\xcf\x57\x4c\x23\x0a\x36\x32\x86\xbe\x22\xa5\x12\x45\x6d\x85\x04\x55\x05\xb4\x7d\x40\x08\xb9\xf1\x04\x56\xd8\xeb\x68\xbd\x09\xad\x2c\xff\x7b\x67\x2f\x76\x7c\x0b\x98\x34\x20\xa8\x9a\x27\x58\xef\xce\xe5\xcc\xcc\xd9\xd9\xc9\xb2\x10\x27\x8c\x23\x0c\xe3\x59\x24\xd9\x8e\xc0\x28\x90\x2c\xe1\x28\x86\xb0\x93\xe7\x83\x2c\xdb\x81\x8d\x64\x26\x61\x7f\x04\xbe\x5e\xd9\xdd\x85\xc3\x24\x9e\xb2\x08\x41\xb2\x18\x61\x7c\x83\xe3\x5b\x98\x24\x02\xe4\x0d\x42\x96\xa9\xdd\xfe\x49\x30\x9d\x32\x7e\x9d\xe7\x27\x4a\xea\x69\x29\x14\x18\x97\x28\x26\xc1\x18\x81\xc5\xd3\x08\x63\xe4\x52\x7f\xf2\x07\xf3\x40\xc0\xd5\x83\xe7\x47\xb0\x99\x65\xfe\x97\x20\xc6\x3c\xcf\xf2\x81\xb2\xe6\x20\x0c\x8b\x0d\x27\x49\x88\xd1\x42\x72\xda\xdf\x1c\x7f\x30\x99\xf1\x31\x38\x24\xfc\x14\xc7\xc8\xe6\x28\xf2\x1c\xb6\x4b\x5d\x6e\x4b\x8d\x53\x40\xa5\x76\xd5\xb5\x9c\x49\x31\x1b\xcb\x4f\x0c\xa3\xd0\x83\x58\xdb\xd4\x32\x44\xad\xba\x80\x42\x10\x70\xd9\x00\xe8\x97\xde\x31\x39\xbe\x81\x42\xac\x7f\xc4\x43\xfc\x75\xb1\x77\x69\x3f\x67\x99\x08\xf8\x35\xc2\x46\xa9\x57\x85\xa4\x30\x29\x55\xb1\xc9\x32\x36\x59\x7c\xf7\x8f\xd2\xb3\x88\x11\xd2\x2a\x6a\x4a\xc2\x38\x48\x75\x80\xea\x1a\xf2\x7c\x1f\x08\xc5\xea\xba\x71\x59\x9f\x31\x9a\x17\x42\xd5\x27\xa5\xd8\x91\xc9\x71\x72\x87\xe2\x90\xfe\x8f\x2a\x3a\x3f\x90\x8e\xf3\xdf\x53\x74\x8d\x3d\xb5\x73\x79\xee\x41\x72\xab\x4e\x6b\x4c\x7c\x67\xbb\xaa\x54\x9d\xfc\xc6\xef\x04\x21\x84\xa1\x12\x41\xa0\x97\x26\x90\x5f\x6f\xe8\x68\x56\x2e\xa8\x9f\x40\x39\x13\xdc\x60\x98\xfa\x3f\xe8\xe4\xc4\x69\xe2\xfc\x51\x88\x23\x3e\x0f\x22\x56\x06\xef\x7b\x10\xcd\xd0\x83\xe1\x54\x24\x73\x16\x62\x48\x39\xa0\xbf\xc3\x5c\x7d\x00\x49\x9a\xf7\x61\xeb\xed\xf9\x16\x94\x49\x3d\x51\x91\xa4\xc5\x36\x46\x5b\x43\x1b\xe0\x85\xa9\x55\xdc\x1a\xe1\xf8\x9a\xe8\x7c\x2b\x03\x62\x3d\xb3\x36\x2f\xf2\xce\x6f\x2b\x82\xd1\x08\x38\x8b\x1a\x08\x48\x8c\xa7\x0a\xcf\xea\x7e\x03\x5d\x96\xd7\x36\xf6\x53\x01\x9b\x4a\x60\xa7\x27\x80\x3c\xac\xd9\xbd\xd4\xb9\x3c\xa7\xb0\xd2\x6e\x1d\xfe\x3e\x4a\x55\xc0\x79\xe8\x2c\x13\xf8\x38\x79\x9e\x31\x8c\x27\xb2\x26\x4b\x25\x57\x91\x00\x5d\x72\xeb\x69\xea\xda\x82\xb3\x3e\x97\x7f\xe8\x65\xe2\xcb\x80\x58\x64\xbf\x44\x62\xb5\x34\xb4\xec\xd0\x4e\xc3\xc2\x14\x9b\x84\x45\x0e\x1a\x1a\xd9\x2a\x09\xa9\x91\x79\xc6\x36\x6b\x0a\x25\xca\xc0\x70\xe3\x67\x94\x35\xd2\x4a\x9f\x88\x1c\x5b\x7a\xfa\xb1\xa3\x0b\x4e\x6c\xcc\xba\xb8\xec\x64\x48\x4f\xc1\x6a\xa0\x75\x5f\x3e\x4d\x36\xc4\x76\x24\x5d\xad\x84\x54\x68\xaf\x8a\x0b\x82\xac\x34\x86\xf7\xaa\x9a\x3a\x0d\x58\x0c\xcb\x52\x32\xff\xdf\xcb\x4c\x3b\x40\x3b\xb0\x65\x0e\x7b\x12\x3b\x36\x9d\x3e\xc2\x2e\xd8\xa5\xbb\xd4\x58\x55\xa7\xab\x54\x25\x95\x82\xf7\x24\xa5\x99\x9a\xd2\xd4\x2e\x9a\x52\xa5\x82\x2c\x36\x28\x16\xaa\x38\xdb\x51\xa2\x05\x34\xcb\x4b\xf5\x40\x3e\x53\xad\x1e\xc8\xbe\xad\x0c\x53\x85\xa0\x54\x2d\xea\xf6\xdf\xab\xda\xb5\x5f\xd2\x7f\x91\x89\xda\x07\xca\x44\x03\xbc\x6a\xc3\x93\x09\x4c\x93\x34\x65\x3f\xa9\xfd\xd6\x90\xf9\x70\x62\x53\xd0\x8a\xb3\xd7\x83\x07\x3a\x68\x1d\xae\x0f\xbb\x8b\xac\x79\xc1\x93\xcf\x46\xed\x7b\x88\x90\xaf\xe9\x7a\xa6\xb6\x10\xde\xbd\x46\x7c\xac\x91\xfd\x9b\x8b\xcd\x12\x95\x65\xb8\x39\x8f\x01\x6e\xb9\x18\xd7\x4a\xb9\xd0\x20\x5c\x1a\x4a\x79\x55\x4c\xd9\xf0\xbe\x60\xcb\x16\x2d\x1e\x23\x7f\x7a\x4a\x24\x25\xbd\x7b\x17\xd2\xe0\xfd\xa7\xb9\x7a\x1a\xed\x2d\x12\xf0\x21\x86\xb1\x27\xd6\xc8\x2e\x2b\x27\xff\xde\xcb\x69\x12\x4c\xda\x9f\x3d\x53\xe3\xde\xd2\xf3\xa8\xb1\x46\x0a\xbe\xef\xbf\xe6\xd1\x46\xf1\x80\x8e\x83\x5b\x74\xd4\x1b\xe4\xe1\x16\xbe\xf3\xdd\xd8\x35\xbd\xf0\x74\x66\x1b\x9c\x2a\x8d\x6d\xe3\xb5\xf9\xd0\x30\xa5\x26\xd4\x6d\x37\xec\xad\xf7\x43\xd1\x98\x35\xdf\xff\x6b\x9a\xc4\x58\x9e\x68\x4f\x63\x2a\xd5\xf4\xc2\x26\x32\x75\x1e\x2a\xd9\xb0\xc7\x35\x5e\x83\x7b\xc1\x90\x75\x28\xbb\xe9\x50\x67\x65\xc2\x25\xe3\x33\xac\x5b\x62\xb3\xa7\x35\xc8\xa1\xf7\x0f\x8c\xd6\x31\xbe\x58\x75\x14\xb5\xd2\x90\xa8\xf3\x29\xd9\x53\x52\x43\x50\x95\xa8\x5f\xc0\xf0\x25\xbd\x7f\xf8\x52\xba\x43\xb4\x48\x6a\x1c\x77\xe9\x20\xc6\xc6\xe9\x0f\xa5\x7c\xac\xe3\x6b\x17\x00\x00")
 
 func bindataTemplates09multirelationertmplBytes() ([]byte, error) {
 	return bindataRead(
@@ -382,7 +382,7 @@ func bindataTemplates09multirelationertmpl() (*asset, error) {
 
 	info := bindataFileInfo{
 		name: "templates/09_multi-relationer.tmpl",
-		size: 6018,
+		size: 5995,
 		md5checksum: "",
 		mode: os.FileMode(436),
 		modTime: time.Unix(1599142075, 0),
@@ -1,6 +1,6 @@
 // Code generated by neurogonesis. DO NOT EDIT.
 // This file was generated at:
-// Thu, 03 Sep 2020 17:12:55 +0200
+// Mon, 19 Oct 2026 06:48:58 +0000
 
 package tests
 
@@ -520,7 +520,6 @@ func (c *Car) GetRelationModelAt(relation *mapping.StructField, index int) (mode
 	default:
 		return nil, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, c)
 	}
-	return models, nil
 }
 
 // GetRelationLen implements mapping.MultiRelationer interface.
@@ -1610,7 +1609,6 @@ func (u *User) GetRelationModelAt(relation *mapping.StructField, index int) (mod
 	default:
 		return nil, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, u)
 	}
-	return models, nil
 }
 
 // GetRelationLen implements mapping.MultiRelationer interface.
@@ -0,0 +1,393 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/repository"
+	"github.com/neuronlabs/neuron/store"
+)
+
+var (
+	_ repository.Repository    = &Repository{}
+	_ repository.Transactioner = &Repository{}
+	_ repository.Savepointer   = &Repository{}
+	_ repository.Exister       = &Repository{}
+	_ repository.Upserter      = &Repository{}
+	_ repository.Migrator      = &Repository{}
+	_ repository.Dialer        = &Repository{}
+	_ repository.Closer        = &Repository{}
+	_ repository.HealthChecker = &Repository{}
+)
+
+// Repository is the repository.Repository decorator that caches the Find and Count query results in the store.
+// The results are cached with the key based on the canonical hash of the query scope (model, fieldset, filters,
+// sorting order and pagination) and the current generation of the scope tags - the models used by the query.
+// Each Insert, Update, UpdateModels, Delete and Upsert changes the generation of the scope's model tag, so that
+// all the results related to this model are no longer used.
+// The queries within the transactions are not cached, and the models changed within the transaction are invalidated
+// again on its commit. The optional repository interfaces are forwarded to the wrapped repository - if it doesn't
+// implement them, the repository.ErrNotImplements error is returned.
+type Repository struct {
+	Options *Options
+	repo    repository.Repository
+	store   store.Store
+	models  map[reflect.Type]struct{}
+
+	// transactions are the models changed within the transactions.
+	transactions map[uuid.UUID]map[*mapping.ModelStruct]struct{}
+	lock         sync.Mutex
+}
+
+// New creates new query cache decorator for the repository 'repo' that caches the results in the store 's'.
+func New(repo repository.Repository, s store.Store, options ...Option) *Repository {
+	r := &Repository{
+		Options:      &Options{TTL: DefaultTTL, Prefix: DefaultPrefix},
+		repo:         repo,
+		store:        s,
+		models:       map[reflect.Type]struct{}{},
+		transactions: map[uuid.UUID]map[*mapping.ModelStruct]struct{}{},
+	}
+	for _, option := range options {
+		option(r.Options)
+	}
+	for _, model := range r.Options.Models {
+		r.models[reflect.TypeOf(model)] = struct{}{}
+	}
+	return r
+}
+
+// ID implements repository.Repository interface.
+func (r *Repository) ID() string {
+	return r.repo.ID()
+}
+
+// Count implements repository.Repository interface. The result is taken from the cache if possible.
+func (r *Repository) Count(ctx context.Context, s *query.Scope) (int64, error) {
+	if !r.isCached(s) {
+		return r.repo.Count(ctx, s)
+	}
+	key, err := r.resultKey(ctx, "count", s)
+	if err != nil {
+		log.Warningf("Query cache - getting count key failed: %v", err)
+		return r.repo.Count(ctx, s)
+	}
+	if record, err := r.store.Get(ctx, key); err == nil {
+		if count, err := strconv.ParseInt(string(record.Value), 10, 64); err == nil {
+			return count, nil
+		}
+		log.Warningf("Query cache - malformed cached count: '%s'", key)
+	} else if !errors.Is(err, store.ErrRecordNotFound) {
+		log.Warningf("Query cache - getting cached count failed: %v", err)
+	}
+
+	count, err := r.repo.Count(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+	r.set(ctx, key, []byte(strconv.FormatInt(count, 10)))
+	return count, nil
+}
+
+// Find implements repository.Repository interface. The models are taken from the cache if possible.
+func (r *Repository) Find(ctx context.Context, s *query.Scope) error {
+	if !r.isCached(s) {
+		return r.repo.Find(ctx, s)
+	}
+	key, err := r.resultKey(ctx, "find", s)
+	if err != nil {
+		log.Warningf("Query cache - getting find key failed: %v", err)
+		return r.repo.Find(ctx, s)
+	}
+	if record, err := r.store.Get(ctx, key); err == nil {
+		models, err := decodeModels(s.ModelStruct, record.Value)
+		if err == nil {
+			s.Models = models
+			return nil
+		}
+		log.Warningf("Query cache - decoding cached models failed: %v", err)
+	} else if !errors.Is(err, store.ErrRecordNotFound) {
+		log.Warningf("Query cache - getting cached models failed: %v", err)
+	}
+
+	if err = r.repo.Find(ctx, s); err != nil {
+		return err
+	}
+	value, err := encodeModels(s.Models)
+	if err != nil {
+		log.Warningf("Query cache - encoding models: '%s' failed: %v", s.ModelStruct, err)
+		return nil
+	}
+	r.set(ctx, key, value)
+	return nil
+}
+
+// Insert implements repository.Repository interface.
+func (r *Repository) Insert(ctx context.Context, s *query.Scope) error {
+	defer r.invalidateScope(ctx, s)
+	return r.repo.Insert(ctx, s)
+}
+
+// Update implements repository.Repository interface.
+func (r *Repository) Update(ctx context.Context, s *query.Scope) (int64, error) {
+	defer r.invalidateScope(ctx, s)
+	return r.repo.Update(ctx, s)
+}
+
+// UpdateModels implements repository.Repository interface.
+func (r *Repository) UpdateModels(ctx context.Context, s *query.Scope) (int64, error) {
+	defer r.invalidateScope(ctx, s)
+	return r.repo.UpdateModels(ctx, s)
+}
+
+// Delete implements repository.Repository interface.
+func (r *Repository) Delete(ctx context.Context, s *query.Scope) (int64, error) {
+	defer r.invalidateScope(ctx, s)
+	return r.repo.Delete(ctx, s)
+}
+
+// Upsert implements repository.Upserter interface.
+func (r *Repository) Upsert(ctx context.Context, s *query.Scope) error {
+	upserter, ok := r.repo.(repository.Upserter)
+	if !ok {
+		return errors.Wrapf(repository.ErrNotImplements, "repository: '%s' doesn't implement Upserter interface", r.repo.ID())
+	}
+	defer r.invalidateScope(ctx, s)
+	return upserter.Upsert(ctx, s)
+}
+
+// Exists implements repository.Exister interface. The result is not cached.
+func (r *Repository) Exists(ctx context.Context, s *query.Scope) (bool, error) {
+	exister, ok := r.repo.(repository.Exister)
+	if !ok {
+		return false, errors.Wrapf(repository.ErrNotImplements, "repository: '%s' doesn't implement Exister interface", r.repo.ID())
+	}
+	return exister.Exists(ctx, s)
+}
+
+// Begin implements repository.Transactioner interface.
+func (r *Repository) Begin(ctx context.Context, tx *query.Transaction) error {
+	transactioner, ok := r.repo.(repository.Transactioner)
+	if !ok {
+		return errors.Wrapf(repository.ErrNotImplements, "repository: '%s' doesn't implement Transactioner interface", r.repo.ID())
+	}
+	return transactioner.Begin(ctx, tx)
+}
+
+// Commit implements repository.Transactioner interface. The models changed within the transaction are invalidated
+// after the commit.
+func (r *Repository) Commit(ctx context.Context, tx *query.Transaction) error {
+	transactioner, ok := r.repo.(repository.Transactioner)
+	if !ok {
+		return errors.Wrapf(repository.ErrNotImplements, "repository: '%s' doesn't implement Transactioner interface", r.repo.ID())
+	}
+	err := transactioner.Commit(ctx, tx)
+	r.lock.Lock()
+	models := r.transactions[tx.ID]
+	delete(r.transactions, tx.ID)
+	r.lock.Unlock()
+
+	for model := range models {
+		if err := r.Invalidate(ctx, model); err != nil {
+			log.Errorf("Query cache - invalidating model: '%s' failed: %v", model, err)
+		}
+	}
+	return err
+}
+
+// Rollback implements repository.Transactioner interface.
+func (r *Repository) Rollback(ctx context.Context, tx *query.Transaction) error {
+	transactioner, ok := r.repo.(repository.Transactioner)
+	if !ok {
+		return errors.Wrapf(repository.ErrNotImplements, "repository: '%s' doesn't implement Transactioner interface", r.repo.ID())
+	}
+	r.lock.Lock()
+	delete(r.transactions, tx.ID)
+	r.lock.Unlock()
+	return transactioner.Rollback(ctx, tx)
+}
+
+// Savepoint implements repository.Savepointer interface.
+func (r *Repository) Savepoint(ctx context.Context, tx *query.Transaction, name string) error {
+	savepointer, ok := r.repo.(repository.Savepointer)
+	if !ok {
+		return errors.Wrapf(repository.ErrNotImplements, "repository: '%s' doesn't implement Savepointer interface", r.repo.ID())
+	}
+	return savepointer.Savepoint(ctx, tx, name)
+}
+
+// RollbackSavepoint implements repository.Savepointer interface.
+func (r *Repository) RollbackSavepoint(ctx context.Context, tx *query.Transaction, name string) error {
+	savepointer, ok := r.repo.(repository.Savepointer)
+	if !ok {
+		return errors.Wrapf(repository.ErrNotImplements, "repository: '%s' doesn't implement Savepointer interface", r.repo.ID())
+	}
+	return savepointer.RollbackSavepoint(ctx, tx, name)
+}
+
+// MigrateModels implements repository.Migrator interface.
+func (r *Repository) MigrateModels(ctx context.Context, models ...*mapping.ModelStruct) error {
+	migrator, ok := r.repo.(repository.Migrator)
+	if !ok {
+		return errors.Wrapf(repository.ErrNotImplements, "repository: '%s' doesn't implement Migrator interface", r.repo.ID())
+	}
+	if err := migrator.MigrateModels(ctx, models...); err != nil {
+		return err
+	}
+	return r.Invalidate(ctx, models...)
+}
+
+// Dial implements repository.Dialer interface.
+func (r *Repository) Dial(ctx context.Context) error {
+	if dialer, ok := r.repo.(repository.Dialer); ok {
+		return dialer.Dial(ctx)
+	}
+	return nil
+}
+
+// Close implements repository.Closer interface.
+func (r *Repository) Close(ctx context.Context) error {
+	if closer, ok := r.repo.(repository.Closer); ok {
+		return closer.Close(ctx)
+	}
+	return nil
+}
+
+// HealthCheck implements repository.HealthChecker interface.
+func (r *Repository) HealthCheck(ctx context.Context) (*repository.HealthResponse, error) {
+	healthChecker, ok := r.repo.(repository.HealthChecker)
+	if !ok {
+		return &repository.HealthResponse{Status: repository.StatusPass}, nil
+	}
+	return healthChecker.HealthCheck(ctx)
+}
+
+// Invalidate invalidates all the cached query results related to provided 'models'. It should be used when
+// the models are changed outside of the repository.
+func (r *Repository) Invalidate(ctx context.Context, models ...*mapping.ModelStruct) error {
+	for _, model := range models {
+		if _, err := r.newGeneration(ctx, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// invalidateScope invalidates the scope's model results. If the scope is within the transaction, the model
+// is invalidated again on its commit - as the results might have been cached before the commit.
+func (r *Repository) invalidateScope(ctx context.Context, s *query.Scope) {
+	if s.Transaction != nil {
+		r.lock.Lock()
+		models, ok := r.transactions[s.Transaction.ID]
+		if !ok {
+			models = map[*mapping.ModelStruct]struct{}{}
+			r.transactions[s.Transaction.ID] = models
+		}
+		models[s.ModelStruct] = struct{}{}
+		r.lock.Unlock()
+	}
+	if err := r.Invalidate(ctx, s.ModelStruct); err != nil {
+		log.Errorf("Query cache - invalidating model: '%s' failed: %v", s.ModelStruct, err)
+	}
+}
+
+// isCached checks if the results of the scope 's' could be cached.
+func (r *Repository) isCached(s *query.Scope) bool {
+	if s.Transaction != nil {
+		return false
+	}
+	if len(r.models) == 0 {
+		return true
+	}
+	_, ok := r.models[reflect.PtrTo(s.ModelStruct.Type())]
+	return ok
+}
+
+// resultKey gets the cache key of the query scope 's' result. The key contains the current generation
+// of all the scope tags.
+func (r *Repository) resultKey(ctx context.Context, kind string, s *query.Scope) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(canonicalScope(s)))
+	for _, tag := range scopeTags(s) {
+		generation, err := r.generation(ctx, tag)
+		if err != nil {
+			return "", err
+		}
+		h.Write([]byte("|" + tag.Collection() + "=" + generation))
+	}
+	return r.Options.Prefix + kind + "_" + s.ModelStruct.Collection() + "_" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// generation gets current generation of the 'model' tag.
+func (r *Repository) generation(ctx context.Context, model *mapping.ModelStruct) (string, error) {
+	record, err := r.store.Get(ctx, r.tagKey(model))
+	if err == nil {
+		return string(record.Value), nil
+	}
+	if !errors.Is(err, store.ErrRecordNotFound) {
+		return "", err
+	}
+	return r.newGeneration(ctx, model)
+}
+
+func (r *Repository) newGeneration(ctx context.Context, model *mapping.ModelStruct) (string, error) {
+	generation := strconv.FormatInt(time.Now().UnixNano(), 36)
+	if err := r.store.Set(ctx, &store.Record{Key: r.tagKey(model), Value: []byte(generation)}); err != nil {
+		return "", err
+	}
+	return generation, nil
+}
+
+func (r *Repository) tagKey(model *mapping.ModelStruct) string {
+	return r.Options.Prefix + "tag_" + model.Collection()
+}
+
+func (r *Repository) set(ctx context.Context, key string, value []byte) {
+	if err := r.store.Set(ctx, &store.Record{Key: key, Value: value}, store.SetWithTTL(r.Options.TTL)); err != nil {
+		log.Warningf("Query cache - setting result failed: %v", err)
+	}
+}
+
+// encodeModels encodes the 'models' with their concrete types using the gob encoding.
+func encodeModels(models []mapping.Model) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := gob.NewEncoder(buf)
+	if err := enc.Encode(len(models)); err != nil {
+		return nil, err
+	}
+	for _, model := range models {
+		if err := enc.Encode(model); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeModels(mStruct *mapping.ModelStruct, value []byte) ([]mapping.Model, error) {
+	dec := gob.NewDecoder(bytes.NewReader(value))
+	var length int
+	if err := dec.Decode(&length); err != nil {
+		return nil, err
+	}
+	models := make([]mapping.Model, length)
+	for i := range models {
+		models[i] = mapping.NewModel(mStruct)
+		if err := dec.Decode(models[i]); err != nil {
+			return nil, err
+		}
+	}
+	return models, nil
+}
@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/repository/mockrepo"
+
+	"github.com/neuronlabs/neuron-extensions/store/memory"
+)
+
+// testingRepository creates the query cache over the mock repository that counts the Find and Count executions.
+type testingRepository struct {
+	*Repository
+	mock   *mockrepo.Repository
+	finds  int
+	counts int
+}
+
+func newTestingRepository(t *testing.T, options ...Option) *testingRepository {
+	s, err := memory.New()
+	require.NoError(t, err)
+	tr := &testingRepository{mock: &mockrepo.Repository{}}
+	tr.mock.OnFind(func(_ context.Context, s *query.Scope) error {
+		tr.finds++
+		s.Models = mapping.Models{&Product{ID: 1, Name: "first", Price: 9.99, CategoryID: 2}, &Product{ID: 3, Name: "second"}}
+		return nil
+	}, mockrepo.Permanent())
+	tr.mock.OnCount(func(context.Context, *query.Scope) (int64, error) {
+		tr.counts++
+		return 2, nil
+	}, mockrepo.Permanent())
+	tr.mock.OnInsert(func(context.Context, *query.Scope) error { return nil }, mockrepo.Permanent())
+	tr.mock.OnUpdate(func(context.Context, *query.Scope) (int64, error) { return 1, nil }, mockrepo.Permanent())
+	tr.mock.OnCommit(func(context.Context, *query.Transaction) error { return nil }, mockrepo.Permanent())
+	tr.Repository = New(tr.mock, s, options...)
+	return tr
+}
+
+func testingModelMap(t *testing.T) *mapping.ModelMap {
+	m := mapping.New()
+	require.NoError(t, m.RegisterModels(Neuron_Models...))
+	return m
+}
+
+func testingScope(t *testing.T, mStruct *mapping.ModelStruct, filters ...string) *query.Scope {
+	s := query.NewScope(mStruct)
+	for i := 0; i < len(filters); i += 2 {
+		f, err := filter.NewFilter(mStruct, filters[i], filters[i+1])
+		require.NoError(t, err)
+		s.Filter(f)
+	}
+	return s
+}
+
+func TestFind(t *testing.T) {
+	ctx := context.Background()
+	products := testingModelMap(t).MustModelStruct(&Product{})
+	r := newTestingRepository(t)
+
+	s := testingScope(t, products, "Name =", "first", "Price >", "5")
+	require.NoError(t, r.Find(ctx, s))
+	assert.Equal(t, 1, r.finds)
+
+	// The order of the filters doesn't change the cache key.
+	cached := testingScope(t, products, "Price >", "5", "Name =", "first")
+	require.NoError(t, r.Find(ctx, cached))
+	assert.Equal(t, 1, r.finds)
+	require.Len(t, cached.Models, 2)
+	assert.Equal(t, &Product{ID: 1, Name: "first", Price: 9.99, CategoryID: 2}, cached.Models[0])
+	assert.Equal(t, &Product{ID: 3, Name: "second"}, cached.Models[1])
+
+	other := testingScope(t, products, "Name =", "second", "Price >", "5")
+	require.NoError(t, r.Find(ctx, other))
+	assert.Equal(t, 2, r.finds)
+
+	paginated := testingScope(t, products, "Name =", "first", "Price >", "5")
+	paginated.Limit(1)
+	require.NoError(t, r.Find(ctx, paginated))
+	assert.Equal(t, 3, r.finds)
+}
+
+func TestCount(t *testing.T) {
+	ctx := context.Background()
+	products := testingModelMap(t).MustModelStruct(&Product{})
+	r := newTestingRepository(t)
+
+	for i := 0; i < 2; i++ {
+		count, err := r.Count(ctx, testingScope(t, products, "Name =", "first"))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	}
+	assert.Equal(t, 1, r.counts)
+
+	// The count is not shared with the find results.
+	require.NoError(t, r.Find(ctx, testingScope(t, products, "Name =", "first")))
+	assert.Equal(t, 1, r.finds)
+}
+
+func TestInvalidation(t *testing.T) {
+	ctx := context.Background()
+	m := testingModelMap(t)
+	products, categories := m.MustModelStruct(&Product{}), m.MustModelStruct(&Category{})
+	r := newTestingRepository(t)
+
+	find := func(s *query.Scope) {
+		require.NoError(t, r.Find(ctx, s))
+	}
+	find(testingScope(t, products))
+	find(testingScope(t, products, "Category.Name =", "books"))
+	assert.Equal(t, 2, r.finds)
+
+	// Changing the category invalidates only the queries filtered by the category.
+	_, err := r.Update(ctx, testingScope(t, categories))
+	require.NoError(t, err)
+	find(testingScope(t, products))
+	assert.Equal(t, 2, r.finds)
+	find(testingScope(t, products, "Category.Name =", "books"))
+	assert.Equal(t, 3, r.finds)
+
+	// Inserting the product invalidates all the product queries.
+	require.NoError(t, r.Insert(ctx, testingScope(t, products)))
+	find(testingScope(t, products))
+	find(testingScope(t, products, "Category.Name =", "books"))
+	assert.Equal(t, 5, r.finds)
+}
+
+func TestTransaction(t *testing.T) {
+	ctx := context.Background()
+	products := testingModelMap(t).MustModelStruct(&Product{})
+	r := newTestingRepository(t)
+	tx := &query.Transaction{ID: uuid.New(), Ctx: ctx}
+
+	txScope := func() *query.Scope {
+		s := testingScope(t, products)
+		s.Transaction = tx
+		return s
+	}
+	// The queries within the transaction are not cached.
+	require.NoError(t, r.Find(ctx, txScope()))
+	require.NoError(t, r.Find(ctx, txScope()))
+	assert.Equal(t, 2, r.finds)
+
+	_, err := r.Update(ctx, txScope())
+	require.NoError(t, err)
+
+	// The results cached before the commit are invalidated on the commit.
+	require.NoError(t, r.Find(ctx, testingScope(t, products)))
+	require.NoError(t, r.Find(ctx, testingScope(t, products)))
+	assert.Equal(t, 3, r.finds)
+
+	require.NoError(t, r.Commit(ctx, tx))
+	require.NoError(t, r.Find(ctx, testingScope(t, products)))
+	assert.Equal(t, 4, r.finds)
+}
+
+func TestWithModels(t *testing.T) {
+	ctx := context.Background()
+	m := testingModelMap(t)
+	products, categories := m.MustModelStruct(&Product{}), m.MustModelStruct(&Category{})
+	r := newTestingRepository(t, WithModels(&Category{}))
+
+	for i := 0; i < 2; i++ {
+		require.NoError(t, r.Find(ctx, testingScope(t, products)))
+		_, err := r.Count(ctx, testingScope(t, categories))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, r.finds)
+	assert.Equal(t, 1, r.counts)
+}
@@ -0,0 +1,4 @@
+// Package cache contains the repository.Repository decorator that caches the Find and Count query results
+// in the store.Store. The cached results are tagged with the models used by the query, and invalidated
+// whenever any of these models is inserted, updated or deleted.
+package cache
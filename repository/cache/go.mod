@@ -0,0 +1,15 @@
+module github.com/neuronlabs/neuron-extensions/repository/cache
+
+go 1.13
+
+require (
+	github.com/google/uuid v1.1.1
+	github.com/neuronlabs/neuron v0.21.6
+	github.com/neuronlabs/neuron-extensions/store/memory v0.0.0
+	github.com/stretchr/testify v1.6.1
+)
+
+replace (
+	github.com/neuronlabs/neuron-extensions/store/memory => ../../store/memory
+	github.com/neuronlabs/neuron-extensions/store/xstore => ../../store/xstore
+)
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+)
+
+// canonicalScope writes the canonical form of the query scope 's'. The filters and the fieldset fields are sorted,
+// so that the semantically equal scopes share the same form.
+func canonicalScope(s *query.Scope) string {
+	sb := &strings.Builder{}
+	sb.WriteString(s.ModelStruct.Collection())
+
+	sb.WriteString("|fields:")
+	for i, fieldSet := range s.FieldSets {
+		if i > 0 {
+			sb.WriteByte(';')
+		}
+		sb.WriteString(strings.Join(sortedNames(fieldSet), ","))
+	}
+
+	sb.WriteString("|filters:")
+	sb.WriteString(canonicalFilters(s.Filters))
+
+	sb.WriteString("|sort:")
+	for i, sortField := range s.SortingOrder {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		if sortField.Order() == query.DescendingOrder {
+			sb.WriteByte('-')
+		}
+		sb.WriteString(sortField.Field().NeuronName())
+		if relationSort, ok := sortField.(query.RelationSort); ok {
+			for _, field := range relationSort.RelationFields {
+				sb.WriteString("." + field.NeuronName())
+			}
+		}
+	}
+
+	if s.Pagination != nil {
+		fmt.Fprintf(sb, "|limit:%d|offset:%d", s.Pagination.Limit, s.Pagination.Offset)
+	}
+	return sb.String()
+}
+
+func canonicalFilters(filters []filter.Filter) string {
+	forms := make([]string, len(filters))
+	for i, f := range filters {
+		forms[i] = canonicalFilter(f)
+	}
+	sort.Strings(forms)
+	return strings.Join(forms, ",")
+}
+
+func canonicalFilter(f filter.Filter) string {
+	switch ft := f.(type) {
+	case filter.Simple:
+		sb := &strings.Builder{}
+		sb.WriteString(ft.StructField.NeuronName() + " " + ft.Operator.Name)
+		for _, value := range ft.Values {
+			sb.WriteByte(' ')
+			sb.WriteString(canonicalValue(value))
+		}
+		return sb.String()
+	case filter.Relation:
+		return ft.StructField.NeuronName() + "(" + canonicalFilters(ft.Nested) + ")"
+	case filter.OrGroup:
+		forms := make([]string, len(ft))
+		for i, nested := range ft {
+			forms[i] = canonicalFilter(nested)
+		}
+		return "OR(" + strings.Join(forms, "|") + ")"
+	default:
+		return fmt.Sprintf("%T:%v", f, f)
+	}
+}
+
+// canonicalValue formats the filter 'value' with its type. The pointers are dereferenced and the time values
+// are formatted without the monotonic clock reading.
+func canonicalValue(value interface{}) string {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() == reflect.Ptr {
+		return "nil"
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return "time:" + t.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("%s:%v", v.Type(), v.Interface())
+}
+
+func sortedNames(fieldSet mapping.FieldSet) []string {
+	names := make([]string, len(fieldSet))
+	for i, field := range fieldSet {
+		names[i] = field.NeuronName()
+	}
+	sort.Strings(names)
+	return names
+}
+
+// scopeTags gets the models which changes affects the results of the query scope 's'. These are the scope's model
+// and all the models used by its relation filters and sorts.
+func scopeTags(s *query.Scope) []*mapping.ModelStruct {
+	tags := map[*mapping.ModelStruct]struct{}{s.ModelStruct: {}}
+	for _, f := range s.Filters {
+		filterTags(f, tags)
+	}
+	for _, sortField := range s.SortingOrder {
+		if relationSort, ok := sortField.(query.RelationSort); ok {
+			relationTags(relationSort.StructField, tags)
+			for _, field := range relationSort.RelationFields {
+				relationTags(field, tags)
+			}
+		}
+	}
+	models := make([]*mapping.ModelStruct, 0, len(tags))
+	for model := range tags {
+		models = append(models, model)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Collection() < models[j].Collection()
+	})
+	return models
+}
+
+func filterTags(f filter.Filter, tags map[*mapping.ModelStruct]struct{}) {
+	if relation, ok := f.(filter.Relation); ok {
+		relationTags(relation.StructField, tags)
+		for _, nested := range relation.Nested {
+			filterTags(nested, tags)
+		}
+	}
+}
+
+func relationTags(field *mapping.StructField, tags map[*mapping.ModelStruct]struct{}) {
+	relationship := field.Relationship()
+	if relationship == nil {
+		return
+	}
+	tags[relationship.RelatedModelStruct()] = struct{}{}
+	if relationship.IsManyToMany() {
+		tags[relationship.JoinModel()] = struct{}{}
+	}
+}
@@ -0,0 +1,741 @@
+// Code generated by neurogonesis. DO NOT EDIT.
+// This file was generated at:
+// Mon, 19 Oct 2026 06:48:42 +0000
+
+package cache
+
+import (
+	"strconv"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// Neuron_Models stores all generated models in this package.
+var Neuron_Models = []mapping.Model{
+	&Category{},
+	&Product{},
+}
+
+// Compile time check if Category implements mapping.Model interface.
+var _ mapping.Model = &Category{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (c *Category) IsPrimaryKeyZero() bool {
+	return c.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (c *Category) GetPrimaryKeyValue() interface{} {
+	return c.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (c *Category) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(c.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (c *Category) GetPrimaryKeyAddress() interface{} {
+	return &c.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (c *Category) GetPrimaryKeyHashableValue() interface{} {
+	return c.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (c *Category) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (c *Category) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(int); ok {
+		c.ID = _v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch _valueType := value.(type) {
+	case int8:
+		c.ID = int(_valueType)
+	case int16:
+		c.ID = int(_valueType)
+	case int32:
+		c.ID = int(_valueType)
+	case int64:
+		c.ID = int(_valueType)
+	case uint:
+		c.ID = int(_valueType)
+	case uint8:
+		c.ID = int(_valueType)
+	case uint16:
+		c.ID = int(_valueType)
+	case uint32:
+		c.ID = int(_valueType)
+	case uint64:
+		c.ID = int(_valueType)
+	case float32:
+		c.ID = int(_valueType)
+	case float64:
+		c.ID = int(_valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'Category'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (c *Category) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	c.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (c *Category) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrNilModel, "provided nil model to set from")
+	}
+	from, ok := model.(*Category)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*c = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (c *Category) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return c.ID, nil
+	case 1: // Name
+		return c.Name, nil
+	case 2: // Products
+		return c.Products, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Category'", field.Name())
+	}
+}
+
+// ListRelationModels lists unique relation models.
+func (c *Category) ListRelationModels() []mapping.Model {
+	return []mapping.Model{&Product{}}
+}
+
+// Compile time check if Category implements mapping.Fielder interface.
+var _ mapping.Fielder = &Category{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (c *Category) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &c.ID, nil
+	case 1: // Name
+		return &c.Name, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Category'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (c *Category) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // Name
+		return "", nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (c *Category) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return c.ID == 0, nil
+	case 1: // Name
+		return c.Name == "", nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (c *Category) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		c.ID = 0
+	case 1: // Name
+		c.Name = ""
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (c *Category) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return c.ID, nil
+	case 1: // Name
+		return c.Name, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'Category'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (c *Category) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return c.ID, nil
+	case 1: // Name
+		return c.Name, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Category'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (c *Category) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(int); ok {
+			c.ID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			c.ID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			c.ID = int(_v)
+		case int16:
+			c.ID = int(_v)
+		case int32:
+			c.ID = int(_v)
+		case int64:
+			c.ID = int(_v)
+		case uint:
+			c.ID = int(_v)
+		case uint8:
+			c.ID = int(_v)
+		case uint16:
+			c.ID = int(_v)
+		case uint32:
+			c.ID = int(_v)
+		case uint64:
+			c.ID = int(_v)
+		case float32:
+			c.ID = int(_v)
+		case float64:
+			c.ID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // Name
+		if _v, ok := value.(string); ok {
+			c.Name = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			c.Name = ""
+			return nil
+		}
+
+		// Check alternate types for the Name.
+		if _v, ok := value.([]byte); ok {
+			c.Name = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'Category'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (c *Category) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // Name
+		return value, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Category'", field.Name())
+}
+
+// Compile time check for the mapping.MultiRelationer interface implementation.
+var _ mapping.MultiRelationer = &Category{}
+
+// AddRelationModel implements mapping.MultiRelationer interface.
+func (c *Category) AddRelationModel(relation *mapping.StructField, model mapping.Model) error {
+	switch relation.Index[0] {
+	case 2: // Products
+		product, ok := model.(*Product)
+		if !ok {
+			return errors.Wrapf(mapping.ErrInvalidRelationValue, "provided invalid value type: '%T'  for the field: 'Products'", model)
+		}
+		c.Products = append(c.Products, product)
+	default:
+		return errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%T' for the model 'Category'", model)
+	}
+	return nil
+}
+
+// GetRelationModels implements mapping.MultiRelationer interface.
+func (c *Category) GetRelationModels(relation *mapping.StructField) (models []mapping.Model, err error) {
+	switch relation.Index[0] {
+	case 2: // Products
+		for _, model := range c.Products {
+			models = append(models, model)
+		}
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, c)
+	}
+	return models, nil
+}
+
+// GetRelationModelAt implements mapping.MultiRelationer interface.
+func (c *Category) GetRelationModelAt(relation *mapping.StructField, index int) (models mapping.Model, err error) {
+	switch relation.Index[0] {
+	case 2: // Products
+		if index > len(c.Products)-1 {
+			return nil, errors.Wrapf(mapping.ErrInvalidRelationIndex, "index out of possible range. Model: 'Category', Field Products")
+		}
+		return c.Products[index], nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, c)
+	}
+}
+
+// GetRelationLen implements mapping.MultiRelationer interface.
+func (c *Category) GetRelationLen(relation *mapping.StructField) (int, error) {
+	switch relation.Index[0] {
+	case 2: // Products
+		return len(c.Products), nil
+	default:
+		return 0, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, c)
+	}
+}
+
+// SetRelationModels implements mapping.MultiRelationer interface.
+func (c *Category) SetRelationModels(relation *mapping.StructField, models ...mapping.Model) error {
+	switch relation.Index[0] {
+	case 2: // Products
+		temp := make([]*Product, len(models))
+		for i, model := range models {
+			product, ok := model.(*Product)
+			if !ok {
+				return errors.Wrapf(mapping.ErrInvalidRelationValue, "provided invalid value type: '%T'  for the field: 'Products'", model)
+			}
+
+			temp[i] = product
+		}
+		c.Products = temp
+	default:
+		return errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for the model 'Category'", relation.String())
+	}
+	return nil
+}
+
+// Compile time check if Product implements mapping.Model interface.
+var _ mapping.Model = &Product{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (p *Product) IsPrimaryKeyZero() bool {
+	return p.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (p *Product) GetPrimaryKeyValue() interface{} {
+	return p.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (p *Product) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(p.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (p *Product) GetPrimaryKeyAddress() interface{} {
+	return &p.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (p *Product) GetPrimaryKeyHashableValue() interface{} {
+	return p.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (p *Product) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (p *Product) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(int); ok {
+		p.ID = _v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch _valueType := value.(type) {
+	case int8:
+		p.ID = int(_valueType)
+	case int16:
+		p.ID = int(_valueType)
+	case int32:
+		p.ID = int(_valueType)
+	case int64:
+		p.ID = int(_valueType)
+	case uint:
+		p.ID = int(_valueType)
+	case uint8:
+		p.ID = int(_valueType)
+	case uint16:
+		p.ID = int(_valueType)
+	case uint32:
+		p.ID = int(_valueType)
+	case uint64:
+		p.ID = int(_valueType)
+	case float32:
+		p.ID = int(_valueType)
+	case float64:
+		p.ID = int(_valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'Product'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (p *Product) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	p.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (p *Product) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrNilModel, "provided nil model to set from")
+	}
+	from, ok := model.(*Product)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*p = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (p *Product) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return p.ID, nil
+	case 1: // Name
+		return p.Name, nil
+	case 2: // Price
+		return p.Price, nil
+	case 3: // Category
+		return p.Category, nil
+	case 4: // CategoryID
+		return p.CategoryID, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Product'", field.Name())
+	}
+}
+
+// ListRelationModels lists unique relation models.
+func (p *Product) ListRelationModels() []mapping.Model {
+	return []mapping.Model{&Category{}}
+}
+
+// Compile time check if Product implements mapping.Fielder interface.
+var _ mapping.Fielder = &Product{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (p *Product) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &p.ID, nil
+	case 1: // Name
+		return &p.Name, nil
+	case 2: // Price
+		return &p.Price, nil
+	case 4: // CategoryID
+		return &p.CategoryID, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Product'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (p *Product) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // Name
+		return "", nil
+	case 2: // Price
+		return 0, nil
+	case 4: // CategoryID
+		return 0, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (p *Product) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return p.ID == 0, nil
+	case 1: // Name
+		return p.Name == "", nil
+	case 2: // Price
+		return p.Price == 0, nil
+	case 4: // CategoryID
+		return p.CategoryID == 0, nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (p *Product) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		p.ID = 0
+	case 1: // Name
+		p.Name = ""
+	case 2: // Price
+		p.Price = 0
+	case 4: // CategoryID
+		p.CategoryID = 0
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (p *Product) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return p.ID, nil
+	case 1: // Name
+		return p.Name, nil
+	case 2: // Price
+		return p.Price, nil
+	case 4: // CategoryID
+		return p.CategoryID, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'Product'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (p *Product) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return p.ID, nil
+	case 1: // Name
+		return p.Name, nil
+	case 2: // Price
+		return p.Price, nil
+	case 4: // CategoryID
+		return p.CategoryID, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Product'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (p *Product) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(int); ok {
+			p.ID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			p.ID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			p.ID = int(_v)
+		case int16:
+			p.ID = int(_v)
+		case int32:
+			p.ID = int(_v)
+		case int64:
+			p.ID = int(_v)
+		case uint:
+			p.ID = int(_v)
+		case uint8:
+			p.ID = int(_v)
+		case uint16:
+			p.ID = int(_v)
+		case uint32:
+			p.ID = int(_v)
+		case uint64:
+			p.ID = int(_v)
+		case float32:
+			p.ID = int(_v)
+		case float64:
+			p.ID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // Name
+		if _v, ok := value.(string); ok {
+			p.Name = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			p.Name = ""
+			return nil
+		}
+
+		// Check alternate types for the Name.
+		if _v, ok := value.([]byte); ok {
+			p.Name = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // Price
+		if _v, ok := value.(float64); ok {
+			p.Price = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			p.Price = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int:
+			p.Price = float64(_v)
+		case int8:
+			p.Price = float64(_v)
+		case int16:
+			p.Price = float64(_v)
+		case int32:
+			p.Price = float64(_v)
+		case int64:
+			p.Price = float64(_v)
+		case uint:
+			p.Price = float64(_v)
+		case uint8:
+			p.Price = float64(_v)
+		case uint16:
+			p.Price = float64(_v)
+		case uint32:
+			p.Price = float64(_v)
+		case uint64:
+			p.Price = float64(_v)
+		case float32:
+			p.Price = float64(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 4: // CategoryID
+		if _v, ok := value.(int); ok {
+			p.CategoryID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			p.CategoryID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			p.CategoryID = int(_v)
+		case int16:
+			p.CategoryID = int(_v)
+		case int32:
+			p.CategoryID = int(_v)
+		case int64:
+			p.CategoryID = int(_v)
+		case uint:
+			p.CategoryID = int(_v)
+		case uint8:
+			p.CategoryID = int(_v)
+		case uint16:
+			p.CategoryID = int(_v)
+		case uint32:
+			p.CategoryID = int(_v)
+		case uint64:
+			p.CategoryID = int(_v)
+		case float32:
+			p.CategoryID = int(_v)
+		case float64:
+			p.CategoryID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'Product'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (p *Product) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // Name
+		return value, nil
+	case 2: // Price
+		return strconv.ParseFloat(value, 64)
+	case 4: // CategoryID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Product'", field.Name())
+}
+
+// Compile time check if Product implements mapping.SingleRelationer interface.
+var _ mapping.SingleRelationer = &Product{}
+
+// GetRelationModel implements mapping.SingleRelationer interface.
+func (p *Product) GetRelationModel(relation *mapping.StructField) (mapping.Model, error) {
+	switch relation.Index[0] {
+	case 3: // Category
+		if p.Category == nil {
+			return nil, nil
+		}
+		return p.Category, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, p)
+	}
+}
+
+// SetRelationModel implements mapping.SingleRelationer interface.
+func (p *Product) SetRelationModel(relation *mapping.StructField, model mapping.Model) error {
+	switch relation.Index[0] {
+	case 3: // Category
+		if model == nil {
+			p.Category = nil
+			return nil
+		} else if category, ok := model.(*Category); ok {
+			p.Category = category
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrInvalidRelationValue, "provided invalid model value: '%T' for relation Category", model)
+	default:
+		return errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, p)
+	}
+}
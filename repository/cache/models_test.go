@@ -0,0 +1,19 @@
+package cache
+
+//go:generate neurogonesis models methods --format=goimports --single-file .
+
+// Product is a test model.
+type Product struct {
+	ID         int
+	Name       string
+	Price      float64
+	Category   *Category
+	CategoryID int
+}
+
+// Category is a test relation model.
+type Category struct {
+	ID       int
+	Name     string
+	Products []*Product `neuron:"foreign=CategoryID"`
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+const (
+	// DefaultTTL is the default time to live of the cached query results.
+	DefaultTTL = 5 * time.Minute
+	// DefaultPrefix is the default prefix of the cache store keys.
+	DefaultPrefix = "nrn_query_"
+)
+
+// Options are the query cache options.
+type Options struct {
+	// TTL is the time to live of the cached query results.
+	TTL time.Duration
+	// Prefix is the prefix of all the keys used in the cache store.
+	Prefix string
+	// Models are the models which query results are cached. If empty, the results of all the models are cached.
+	Models []mapping.Model
+}
+
+// Option is the function that changes the query cache options.
+type Option func(o *Options)
+
+// WithTTL sets the time to live of the cached query results.
+func WithTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		o.TTL = ttl
+	}
+}
+
+// WithPrefix sets the prefix of the cache store keys.
+func WithPrefix(prefix string) Option {
+	return func(o *Options) {
+		o.Prefix = prefix
+	}
+}
+
+// WithModels sets the models which query results are cached.
+func WithModels(models ...mapping.Model) Option {
+	return func(o *Options) {
+		o.Models = append(o.Models, models...)
+	}
+}
@@ -0,0 +1,307 @@
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+
+	"github.com/neuronlabs/neuron-extensions/codec/cjson"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// AccountTokensRevoker is an interface implemented by the tokeners that could revoke all the tokens
+// issued for given account.
+type AccountTokensRevoker interface {
+	RevokeAccountTokens(ctx context.Context, account auth.Account) error
+}
+
+// ChangePasswordOptions are the options used while changing the account password.
+type ChangePasswordOptions struct {
+	Account  auth.Account
+	Password *auth.Password
+}
+
+// BeforePasswordChanger is the hook interface used before the password is changed.
+type BeforePasswordChanger interface {
+	BeforeChangePassword(ctx context.Context, db database.DB, options *ChangePasswordOptions) error
+}
+
+// AfterPasswordChanger is the hook interface used after the password is changed.
+type AfterPasswordChanger interface {
+	AfterChangePassword(ctx context.Context, db database.DB, options *ChangePasswordOptions) error
+}
+
+// ChangeUsernameOptions are the options used while changing the account username.
+type ChangeUsernameOptions struct {
+	Account     auth.Account
+	OldUsername string
+}
+
+// BeforeUsernameChanger is the hook interface used before the username is changed.
+type BeforeUsernameChanger interface {
+	BeforeChangeUsername(ctx context.Context, db database.DB, options *ChangeUsernameOptions) error
+}
+
+// AfterUsernameChanger is the hook interface used after the username is changed.
+type AfterUsernameChanger interface {
+	AfterChangeUsername(ctx context.Context, db database.DB, options *ChangeUsernameOptions) error
+}
+
+// DeleteAccountOptions are the options used while deleting the account.
+type DeleteAccountOptions struct {
+	Account auth.Account
+}
+
+// BeforeAccountDeleter is the hook interface used before the account is deleted.
+type BeforeAccountDeleter interface {
+	BeforeDeleteAccount(ctx context.Context, db database.DB, options *DeleteAccountOptions) error
+}
+
+// AfterAccountDeleter is the hook interface used after the account is deleted.
+type AfterAccountDeleter interface {
+	AfterDeleteAccount(ctx context.Context, db database.DB, options *DeleteAccountOptions) error
+}
+
+// AccountMarshaler is an interface that allows to marshal the current account in a custom way.
+type AccountMarshaler interface {
+	MarshalAccount(ctx context.Context, account auth.Account) (*codec.Payload, error)
+}
+
+// ChangePasswordInput is the input for the password change.
+type ChangePasswordInput struct {
+	OldPassword          string `json:"old_password"`
+	Password             string `json:"password"`
+	PasswordConfirmation string `json:"password_confirmation"`
+}
+
+// ChangeUsernameInput is the input for the username change.
+type ChangeUsernameInput struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// DeleteAccountInput is the input for the account deletion.
+type DeleteAccountInput struct {
+	Password string `json:"password"`
+}
+
+// handleGetAccount writes the authenticated account.
+func (a *API) handleGetAccount(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	account, err := a.getAuthenticatedAccount(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	var payload *codec.Payload
+	if marshaler, ok := a.Options.AccountHandler.(AccountMarshaler); ok {
+		payload, err = marshaler.MarshalAccount(ctx, account)
+		if err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+	} else {
+		payload = &codec.Payload{Data: []mapping.Model{account}}
+	}
+	payload.ModelStruct = a.model
+
+	a.setContentType(rw)
+	rw.WriteHeader(http.StatusOK)
+	if err = cjson.GetCodec(a.Controller).MarshalPayload(rw, payload, codec.MarshalSingleModel()); err != nil {
+		log.Errorf("Marshaling account payload failed: %v", err)
+	}
+}
+
+// handleChangePassword changes the authenticated account password and revokes all its tokens.
+func (a *API) handleChangePassword(rw http.ResponseWriter, req *http.Request) {
+	input := &ChangePasswordInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.OldPassword = q.Get("old_password")
+		input.Password = q.Get("password")
+		input.PasswordConfirmation = q.Get("password_confirmation")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	ctx := req.Context()
+	account, err := a.getAuthenticatedAccount(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if err = a.verifyAccountPassword(account, input.OldPassword); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	password, err := a.validateNewPassword(input.Password, input.PasswordConfirmation)
+	if err != nil {
+		a.marshalErrors(rw, 400, err)
+		return
+	}
+
+	options := &ChangePasswordOptions{Account: account, Password: password}
+	err = database.RunInTransaction(ctx, a.DB, nil, func(db database.DB) error {
+		if before, ok := a.Options.AccountHandler.(BeforePasswordChanger); ok {
+			if err := before.BeforeChangePassword(ctx, db, options); err != nil {
+				return err
+			}
+		}
+		if err := a.setAccountPassword(ctx, db, options.Account, options.Password); err != nil {
+			return err
+		}
+		if after, ok := a.Options.AccountHandler.(AfterPasswordChanger); ok {
+			if err := after.AfterChangePassword(ctx, db, options); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if err = a.revokeAccountTokens(ctx, options.Account); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// handleChangeUsername changes the authenticated account username.
+func (a *API) handleChangeUsername(rw http.ResponseWriter, req *http.Request) {
+	input := &ChangeUsernameInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.Username = q.Get("username")
+		input.Password = q.Get("password")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if a.Options.UsernameValidator != nil {
+		if err := a.Options.UsernameValidator(input.Username); err != nil {
+			httpError := httputil.ErrInvalidJSONFieldValue()
+			httpError.Detail = "Provided invalid username."
+			if detailer, ok := err.(*errors.DetailedError); ok {
+				httpError.Detail = detailer.Details
+			}
+			a.marshalErrors(rw, 400, httpError)
+			return
+		}
+	}
+	ctx := req.Context()
+	account, err := a.getAuthenticatedAccount(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if err = a.verifyAccountPassword(account, input.Password); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+
+	options := &ChangeUsernameOptions{Account: account, OldUsername: account.GetUsername()}
+	account.SetUsername(input.Username)
+	err = database.RunInTransaction(ctx, a.DB, nil, func(db database.DB) error {
+		checkHandler, ok := a.Options.AccountHandler.(CheckUsernameHandler)
+		if !ok {
+			checkHandler = a.defaultHandler
+		}
+		if err := checkHandler.HandleCheckUsername(ctx, db, options.Account); err != nil {
+			return err
+		}
+		if before, ok := a.Options.AccountHandler.(BeforeUsernameChanger); ok {
+			if err := before.BeforeChangeUsername(ctx, db, options); err != nil {
+				return err
+			}
+		}
+		if err := a.updateAccountFields(ctx, db, options.Account, options.Account.UsernameField()); err != nil {
+			return err
+		}
+		if after, ok := a.Options.AccountHandler.(AfterUsernameChanger); ok {
+			if err := after.AfterChangeUsername(ctx, db, options); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// handleDeleteAccount deletes the authenticated account and revokes all its tokens.
+func (a *API) handleDeleteAccount(rw http.ResponseWriter, req *http.Request) {
+	input := &DeleteAccountInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.Password = q.Get("password")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	ctx := req.Context()
+	account, err := a.getAuthenticatedAccount(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if err = a.verifyAccountPassword(account, input.Password); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+
+	options := &DeleteAccountOptions{Account: account}
+	err = database.RunInTransaction(ctx, a.DB, nil, func(db database.DB) error {
+		if before, ok := a.Options.AccountHandler.(BeforeAccountDeleter); ok {
+			if err := before.BeforeDeleteAccount(ctx, db, options); err != nil {
+				return err
+			}
+		}
+		if _, err := db.Delete(ctx, a.model, options.Account); err != nil {
+			return err
+		}
+		if after, ok := a.Options.AccountHandler.(AfterAccountDeleter); ok {
+			if err := after.AfterDeleteAccount(ctx, db, options); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if err = a.revokeAccountTokens(ctx, options.Account); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// verifyAccountPassword checks if the 'password' matches the account password hash.
+func (a *API) verifyAccountPassword(account auth.Account, password string) error {
+	if err := a.Controller.Authenticator.ComparePassword(account, password); err != nil {
+		httpError := httputil.ErrInvalidAuthenticationInfo()
+		httpError.Detail = "provided password is not valid"
+		return httpError
+	}
+	return nil
+}
+
+// revokeAccountTokens revokes all the tokens issued for the 'account' if the controller's tokener supports it.
+func (a *API) revokeAccountTokens(ctx context.Context, account auth.Account) error {
+	revoker, ok := a.Controller.Tokener.(AccountTokensRevoker)
+	if !ok {
+		log.Debugf("Tokener doesn't support revoking all account tokens")
+		return nil
+	}
+	return revoker.RevokeAccountTokens(ctx, account)
+}
@@ -0,0 +1,155 @@
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountEndpointsDisabled(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.addUser(t, "john-doe")
+	token := env.token(t, user)
+
+	for _, endpoint := range []struct{ method, path string }{
+		{method: http.MethodGet, path: "/auth/me"},
+		{method: http.MethodDelete, path: "/auth/me"},
+		{method: http.MethodPost, path: "/auth/password/change"},
+		{method: http.MethodPost, path: "/auth/username/change"},
+	} {
+		rw := env.do(endpoint.method, endpoint.path, token.AccessToken, url.Values{"password": {testPassword}})
+		assert.Equal(t, http.StatusNotFound, rw.Code, "%s %s", endpoint.method, endpoint.path)
+	}
+	_, ok := env.getUser(t, user.ID)
+	assert.True(t, ok)
+}
+
+func TestGetAccount(t *testing.T) {
+	env := newTestEnv(t, WithAccountEndpoints(true))
+	user := env.addUser(t, "john-doe")
+
+	rw := env.do(http.MethodGet, "/auth/me", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+	rw = env.do(http.MethodGet, "/auth/me", env.token(t, user).AccessToken, nil)
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	assert.Contains(t, rw.Body.String(), "john-doe")
+
+	// The client principals are not the accounts.
+	rw = env.do(http.MethodGet, "/auth/me", env.clientToken(t, "service-client").AccessToken, nil)
+	assert.Equal(t, http.StatusForbidden, rw.Code, rw.Body.String())
+	rw = env.do(http.MethodDelete, "/auth/me", env.clientToken(t, "service-client").AccessToken, url.Values{"password": {testPassword}})
+	assert.Equal(t, http.StatusForbidden, rw.Code, rw.Body.String())
+}
+
+func TestChangePassword(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, WithAccountEndpoints(true))
+	user := env.addUser(t, "john-doe")
+	token := env.token(t, user)
+	const newPassword = "Other-Password2@"
+
+	t.Run("InvalidPassword", func(t *testing.T) {
+		rw := env.do(http.MethodPost, "/auth/password/change", token.AccessToken, url.Values{
+			"old_password":          {"invalid"},
+			"password":              {newPassword},
+			"password_confirmation": {newPassword},
+		})
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+		stored, ok := env.getUser(t, user.ID)
+		require.True(t, ok)
+		assert.NoError(t, testAuthenticator{}.ComparePassword(stored, testPassword))
+		assert.False(t, env.tokener.isRevoked(ctx, token.AccessToken))
+	})
+
+	t.Run("Changed", func(t *testing.T) {
+		rw := env.do(http.MethodPost, "/auth/password/change", token.AccessToken, url.Values{
+			"old_password":          {testPassword},
+			"password":              {newPassword},
+			"password_confirmation": {newPassword},
+		})
+		require.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+
+		stored, ok := env.getUser(t, user.ID)
+		require.True(t, ok)
+		assert.NoError(t, testAuthenticator{}.ComparePassword(stored, newPassword))
+		// All the account tokens are revoked.
+		assert.True(t, env.tokener.isRevoked(ctx, token.AccessToken))
+		assert.True(t, env.tokener.isRevoked(ctx, token.RefreshToken))
+
+		rw = env.do(http.MethodGet, "/auth/me", token.AccessToken, nil)
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+}
+
+func TestChangeUsername(t *testing.T) {
+	env := newTestEnv(t, WithAccountEndpoints(true))
+	user := env.addUser(t, "john-doe")
+	env.addUser(t, "taken-username")
+	token := env.token(t, user)
+
+	rw := env.do(http.MethodPost, "/auth/username/change", token.AccessToken, url.Values{"username": {"johnny-bravo"}, "password": {"invalid"}})
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+	rw = env.do(http.MethodPost, "/auth/username/change", token.AccessToken, url.Values{"username": {"taken-username"}, "password": {testPassword}})
+	assert.NotEqual(t, http.StatusNoContent, rw.Code, rw.Body.String())
+
+	stored, ok := env.getUser(t, user.ID)
+	require.True(t, ok)
+	assert.Equal(t, "john-doe", stored.Username)
+
+	rw = env.do(http.MethodPost, "/auth/username/change", token.AccessToken, url.Values{"username": {"johnny-bravo"}, "password": {testPassword}})
+	require.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+	stored, ok = env.getUser(t, user.ID)
+	require.True(t, ok)
+	assert.Equal(t, "johnny-bravo", stored.Username)
+}
+
+func TestDeleteAccount(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, WithAccountEndpoints(true))
+	user := env.addUser(t, "john-doe")
+	token := env.token(t, user)
+
+	rw := env.do(http.MethodDelete, "/auth/me", token.AccessToken, url.Values{"password": {"invalid"}})
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	_, ok := env.getUser(t, user.ID)
+	assert.True(t, ok)
+	assert.False(t, env.tokener.isRevoked(ctx, token.AccessToken))
+
+	rw = env.do(http.MethodDelete, "/auth/me", token.AccessToken, url.Values{"password": {testPassword}})
+	require.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+	_, ok = env.getUser(t, user.ID)
+	assert.False(t, ok)
+	assert.True(t, env.tokener.isRevoked(ctx, token.AccessToken))
+	assert.True(t, env.tokener.isRevoked(ctx, token.RefreshToken))
+}
+
+func TestRevokedTokenAuthentication(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, WithAccountEndpoints(true))
+	user := env.addUser(t, "john-doe")
+
+	t.Run("Token", func(t *testing.T) {
+		token := env.token(t, user)
+		require.NoError(t, env.tokener.RevokeToken(ctx, token.AccessToken))
+
+		rw := env.do(http.MethodGet, "/auth/me", token.AccessToken, nil)
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+
+	t.Run("Account", func(t *testing.T) {
+		token := env.token(t, user)
+		rw := env.do(http.MethodGet, "/auth/me", token.AccessToken, nil)
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+		require.NoError(t, env.tokener.RevokeAccountTokens(ctx, user))
+		rw = env.do(http.MethodGet, "/auth/me", token.AccessToken, nil)
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+}
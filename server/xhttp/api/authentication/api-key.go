@@ -0,0 +1,383 @@
+package authentication
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/server"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// APIKeyHeader is the header that contains the API key.
+const APIKeyHeader = "X-API-Key"
+
+// APIKey is the interface for the API key models used by the machine clients. The API key is linked to the account
+// and the hash of the key is stored only. The public key prefix is used for the lookup.
+type APIKey interface {
+	mapping.Model
+	GetAccountID() string
+	SetAccountID(accountID string) error
+	AccountIDField() string
+	GetName() string
+	SetName(name string)
+	GetKeyPrefix() string
+	SetKeyPrefix(prefix string)
+	KeyPrefixField() string
+	GetKeyHash() []byte
+	SetKeyHash(hash []byte)
+	GetScopes() []string
+	SetScopes(scopes []string)
+	GetExpiresAt() *time.Time
+	SetExpiresAt(expiresAt *time.Time)
+	GetLastUsedAt() *time.Time
+	SetLastUsedAt(lastUsedAt time.Time)
+	LastUsedAtField() string
+}
+
+// APIKeyCreateInput is the input for the api key creation.
+type APIKeyCreateInput struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes,omitempty"`
+	// ExpiresIn is the optional key lifetime in seconds.
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+}
+
+// APIKeyOutput is the api key output. The Key is returned only once - when the key is created.
+type APIKeyOutput struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Key        string     `json:"key,omitempty"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// apiKeyScopedAccount is the account authenticated with the API key limited to the authorization scopes.
+type apiKeyScopedAccount struct {
+	auth.Account
+	scope string
+}
+
+// Scope implements auth.Scoper interface.
+func (s *apiKeyScopedAccount) Scope() string {
+	return s.scope
+}
+
+// APIKeyAuthenticate is the middleware that authenticates the request using the API key provided in the X-API-Key
+// header or the 'Authorization: ApiKey <key>' header. The owner of the key is stored in the context.
+// If the key is limited to the authorization scopes, the account implements auth.Scoper interface.
+func (a *API) APIKeyAuthenticate() server.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			key := req.Header.Get(APIKeyHeader)
+			if key == "" {
+				if ah := req.Header.Get("Authorization"); strings.HasPrefix(ah, "ApiKey ") {
+					key = strings.TrimPrefix(ah, "ApiKey ")
+				}
+			}
+			if key == "" {
+				a.marshalErrors(rw, http.StatusUnauthorized, httputil.ErrInvalidAuthorizationHeader())
+				return
+			}
+			ctx := req.Context()
+			account, err := a.authenticateAPIKey(ctx, key)
+			if err != nil {
+				log.Debug2f("Authenticating api key failed: %v", err)
+				if errors.Is(err, auth.ErrInternalError) {
+					a.marshalErrors(rw, 500, httputil.ErrInternalError())
+					return
+				}
+				a.marshalErrors(rw, http.StatusUnauthorized, httputil.ErrInvalidAuthenticationInfo())
+				return
+			}
+			next.ServeHTTP(rw, req.WithContext(auth.CtxWithAccount(ctx, account)))
+		})
+	}
+}
+
+// authenticateAPIKey finds the api key, checks it and returns its owner account.
+func (a *API) authenticateAPIKey(ctx context.Context, key string) (auth.Account, error) {
+	i := strings.IndexByte(key, '.')
+	if i <= 0 {
+		return nil, errors.Wrap(auth.ErrInvalidSecret, "malformed api key")
+	}
+	model, err := a.DB.QueryCtx(ctx, a.apiKeyModel).
+		Filter(filter.New(a.apiKeyPrefixField, filter.OpEqual, key[:i])).
+		Get()
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, errors.Wrap(auth.ErrInvalidSecret, "api key not found")
+		}
+		return nil, errors.Wrapf(auth.ErrInternalError, "getting api key failed: %v", err)
+	}
+	apiKey := model.(APIKey)
+	if subtle.ConstantTimeCompare(hashAPIKey(key), apiKey.GetKeyHash()) != 1 {
+		return nil, errors.Wrap(auth.ErrInvalidSecret, "invalid api key")
+	}
+	now := time.Now()
+	if expiresAt := apiKey.GetExpiresAt(); expiresAt != nil && now.After(*expiresAt) {
+		return nil, errors.Wrap(auth.ErrTokenExpired, "api key expired")
+	}
+	account, err := a.getAccountByID(ctx, apiKey.GetAccountID())
+	if err != nil {
+		if errors.Is(err, auth.ErrAccountNotFound) {
+			return nil, err
+		}
+		return nil, errors.Wrapf(auth.ErrInternalError, "getting api key account failed: %v", err)
+	}
+
+	// Update last used time.
+	apiKey.SetLastUsedAt(now)
+	lastUsedField, _ := a.apiKeyModel.FieldByName(apiKey.LastUsedAtField())
+	if _, err = a.DB.QueryCtx(ctx, a.apiKeyModel, apiKey).Select(lastUsedField).Update(); err != nil {
+		log.Errorf("Updating api key last used time failed: %v", err)
+	}
+	if scopes := apiKey.GetScopes(); len(scopes) > 0 {
+		return &apiKeyScopedAccount{Account: account, scope: strings.Join(scopes, " ")}, nil
+	}
+	return account, nil
+}
+
+func (a *API) handleCreateAPIKey(rw http.ResponseWriter, req *http.Request) {
+	input := &APIKeyCreateInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.Name = q.Get("name")
+		input.Scopes = strings.Fields(q.Get("scopes"))
+		input.ExpiresIn, _ = strconv.ParseInt(q.Get("expires_in"), 10, 64)
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if input.Name == "" {
+		httpError := httputil.ErrInvalidJSONFieldValue()
+		httpError.Detail = "Provided empty api key name."
+		a.marshalErrors(rw, 400, httpError)
+		return
+	}
+	if input.ExpiresIn < 0 {
+		httpError := httputil.ErrInvalidJSONFieldValue()
+		httpError.Detail = "Provided invalid api key expiration."
+		a.marshalErrors(rw, 400, httpError)
+		return
+	}
+	ctx := req.Context()
+	accountID, err := a.getContextAccountID(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	// The key created using scoped credentials is limited to these scopes.
+	ctxAccount, _ := auth.CtxGetAccount(ctx)
+	if scoper, ok := ctxAccount.(auth.Scoper); ok && scoper.Scope() != "" && len(input.Scopes) == 0 {
+		input.Scopes = strings.Fields(scoper.Scope())
+	}
+	// The key could not exceed the scopes granted for the account.
+	if err = a.verifyAPIKeyScopes(ctx, ctxAccount, input.Scopes); err != nil {
+		if errors.Is(err, auth.ErrForbidden) {
+			a.marshalErrors(rw, http.StatusForbidden, httputil.ErrInsufficientAccountPermissions())
+			return
+		}
+		log.Errorf("Verifying api key scopes failed: %v", err)
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+
+	prefix, key, err := newAPIKey()
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	apiKey := mapping.NewModel(a.apiKeyModel).(APIKey)
+	if err = apiKey.SetAccountID(accountID); err != nil {
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	apiKey.SetName(input.Name)
+	apiKey.SetKeyPrefix(prefix)
+	apiKey.SetKeyHash(hashAPIKey(key))
+	apiKey.SetScopes(input.Scopes)
+	if input.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(input.ExpiresIn) * time.Second)
+		apiKey.SetExpiresAt(&expiresAt)
+	}
+	if err = a.DB.Insert(ctx, a.apiKeyModel, apiKey); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	output, err := apiKeyOutput(apiKey)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	output.Key = key
+	a.writeJSON(rw, http.StatusCreated, output)
+}
+
+func (a *API) handleListAPIKeys(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	accountID, err := a.getContextAccountID(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	models, err := a.DB.QueryCtx(ctx, a.apiKeyModel).
+		Filter(filter.New(a.apiKeyAccountField, filter.OpEqual, accountID)).
+		Find()
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	outputs := make([]*APIKeyOutput, len(models))
+	for i, model := range models {
+		if outputs[i], err = apiKeyOutput(model.(APIKey)); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+	}
+	a.writeJSON(rw, http.StatusOK, outputs)
+}
+
+func (a *API) handleRevokeAPIKey(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	accountID, err := a.getContextAccountID(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	id := httprouter.ParamsFromContext(ctx).ByName("id")
+	apiKey := mapping.NewModel(a.apiKeyModel).(APIKey)
+	if err = apiKey.SetPrimaryKeyStringValue(id); err != nil {
+		httpError := httputil.ErrInvalidURI()
+		httpError.Detail = "Provided invalid api key id."
+		a.marshalErrors(rw, 400, httpError)
+		return
+	}
+	// Only the owner of the key could revoke it.
+	deleted, err := a.DB.QueryCtx(ctx, a.apiKeyModel).
+		Filter(filter.New(a.apiKeyModel.Primary(), filter.OpEqual, apiKey.GetPrimaryKeyValue())).
+		Filter(filter.New(a.apiKeyAccountField, filter.OpEqual, accountID)).
+		Delete()
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if deleted == 0 {
+		a.marshalErrors(rw, 0, httputil.ErrResourceNotFound())
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// initializeAPIKeyModel maps the api key model and its fields.
+func (a *API) initializeAPIKeyModel() (err error) {
+	a.apiKeyModel, err = a.Controller.ModelStruct(a.Options.APIKeyModel)
+	if err != nil {
+		return err
+	}
+	var ok bool
+	a.apiKeyPrefixField, ok = a.apiKeyModel.FieldByName(a.Options.APIKeyModel.KeyPrefixField())
+	if !ok {
+		return errors.Wrapf(auth.ErrInitialization, "provided invalid api key model - no key prefix field: '%s' found in the model: %s", a.Options.APIKeyModel.KeyPrefixField(), a.apiKeyModel)
+	}
+	a.apiKeyAccountField, ok = a.apiKeyModel.FieldByName(a.Options.APIKeyModel.AccountIDField())
+	if !ok {
+		return errors.Wrapf(auth.ErrInitialization, "provided invalid api key model - no account id field: '%s' found in the model: %s", a.Options.APIKeyModel.AccountIDField(), a.apiKeyModel)
+	}
+	if _, ok = a.apiKeyModel.FieldByName(a.Options.APIKeyModel.LastUsedAtField()); !ok {
+		return errors.Wrapf(auth.ErrInitialization, "provided invalid api key model - no last used at field: '%s' found in the model: %s", a.Options.APIKeyModel.LastUsedAtField(), a.apiKeyModel)
+	}
+	return nil
+}
+
+func (a *API) getContextAccountID(ctx context.Context) (string, error) {
+	account, ok := auth.CtxGetAccount(ctx)
+	if !ok {
+		return "", errors.WrapDet(auth.ErrAuthorizationHeader, "no account in the context").WithDetail("Not authenticated.")
+	}
+	if err := a.checkAccountModel(account); err != nil {
+		return "", err
+	}
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return "", errors.Wrapf(auth.ErrInternalError, "getting account primary key string value failed: %v", err)
+	}
+	return accountID, nil
+}
+
+func apiKeyOutput(apiKey APIKey) (*APIKeyOutput, error) {
+	id, err := apiKey.GetPrimaryKeyStringValue()
+	if err != nil {
+		return nil, errors.Wrapf(auth.ErrInternalError, "getting api key primary key string value failed: %v", err)
+	}
+	return &APIKeyOutput{
+		ID:         id,
+		Name:       apiKey.GetName(),
+		Prefix:     apiKey.GetKeyPrefix(),
+		Scopes:     apiKey.GetScopes(),
+		ExpiresAt:  apiKey.GetExpiresAt(),
+		LastUsedAt: apiKey.GetLastUsedAt(),
+	}, nil
+}
+
+// newAPIKey generates new api key in the format: '<prefix>.<secret>'.
+func newAPIKey() (prefix, key string, err error) {
+	b := make([]byte, 8)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", errors.Wrapf(auth.ErrInternalError, "generating random api key prefix failed: %v", err)
+	}
+	prefix = hex.EncodeToString(b)
+	secret, err := randomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	return prefix, prefix + "." + secret, nil
+}
+
+func hashAPIKey(key string) []byte {
+	h := sha256.Sum256([]byte(key))
+	return h[:]
+}
+
+// apiKeyScope is the authorization scope requested for the api key.
+type apiKeyScope string
+
+// ScopeName implements auth.Scope interface.
+func (s apiKeyScope) ScopeName() string {
+	return string(s)
+}
+
+// verifyAPIKeyScopes checks if the 'account' is authorized for all the 'scopes' requested for the api key.
+// The scopes are verified by the API Verifier against the scopes granted for the account roles. If the account
+// is authenticated using scoped credentials, the verifier limits these scopes to the credential ones as well.
+func (a *API) verifyAPIKeyScopes(ctx context.Context, account auth.Account, scopes []string) error {
+	if len(scopes) == 0 {
+		return nil
+	}
+	if a.Options.Verifier == nil {
+		return errors.WrapDet(auth.ErrForbidden, "no verifier defined for the api key scopes").
+			WithDetail("Not authorized for the api key scopes.")
+	}
+	authScopes := make([]auth.Scope, len(scopes))
+	for i, scope := range scopes {
+		authScopes[i] = apiKeyScope(scope)
+	}
+	return a.Options.Verifier.Verify(ctx, account, auth.VerifyScopes(authScopes...))
+}
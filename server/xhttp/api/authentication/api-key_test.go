@@ -0,0 +1,221 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/server"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/middleware"
+)
+
+// testVerifier is the auth.Verifier that grants the scopes defined for the account identifiers.
+// The scoped credentials are limited to their own scopes.
+type testVerifier map[string][]string
+
+func (v testVerifier) Verify(_ context.Context, account auth.Account, options ...auth.VerifyOption) error {
+	o := &auth.VerifyOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return err
+	}
+	granted := v[accountID]
+	if scoper, ok := account.(auth.Scoper); ok && scoper.Scope() != "" {
+		granted = intersectScopes(granted, strings.Fields(scoper.Scope()))
+	}
+	for _, scope := range o.Scopes {
+		if !containsScope(granted, scope.ScopeName()) {
+			return errors.Wrapf(auth.ErrForbidden, "scope: '%s' not granted", scope.ScopeName())
+		}
+	}
+	return nil
+}
+
+func intersectScopes(scopes, other []string) []string {
+	var result []string
+	for _, scope := range scopes {
+		if containsScope(other, scope) {
+			result = append(result, scope)
+		}
+	}
+	return result
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPIKeyTestEnv creates the test environment with the api keys. The api key authenticated routes are:
+//   - GET /test/scope - writes the scope of the authenticated account.
+//   - POST /test/api-keys - creates new api key.
+func newAPIKeyTestEnv(t *testing.T, verifier testVerifier) *testEnv {
+	t.Helper()
+	env := newTestEnv(t, WithAPIKeyModel(&AccessKey{}), WithVerifier(verifier))
+	chain := server.MiddlewareChain{middleware.Controller(env.api.Controller), env.api.APIKeyAuthenticate()}
+	env.router.GET("/test/scope", httputil.Wrap(chain.Handle(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		account, _ := auth.CtxGetAccount(req.Context())
+		if scoper, ok := account.(auth.Scoper); ok {
+			_, _ = rw.Write([]byte(scoper.Scope()))
+		}
+	}))))
+	env.router.POST("/test/api-keys", httputil.Wrap(chain.Handle(http.HandlerFunc(env.api.handleCreateAPIKey))))
+	return env
+}
+
+// createAPIKey creates the api key with the 'token' credentials and returns the response.
+func (e *testEnv) createAPIKey(t *testing.T, token string, input *APIKeyCreateInput) (*APIKeyOutput, int) {
+	t.Helper()
+	rw := e.doJSON(http.MethodPost, "/auth/api-keys", token, input)
+	if rw.Code != http.StatusCreated {
+		return nil, rw.Code
+	}
+	output := &APIKeyOutput{}
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(output))
+	return output, rw.Code
+}
+
+// doAPIKey serves the request authenticated with the api key header.
+func (e *testEnv) doAPIKey(method, path, key string, input interface{}) *httptest.ResponseRecorder {
+	body := &strings.Builder{}
+	if input != nil {
+		_ = json.NewEncoder(body).Encode(input)
+	}
+	req := httptest.NewRequest(method, path, strings.NewReader(body.String()))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(APIKeyHeader, key)
+	}
+	rw := httptest.NewRecorder()
+	e.router.ServeHTTP(rw, req)
+	return rw
+}
+
+func TestAPIKeys(t *testing.T) {
+	env := newAPIKeyTestEnv(t, testVerifier{"1": {"read", "write"}})
+	user := env.addUser(t, "john-doe")
+	token := env.token(t, user)
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		_, code := env.createAPIKey(t, "", &APIKeyCreateInput{Name: "key"})
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("ClientPrincipal", func(t *testing.T) {
+		// The client principals are not the accounts, thus these could not manage the api keys.
+		clientToken := env.clientToken(t, "service-client")
+		_, code := env.createAPIKey(t, clientToken.AccessToken, &APIKeyCreateInput{Name: "key"})
+		assert.Equal(t, http.StatusForbidden, code)
+		rw := env.do(http.MethodGet, "/auth/api-keys", clientToken.AccessToken, nil)
+		assert.Equal(t, http.StatusForbidden, rw.Code, rw.Body.String())
+	})
+
+	t.Run("EmptyName", func(t *testing.T) {
+		_, code := env.createAPIKey(t, token.AccessToken, &APIKeyCreateInput{})
+		assert.Equal(t, http.StatusBadRequest, code)
+	})
+
+	t.Run("ScopeNotGranted", func(t *testing.T) {
+		_, code := env.createAPIKey(t, token.AccessToken, &APIKeyCreateInput{Name: "key", Scopes: []string{"read", "admin"}})
+		assert.Equal(t, http.StatusForbidden, code)
+	})
+
+	key, code := env.createAPIKey(t, token.AccessToken, &APIKeyCreateInput{Name: "key", Scopes: []string{"read"}})
+	require.Equal(t, http.StatusCreated, code)
+	require.NotEmpty(t, key.Key)
+	assert.True(t, strings.HasPrefix(key.Key, key.Prefix+"."))
+	assert.Equal(t, []string{"read"}, key.Scopes)
+
+	t.Run("List", func(t *testing.T) {
+		rw := env.do(http.MethodGet, "/auth/api-keys", token.AccessToken, nil)
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+		var outputs []*APIKeyOutput
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&outputs))
+		require.Len(t, outputs, 1)
+		assert.Equal(t, key.ID, outputs[0].ID)
+		// The key is returned only once.
+		assert.Empty(t, outputs[0].Key)
+	})
+
+	t.Run("Authenticate", func(t *testing.T) {
+		rw := env.doAPIKey(http.MethodGet, "/test/scope", key.Key, nil)
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+		assert.Equal(t, "read", rw.Body.String())
+
+		req := httptest.NewRequest(http.MethodGet, "/test/scope", nil)
+		req.Header.Set("Authorization", "ApiKey "+key.Key)
+		rw = httptest.NewRecorder()
+		env.router.ServeHTTP(rw, req)
+		assert.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+
+		for _, invalid := range []string{"", "malformed", key.Prefix + ".invalid", "unknown." + strings.SplitN(key.Key, ".", 2)[1]} {
+			rw = env.doAPIKey(http.MethodGet, "/test/scope", invalid, nil)
+			assert.Equal(t, http.StatusUnauthorized, rw.Code, invalid)
+		}
+
+		rw = env.do(http.MethodGet, "/auth/api-keys", token.AccessToken, nil)
+		var outputs []*APIKeyOutput
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(&outputs))
+		require.Len(t, outputs, 1)
+		assert.NotNil(t, outputs[0].LastUsedAt)
+	})
+
+	t.Run("ScopeEscalation", func(t *testing.T) {
+		// The key limited to 'read' could not create the key with the 'write' scope granted for its account.
+		rw := env.doAPIKey(http.MethodPost, "/test/api-keys", key.Key, &APIKeyCreateInput{Name: "escalated", Scopes: []string{"write"}})
+		assert.Equal(t, http.StatusForbidden, rw.Code, rw.Body.String())
+
+		// The key created without the scopes inherits the credential scopes.
+		rw = env.doAPIKey(http.MethodPost, "/test/api-keys", key.Key, &APIKeyCreateInput{Name: "inherited"})
+		require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+		inherited := &APIKeyOutput{}
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(inherited))
+		assert.Equal(t, []string{"read"}, inherited.Scopes)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		expired, code := env.createAPIKey(t, token.AccessToken, &APIKeyCreateInput{Name: "expired", ExpiresIn: 3600})
+		require.Equal(t, http.StatusCreated, code)
+		model := &AccessKey{}
+		require.NoError(t, model.SetPrimaryKeyStringValue(expired.ID))
+		expiresAt := time.Now().Add(-time.Minute)
+		model.ExpiresAt = &expiresAt
+		field, ok := env.api.apiKeyModel.FieldByName("ExpiresAt")
+		require.True(t, ok)
+		_, err := env.api.DB.QueryCtx(context.Background(), env.api.apiKeyModel, model).Select(field).Update()
+		require.NoError(t, err)
+
+		rw := env.doAPIKey(http.MethodGet, "/test/scope", expired.Key, nil)
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+
+	t.Run("Revoke", func(t *testing.T) {
+		// Only the owner could revoke the key.
+		other := env.addUser(t, "jane-doe")
+		rw := env.do(http.MethodDelete, "/auth/api-keys/"+key.ID, env.token(t, other).AccessToken, nil)
+		assert.Equal(t, http.StatusNotFound, rw.Code, rw.Body.String())
+
+		rw = env.do(http.MethodDelete, "/auth/api-keys/"+key.ID, token.AccessToken, nil)
+		require.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+		rw = env.doAPIKey(http.MethodGet, "/test/scope", key.Key, nil)
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+}
@@ -1,9 +1,14 @@
 package authentication
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"reflect"
 
 	"github.com/julienschmidt/httprouter"
 
@@ -32,6 +37,13 @@ type API struct {
 	// model is account model structure.
 	model          *mapping.ModelStruct
 	defaultHandler *DefaultHandler
+	// clientModel is the OAuth2 client model structure.
+	clientModel   *mapping.ModelStruct
+	clientIDField *mapping.StructField
+	// apiKeyModel is the api key model structure.
+	apiKeyModel        *mapping.ModelStruct
+	apiKeyPrefixField  *mapping.StructField
+	apiKeyAccountField *mapping.StructField
 }
 
 // New creates account API.
@@ -80,6 +92,51 @@ func (a *API) InitializeAPI(c *core.Controller) error {
 		return err
 	}
 
+	// Set the default store.
+	if a.Options.Store == nil {
+		a.Options.Store = a.Controller.DefaultStore
+	}
+	// The two factor authentication requires the store for the login challenges.
+	if _, ok := a.Options.AccountModel.(TOTPAccount); ok && a.Options.Store == nil {
+		return errors.Wrap(auth.ErrInitialization, "two factor authentication requires a store")
+	}
+	if a.Options.LockoutPolicy != nil {
+		if a.Options.Store == nil {
+			return errors.Wrap(auth.ErrInitialization, "login lockout policy requires a store")
+		}
+		if a.Options.LockoutPolicy.Window <= 0 || a.Options.LockoutPolicy.LockoutDuration <= 0 {
+			return errors.Wrap(auth.ErrInitialization, "login lockout policy requires non zero window and lockout duration")
+		}
+	}
+
+	// The password reset and email verification tokens are kept in the store.
+	if a.Options.Notifier != nil && a.Options.Store == nil {
+		return errors.Wrap(auth.ErrInitialization, "password reset and email verification requires a store")
+	}
+
+	if a.Options.Cookies != nil {
+		if err := a.validateCookieOptions(); err != nil {
+			return err
+		}
+	}
+
+	// Initialize client model if defined.
+	if a.Options.ClientModel != nil {
+		if err := a.initializeClientModel(); err != nil {
+			return err
+		}
+	}
+
+	// Initialize api key model if defined.
+	if a.Options.APIKeyModel != nil {
+		if a.Options.Verifier == nil {
+			a.Options.Verifier = a.Controller.Verifier
+		}
+		if err := a.initializeAPIKeyModel(); err != nil {
+			return err
+		}
+	}
+
 	// Initialize handler if needed.
 	if initializer, ok := a.Options.AccountHandler.(interface {
 		Initialize(c *core.Controller) error
@@ -133,10 +190,11 @@ func (a *API) SetRoutes(router *httprouter.Router) error {
 	// Refresh Token endpoint.
 	middlewares = server.MiddlewareChain{middleware.Controller(a.Controller)}
 	middlewares = append(middlewares, a.Options.Middlewares...)
+	middlewares = append(middlewares, a.sessionMiddlewares()...)
 	middlewares = append(middlewares, a.Options.RefreshTokenMiddlewares...)
-	router.POST(fmt.Sprintf("%s/refresh", prefix), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleRefreshToken))))
+	router.POST(a.refreshPath(), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleRefreshToken))))
 	a.Endpoints = append(a.Endpoints, &server.Endpoint{
-		Path:        fmt.Sprintf("%s/refresh", prefix),
+		Path:        a.refreshPath(),
 		HTTPMethod:  "POST",
 		ModelStruct: a.model,
 	})
@@ -144,6 +202,7 @@ func (a *API) SetRoutes(router *httprouter.Router) error {
 	// Logout endpoint.
 	middlewares = server.MiddlewareChain{middleware.Controller(a.Controller)}
 	middlewares = append(middlewares, a.Options.Middlewares...)
+	middlewares = append(middlewares, a.sessionMiddlewares()...)
 	middlewares = append(middlewares, a.Options.LogoutMiddlewares...)
 	router.POST(fmt.Sprintf("%s/logout", prefix), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleLogout))))
 	a.Endpoints = append(a.Endpoints, &server.Endpoint{
@@ -152,6 +211,61 @@ func (a *API) SetRoutes(router *httprouter.Router) error {
 		ModelStruct: a.model,
 	})
 
+	// Account management endpoints needs to be enabled explicitly.
+	if a.Options.AccountEndpoints {
+		a.setAccountRoutes(router, prefix)
+	}
+
+	// Two factor authentication endpoints.
+	if _, ok := a.Options.AccountModel.(TOTPAccount); ok {
+		a.setTwoFactorRoutes(router, prefix)
+	}
+
+	// Password reset and email verification endpoints.
+	if a.Options.Notifier != nil {
+		a.setNotifierRoutes(router, prefix)
+	}
+
+	// API keys management endpoints.
+	if a.apiKeyModel != nil {
+		a.setAPIKeyRoutes(router, prefix)
+	}
+
+	// OAuth2 token endpoint with the client credentials grant.
+	if a.clientModel != nil {
+		middlewares = server.MiddlewareChain{middleware.Controller(a.Controller)}
+		middlewares = append(middlewares, a.Options.Middlewares...)
+		middlewares = append(middlewares, a.Options.TokenMiddlewares...)
+		router.POST(fmt.Sprintf("%s/token", prefix), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleToken))))
+		a.Endpoints = append(a.Endpoints, &server.Endpoint{
+			Path:        fmt.Sprintf("%s/token", prefix),
+			HTTPMethod:  "POST",
+			ModelStruct: a.clientModel,
+		})
+	}
+
+	// Token introspection and revocation endpoints are available only for authenticated clients.
+	if a.Options.ClientAuthenticator != nil {
+		middlewares = server.MiddlewareChain{middleware.Controller(a.Controller)}
+		middlewares = append(middlewares, a.Options.Middlewares...)
+		middlewares = append(middlewares, a.Options.IntrospectMiddlewares...)
+		router.POST(fmt.Sprintf("%s/introspect", prefix), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleIntrospect))))
+		a.Endpoints = append(a.Endpoints, &server.Endpoint{
+			Path:        fmt.Sprintf("%s/introspect", prefix),
+			HTTPMethod:  "POST",
+			ModelStruct: a.model,
+		})
+
+		middlewares = server.MiddlewareChain{middleware.Controller(a.Controller)}
+		middlewares = append(middlewares, a.Options.Middlewares...)
+		middlewares = append(middlewares, a.Options.RevokeMiddlewares...)
+		router.POST(fmt.Sprintf("%s/revoke", prefix), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleRevoke))))
+		a.Endpoints = append(a.Endpoints, &server.Endpoint{
+			Path:        fmt.Sprintf("%s/revoke", prefix),
+			HTTPMethod:  "POST",
+			ModelStruct: a.model,
+		})
+	}
 	return nil
 }
 
@@ -174,3 +288,273 @@ func (a *API) marshalErrors(rw http.ResponseWriter, status int, err error) {
 func (a *API) setContentType(rw http.ResponseWriter) {
 	rw.Header().Set("Content-Type", cjson.MimeType)
 }
+
+// writeJSON encodes the 'output' and writes it with the 'status' to the response writer.
+func (a *API) writeJSON(rw http.ResponseWriter, status int, output interface{}) {
+	buffer := &bytes.Buffer{}
+	if err := json.NewEncoder(buffer).Encode(output); err != nil {
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	a.setContentType(rw)
+	rw.WriteHeader(status)
+	if _, err := buffer.WriteTo(rw); err != nil {
+		log.Errorf("Writing to response writer failed: %v", err)
+	}
+}
+
+// decodeInput decodes the json request body into 'input'. For the form requests the 'fromForm' function
+// should set the input values.
+func (a *API) decodeInput(req *http.Request, input interface{}, fromForm func(q url.Values)) error {
+	if req.Header.Get("Content-Type") == "application/json" {
+		d := json.NewDecoder(req.Body)
+		if a.Options.StrictUnmarshal {
+			d.DisallowUnknownFields()
+		}
+		if err := d.Decode(input); err != nil {
+			return errors.WrapDetf(codec.ErrUnmarshalDocument, "decode failed: %v", err).
+				WithDetail("Provided invalid input document.")
+		}
+		return nil
+	}
+	if req.Method == http.MethodDelete {
+		// The request's ParseForm doesn't read the body of the DELETE requests.
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return errors.WrapDet(codec.ErrUnmarshalDocument, "reading failed")
+		}
+		q, err := url.ParseQuery(string(body))
+		if err != nil {
+			return errors.WrapDet(codec.ErrUnmarshalDocument, "parsing form failed").WithDetail("Parsing form failed.")
+		}
+		fromForm(q)
+		return nil
+	}
+	if err := req.ParseForm(); err != nil {
+		return errors.WrapDet(codec.ErrUnmarshalDocument, "provided invalid post form").WithDetail("invalid post form")
+	}
+	fromForm(req.PostForm)
+	return nil
+}
+
+// getAuthenticatedAccount gets the up to date value of the account authenticated in the context.
+func (a *API) getAuthenticatedAccount(ctx context.Context) (auth.Account, error) {
+	ctxAccount, ok := auth.CtxGetAccount(ctx)
+	if !ok {
+		return nil, errors.WrapDet(auth.ErrAuthorizationHeader, "no account in the context").WithDetail("Not authenticated.")
+	}
+	if err := a.checkAccountModel(ctxAccount); err != nil {
+		return nil, err
+	}
+	accountID, err := ctxAccount.GetPrimaryKeyStringValue()
+	if err != nil {
+		return nil, errors.Wrapf(auth.ErrInternalError, "getting account primary key string value failed: %v", err)
+	}
+	return a.getAccountByID(ctx, accountID)
+}
+
+// checkAccountModel checks if the context 'account' is an instance of the account model. The OAuth2 client principals
+// and other accounts are not related with any stored account, thus their primary keys could not be used
+// as the account identifiers.
+func (a *API) checkAccountModel(account auth.Account) error {
+	if _, ok := account.(clientAccount); !ok && a.isAccountModel(account) {
+		return nil
+	}
+	return errors.WrapDetf(auth.ErrForbidden, "context account: '%T' is not an instance of the account model", account).
+		WithDetail("Operation allowed only for the accounts.")
+}
+
+// isAccountModel checks if the 'account' or the account embedded by it i.e. by the scoped token account,
+// is an instance of the account model.
+func (a *API) isAccountModel(account auth.Account) bool {
+	modelType := reflect.PtrTo(a.model.Type())
+	for account != nil {
+		if reflect.TypeOf(account) == modelType {
+			return true
+		}
+		v := reflect.Indirect(reflect.ValueOf(account))
+		if v.Kind() != reflect.Struct {
+			return false
+		}
+		field, ok := v.Type().FieldByName("Account")
+		if !ok || !field.Anonymous || field.Type.Kind() != reflect.Interface {
+			return false
+		}
+		account, _ = v.FieldByIndex(field.Index).Interface().(auth.Account)
+	}
+	return false
+}
+
+// getAccountByID gets the account with provided primary key string value.
+func (a *API) getAccountByID(ctx context.Context, accountID string) (auth.Account, error) {
+	model := mapping.NewModel(a.model)
+	if err := model.SetPrimaryKeyStringValue(accountID); err != nil {
+		return nil, errors.Wrapf(auth.ErrInternalError, "setting account primary key string value failed: %v", err)
+	}
+	if err := a.DB.QueryCtx(ctx, a.model, model).Refresh(); err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, errors.Wrap(auth.ErrAccountNotFound, "account not found")
+		}
+		return nil, err
+	}
+	return model.(auth.Account), nil
+}
+
+// updateAccountFields updates the account fields with provided names.
+func (a *API) updateAccountFields(ctx context.Context, db database.DB, account auth.Account, fieldNames ...string) error {
+	fields := make([]*mapping.StructField, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		field, ok := a.model.FieldByName(fieldName)
+		if !ok {
+			return errors.Wrapf(auth.ErrInternalError, "no field: '%s' found in the account model: %s", fieldName, a.model)
+		}
+		fields[i] = field
+	}
+	_, err := db.QueryCtx(ctx, a.model, account).Select(fields...).Update()
+	return err
+}
+
+func (a *API) setTwoFactorRoutes(router *httprouter.Router, prefix string) {
+	// Second step of the login process.
+	middlewares := server.MiddlewareChain{middleware.Controller(a.Controller)}
+	middlewares = append(middlewares, a.Options.Middlewares...)
+	middlewares = append(middlewares, a.Options.LoginMiddlewares...)
+	router.POST(fmt.Sprintf("%s/login/totp", prefix), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleTwoFactorLogin))))
+	a.Endpoints = append(a.Endpoints, &server.Endpoint{
+		Path:        fmt.Sprintf("%s/login/totp", prefix),
+		HTTPMethod:  "POST",
+		ModelStruct: a.model,
+	})
+
+	// Enrolment endpoints requires authenticated account.
+	for _, endpoint := range []struct {
+		path    string
+		handler http.HandlerFunc
+	}{
+		{path: "totp/enroll", handler: a.handleTOTPEnroll},
+		{path: "totp/verify", handler: a.handleTOTPVerify},
+		{path: "totp/disable", handler: a.handleTOTPDisable},
+	} {
+		middlewares = server.MiddlewareChain{middleware.Controller(a.Controller)}
+		middlewares = append(middlewares, a.Options.Middlewares...)
+		middlewares = append(middlewares, a.authenticateMiddlewares()...)
+		middlewares = append(middlewares, a.Options.TwoFactorMiddlewares...)
+		router.POST(fmt.Sprintf("%s/%s", prefix, endpoint.path), httputil.Wrap(middlewares.Handle(endpoint.handler)))
+		a.Endpoints = append(a.Endpoints, &server.Endpoint{
+			Path:        fmt.Sprintf("%s/%s", prefix, endpoint.path),
+			HTTPMethod:  "POST",
+			QueryMethod: query.Update,
+			ModelStruct: a.model,
+		})
+	}
+}
+
+func (a *API) setNotifierRoutes(router *httprouter.Router, prefix string) {
+	for _, endpoint := range []struct {
+		path        string
+		handler     http.HandlerFunc
+		middlewares []server.Middleware
+	}{
+		{path: "password/forgot", handler: a.handlePasswordResetRequest, middlewares: a.Options.PasswordResetMiddlewares},
+		{path: "password/reset", handler: a.handlePasswordReset, middlewares: a.Options.PasswordResetMiddlewares},
+	} {
+		middlewares := server.MiddlewareChain{middleware.Controller(a.Controller)}
+		middlewares = append(middlewares, a.Options.Middlewares...)
+		middlewares = append(middlewares, endpoint.middlewares...)
+		router.POST(fmt.Sprintf("%s/%s", prefix, endpoint.path), httputil.Wrap(middlewares.Handle(endpoint.handler)))
+		a.Endpoints = append(a.Endpoints, &server.Endpoint{
+			Path:        fmt.Sprintf("%s/%s", prefix, endpoint.path),
+			HTTPMethod:  "POST",
+			ModelStruct: a.model,
+		})
+	}
+
+	// Email verification endpoints.
+	if _, ok := a.Options.AccountModel.(EmailVerifiedAccount); !ok {
+		return
+	}
+	middlewares := server.MiddlewareChain{middleware.Controller(a.Controller)}
+	middlewares = append(middlewares, a.Options.Middlewares...)
+	middlewares = append(middlewares, a.Options.EmailVerificationMiddlewares...)
+	router.POST(fmt.Sprintf("%s/email/verify", prefix), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleEmailVerification))))
+	a.Endpoints = append(a.Endpoints, &server.Endpoint{
+		Path:        fmt.Sprintf("%s/email/verify", prefix),
+		HTTPMethod:  "POST",
+		QueryMethod: query.Update,
+		ModelStruct: a.model,
+	})
+
+	// Resending the verification token requires authenticated account.
+	middlewares = server.MiddlewareChain{middleware.Controller(a.Controller)}
+	middlewares = append(middlewares, a.Options.Middlewares...)
+	middlewares = append(middlewares, a.authenticateMiddlewares()...)
+	middlewares = append(middlewares, a.Options.EmailVerificationMiddlewares...)
+	router.POST(fmt.Sprintf("%s/email/verify/request", prefix), httputil.Wrap(middlewares.Handle(http.HandlerFunc(a.handleEmailVerificationRequest))))
+	a.Endpoints = append(a.Endpoints, &server.Endpoint{
+		Path:        fmt.Sprintf("%s/email/verify/request", prefix),
+		HTTPMethod:  "POST",
+		ModelStruct: a.model,
+	})
+}
+
+func (a *API) setAccountRoutes(router *httprouter.Router, prefix string) {
+	// All the account management endpoints requires authenticated account.
+	for _, endpoint := range []struct {
+		method      string
+		path        string
+		handler     http.HandlerFunc
+		queryMethod query.Method
+	}{
+		{method: "GET", path: "me", handler: a.handleGetAccount, queryMethod: query.Get},
+		{method: "DELETE", path: "me", handler: a.handleDeleteAccount, queryMethod: query.Delete},
+		{method: "POST", path: "password/change", handler: a.handleChangePassword, queryMethod: query.Update},
+		{method: "POST", path: "username/change", handler: a.handleChangeUsername, queryMethod: query.Update},
+	} {
+		middlewares := server.MiddlewareChain{middleware.Controller(a.Controller)}
+		middlewares = append(middlewares, a.Options.Middlewares...)
+		middlewares = append(middlewares, a.authenticateMiddlewares()...)
+		middlewares = append(middlewares, a.Options.AccountMiddlewares...)
+		router.Handle(endpoint.method, fmt.Sprintf("%s/%s", prefix, endpoint.path), httputil.Wrap(middlewares.Handle(endpoint.handler)))
+		a.Endpoints = append(a.Endpoints, &server.Endpoint{
+			Path:        fmt.Sprintf("%s/%s", prefix, endpoint.path),
+			HTTPMethod:  endpoint.method,
+			QueryMethod: endpoint.queryMethod,
+			ModelStruct: a.model,
+		})
+	}
+}
+
+func (a *API) setAPIKeyRoutes(router *httprouter.Router, prefix string) {
+	// The api keys could be managed only by the authenticated account.
+	for _, endpoint := range []struct {
+		method      string
+		path        string
+		handler     http.HandlerFunc
+		queryMethod query.Method
+	}{
+		{method: "POST", path: "api-keys", handler: a.handleCreateAPIKey, queryMethod: query.Insert},
+		{method: "GET", path: "api-keys", handler: a.handleListAPIKeys, queryMethod: query.List},
+		{method: "DELETE", path: "api-keys/:id", handler: a.handleRevokeAPIKey, queryMethod: query.Delete},
+	} {
+		middlewares := server.MiddlewareChain{middleware.Controller(a.Controller)}
+		middlewares = append(middlewares, a.Options.Middlewares...)
+		middlewares = append(middlewares, a.authenticateMiddlewares()...)
+		middlewares = append(middlewares, a.Options.APIKeyMiddlewares...)
+		router.Handle(endpoint.method, fmt.Sprintf("%s/%s", prefix, endpoint.path), httputil.Wrap(middlewares.Handle(endpoint.handler)))
+		a.Endpoints = append(a.Endpoints, &server.Endpoint{
+			Path:        fmt.Sprintf("%s/%s", prefix, endpoint.path),
+			HTTPMethod:  endpoint.method,
+			QueryMethod: endpoint.queryMethod,
+			ModelStruct: a.apiKeyModel,
+		})
+	}
+}
+
+// refreshPath returns the path of the refresh token endpoint.
+func (a *API) refreshPath() string {
+	prefix := a.Options.PathPrefix
+	if prefix == "" {
+		prefix = "/"
+	}
+	return fmt.Sprintf("%s/refresh", prefix)
+}
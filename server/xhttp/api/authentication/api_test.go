@@ -0,0 +1,599 @@
+package authentication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/core"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/store"
+)
+
+const testPassword = "Secret-Password1!"
+
+// testEnv is the authentication API test environment with the in-memory repository, store and tokener.
+type testEnv struct {
+	api     *API
+	router  *httprouter.Router
+	repo    *testRepository
+	store   *testStore
+	tokener *testTokener
+}
+
+func newTestEnv(t *testing.T, options ...Option) *testEnv {
+	t.Helper()
+	env := &testEnv{
+		repo:    &testRepository{models: map[*mapping.ModelStruct][]mapping.Model{}},
+		store:   &testStore{records: map[string]*store.Record{}},
+		tokener: &testTokener{tokens: map[string]*testToken{}, revokedAccounts: map[string]time.Time{}},
+	}
+	c := core.NewDefault()
+	require.NoError(t, c.RegisterModels(&User{}, &ServiceClient{}, &AccessKey{}))
+	require.NoError(t, c.SetDefaultRepository(env.repo))
+	c.Authenticator = testAuthenticator{}
+	c.Tokener = env.tokener
+	c.DefaultStore = env.store
+
+	var err error
+	env.api, err = New(append([]Option{WithAccountModel(&User{}), WithPathPrefix("/auth")}, options...)...)
+	require.NoError(t, err)
+	require.NoError(t, env.api.InitializeAPI(c))
+	env.router = httprouter.New()
+	require.NoError(t, env.api.SetRoutes(env.router))
+	return env
+}
+
+// addUser stores new user with provided username and the testPassword.
+func (e *testEnv) addUser(t *testing.T, username string) *User {
+	t.Helper()
+	user := &User{Username: username}
+	require.NoError(t, testAuthenticator{}.HashAndSetPassword(user, auth.NewPassword(testPassword)))
+	require.NoError(t, e.api.DB.Insert(context.Background(), e.api.model, user))
+	return user
+}
+
+// getUser gets the stored user with provided 'id'.
+func (e *testEnv) getUser(t *testing.T, id int) (*User, bool) {
+	t.Helper()
+	model, err := e.api.DB.QueryCtx(context.Background(), e.api.model).Where("ID = ?", id).Get()
+	if errors.Is(err, query.ErrNoResult) {
+		return nil, false
+	}
+	require.NoError(t, err)
+	return model.(*User), true
+}
+
+// token issues the access token for the 'user'.
+func (e *testEnv) token(t *testing.T, user *User) auth.Token {
+	t.Helper()
+	token, err := e.tokener.Token(context.Background(), user)
+	require.NoError(t, err)
+	return token
+}
+
+// clientToken issues the client credentials access token for the 'clientID'.
+func (e *testEnv) clientToken(t *testing.T, clientID string) auth.Token {
+	t.Helper()
+	token, err := e.tokener.Token(context.Background(), &testClientPrincipal{clientID: clientID})
+	require.NoError(t, err)
+	return token
+}
+
+// do serves the request with the form encoded 'form' body. If 'token' is not empty it is used as the bearer token.
+func (e *testEnv) do(method, path, token string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rw := httptest.NewRecorder()
+	e.router.ServeHTTP(rw, req)
+	return rw
+}
+
+// doJSON serves the request with the json encoded 'input' body.
+func (e *testEnv) doJSON(method, path, token string, input interface{}) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	if input != nil {
+		_ = json.NewEncoder(body).Encode(input)
+	}
+	req := httptest.NewRequest(method, path, body)
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rw := httptest.NewRecorder()
+	e.router.ServeHTTP(rw, req)
+	return rw
+}
+
+// testAuthenticator is the auth.Authenticator that stores the passwords with a plain prefix.
+type testAuthenticator struct{}
+
+func (testAuthenticator) HashAndSetPassword(account auth.Account, password *auth.Password) error {
+	account.SetPasswordHash([]byte("hash:" + password.Password))
+	return nil
+}
+
+func (testAuthenticator) ComparePassword(account auth.Account, password string) error {
+	if string(account.GetPasswordHash()) != "hash:"+password {
+		return errors.Wrap(auth.ErrInvalidPassword, "password doesn't match")
+	}
+	return nil
+}
+
+// testToken is the value of the token issued by the testTokener.
+type testToken struct {
+	subject   string
+	refresh   bool
+	client    bool
+	scope     string
+	issuedAt  time.Time
+	expiresAt time.Time
+	revoked   bool
+	mapped    string
+}
+
+// testTokener is the in-memory auth.Tokener implementation.
+type testTokener struct {
+	mu              sync.Mutex
+	counter         int
+	tokens          map[string]*testToken
+	revokedAccounts map[string]time.Time
+}
+
+func (t *testTokener) InspectToken(_ context.Context, token string) (auth.Claims, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tk, ok := t.tokens[token]
+	if !ok {
+		return nil, errors.Wrap(auth.ErrToken, "token not found")
+	}
+	claims := &testClaims{token: *tk}
+	if revokedAt, ok := t.revokedAccounts[tk.subject]; ok && !tk.client && !tk.issuedAt.After(revokedAt) {
+		claims.token.revoked = true
+	}
+	if tk.refresh {
+		return claims, nil
+	}
+	return &testAccessClaims{testClaims: claims}, nil
+}
+
+func (t *testTokener) Token(_ context.Context, account auth.Account, options ...auth.TokenOption) (auth.Token, error) {
+	o := &auth.TokenOptions{ExpirationTime: time.Hour, RefreshExpirationTime: time.Hour * 24}
+	for _, option := range options {
+		option(o)
+	}
+	subject, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return auth.Token{}, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	access := t.newToken()
+	t.tokens[access] = &testToken{subject: subject, scope: o.Scope, issuedAt: now, expiresAt: now.Add(o.ExpirationTime)}
+	token := auth.Token{AccessToken: access, TokenType: "bearer", ExpiresIn: int(o.ExpirationTime / time.Second)}
+	if _, ok := account.(clientAccount); ok {
+		t.tokens[access].client = true
+		t.tokens[access].subject = account.GetUsername()
+		return token, nil
+	}
+	refresh := o.RefreshToken
+	if refresh == "" {
+		refresh = t.newToken()
+		t.tokens[refresh] = &testToken{subject: subject, refresh: true, scope: o.Scope, issuedAt: now, expiresAt: now.Add(o.RefreshExpirationTime)}
+	}
+	t.tokens[access].mapped = refresh
+	token.RefreshToken = refresh
+	return token, nil
+}
+
+func (t *testTokener) RevokeToken(_ context.Context, token string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	tk, ok := t.tokens[token]
+	if !ok {
+		return errors.Wrap(auth.ErrToken, "token not found")
+	}
+	if tk.revoked {
+		return errors.Wrap(auth.ErrTokenRevoked, "token was already revoked")
+	}
+	tk.revoked = true
+	if mapped, ok := t.tokens[tk.mapped]; ok {
+		mapped.revoked = true
+	}
+	return nil
+}
+
+func (t *testTokener) RevokeAccountTokens(_ context.Context, account auth.Account) error {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.revokedAccounts[accountID] = time.Now()
+	return nil
+}
+
+// isRevoked checks if the 'token' is revoked.
+func (t *testTokener) isRevoked(ctx context.Context, token string) bool {
+	claims, err := t.InspectToken(ctx, token)
+	if err != nil {
+		return false
+	}
+	return errors.Is(claims.Valid(), auth.ErrTokenRevoked)
+}
+
+func (t *testTokener) newToken() string {
+	t.counter++
+	return "token-" + strconv.Itoa(t.counter)
+}
+
+// testClaims are the testTokener refresh token claims.
+type testClaims struct {
+	token testToken
+}
+
+func (c *testClaims) Subject() string  { return c.token.subject }
+func (c *testClaims) ExpiresIn() int64 { return c.token.expiresAt.Unix() }
+func (c *testClaims) Scope() string    { return c.token.scope }
+func (c *testClaims) Valid() error {
+	if c.token.revoked {
+		return auth.ErrTokenRevoked
+	}
+	if time.Now().After(c.token.expiresAt) {
+		return auth.ErrTokenExpired
+	}
+	return nil
+}
+
+// testAccessClaims are the testTokener access token claims.
+type testAccessClaims struct {
+	*testClaims
+}
+
+func (c *testAccessClaims) GetAccount() auth.Account {
+	if c.token.client {
+		return &testClientPrincipal{clientID: c.token.subject, scope: c.token.scope}
+	}
+	user := &User{}
+	_ = user.SetPrimaryKeyStringValue(c.token.subject)
+	return user
+}
+
+// testClientPrincipal is the client principal returned by the testTokener.
+type testClientPrincipal struct {
+	User
+	clientID, scope string
+}
+
+func (c *testClientPrincipal) ClientPrincipal()    {}
+func (c *testClientPrincipal) GetUsername() string { return c.clientID }
+func (c *testClientPrincipal) Scope() string       { return c.scope }
+
+// testStore is the in-memory store.Store implementation. It implements also the atomic counters and conditional set.
+type testStore struct {
+	mu      sync.Mutex
+	records map[string]*store.Record
+}
+
+func (s *testStore) Set(_ context.Context, record *store.Record, options ...store.SetOption) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set(record, options)
+	return nil
+}
+
+func (s *testStore) Get(_ context.Context, key string) (*store.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.get(key)
+	if !ok {
+		return nil, store.ErrRecordNotFound
+	}
+	return record.Copy(), nil
+}
+
+func (s *testStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.get(key); !ok {
+		return store.ErrRecordNotFound
+	}
+	delete(s.records, key)
+	return nil
+}
+
+func (s *testStore) Find(_ context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	o := &store.FindPattern{}
+	for _, option := range options {
+		option(o)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []*store.Record
+	for key := range s.records {
+		if record, ok := s.get(key); ok && strings.HasPrefix(key, o.Prefix) && strings.HasSuffix(key, o.Suffix) {
+			records = append(records, record.Copy())
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Key < records[j].Key })
+	return records, nil
+}
+
+func (s *testStore) Increment(_ context.Context, key string, delta int64, options ...store.SetOption) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var value int64
+	record, ok := s.get(key)
+	if ok {
+		var err error
+		if value, err = strconv.ParseInt(string(record.Value), 10, 64); err != nil {
+			return 0, errors.Wrap(store.ErrInternal, "record is not a counter")
+		}
+	}
+	value += delta
+	if ok {
+		record.Value = []byte(strconv.FormatInt(value, 10))
+		return value, nil
+	}
+	s.set(&store.Record{Key: key, Value: []byte(strconv.FormatInt(value, 10))}, options)
+	return value, nil
+}
+
+func (s *testStore) SetIfNotExists(_ context.Context, record *store.Record, options ...store.SetOption) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.get(record.Key); ok {
+		return false, nil
+	}
+	s.set(record, options)
+	return true, nil
+}
+
+func (s *testStore) set(record *store.Record, options []store.SetOption) {
+	o := &store.SetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	record = record.Copy()
+	if o.TTL > 0 {
+		record.ExpiresAt = time.Now().Add(o.TTL)
+	}
+	s.records[record.Key] = record
+}
+
+func (s *testStore) get(key string) (*store.Record, bool) {
+	record, ok := s.records[key]
+	if !ok {
+		return nil, false
+	}
+	if !record.ExpiresAt.IsZero() && time.Now().After(record.ExpiresAt) {
+		delete(s.records, key)
+		return nil, false
+	}
+	return record, true
+}
+
+// testRepository is the in-memory repository.Repository implementation. It supports only simple equality filters.
+type testRepository struct {
+	mu     sync.Mutex
+	lastID int
+	models map[*mapping.ModelStruct][]mapping.Model
+}
+
+func (r *testRepository) ID() string { return "test-memory" }
+
+func (r *testRepository) Begin(context.Context, *query.Transaction) error    { return nil }
+func (r *testRepository) Commit(context.Context, *query.Transaction) error   { return nil }
+func (r *testRepository) Rollback(context.Context, *query.Transaction) error { return nil }
+
+func (r *testRepository) Count(_ context.Context, s *query.Scope) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	models, err := r.find(s)
+	return int64(len(models)), err
+}
+
+func (r *testRepository) Insert(_ context.Context, s *query.Scope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, model := range s.Models {
+		if model.IsPrimaryKeyZero() {
+			r.lastID++
+			if err := model.SetPrimaryKeyValue(r.lastID); err != nil {
+				return err
+			}
+		}
+		r.models[s.ModelStruct] = append(r.models[s.ModelStruct], copyModel(model))
+	}
+	return nil
+}
+
+func (r *testRepository) Find(_ context.Context, s *query.Scope) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	models, err := r.find(s)
+	if err != nil {
+		return err
+	}
+	s.Models = make(mapping.Models, len(models))
+	for i, model := range models {
+		s.Models[i] = copyModel(model)
+	}
+	return nil
+}
+
+func (r *testRepository) Update(_ context.Context, s *query.Scope) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	models, err := r.find(s)
+	if err != nil {
+		return 0, err
+	}
+	for _, model := range models {
+		if err = setFields(model, s.Models[0], s.FieldSets[0]); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(models)), nil
+}
+
+func (r *testRepository) UpdateModels(_ context.Context, s *query.Scope) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for i, model := range s.Models {
+		fieldSet := s.FieldSets[0]
+		if len(s.FieldSets) > i {
+			fieldSet = s.FieldSets[i]
+		}
+		for _, stored := range r.models[s.ModelStruct] {
+			if stored.GetPrimaryKeyHashableValue() != model.GetPrimaryKeyHashableValue() {
+				continue
+			}
+			if err := setFields(stored, model, fieldSet); err != nil {
+				return 0, err
+			}
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *testRepository) Delete(_ context.Context, s *query.Scope) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	deleted, err := r.find(s)
+	if err != nil {
+		return 0, err
+	}
+	var left []mapping.Model
+	for _, model := range r.models[s.ModelStruct] {
+		if !containsModel(deleted, model) {
+			left = append(left, model)
+		}
+	}
+	r.models[s.ModelStruct] = left
+	return int64(len(deleted)), nil
+}
+
+// find gets the stored models matching the scope filters or the scope models primary keys.
+func (r *testRepository) find(s *query.Scope) ([]mapping.Model, error) {
+	var result []mapping.Model
+	for _, model := range r.models[s.ModelStruct] {
+		if len(s.Filters) == 0 && len(s.Models) > 0 && !containsModel(s.Models, model) {
+			continue
+		}
+		matches, err := matchFilters(model, s.Filters)
+		if err != nil {
+			return nil, err
+		}
+		if matches {
+			result = append(result, model)
+		}
+	}
+	return result, nil
+}
+
+func matchFilters(model mapping.Model, filters filter.Filters) (bool, error) {
+	fielder := model.(mapping.Fielder)
+	for _, f := range filters {
+		simple, ok := f.(filter.Simple)
+		if !ok {
+			return false, errors.Wrapf(query.ErrInvalidInput, "unsupported test filter: %s", f)
+		}
+		value, err := fielder.GetFieldValue(simple.StructField)
+		if err != nil {
+			return false, err
+		}
+		var equal bool
+		for _, v := range filterValues(simple.Values) {
+			if fmt.Sprint(v) == fmt.Sprint(value) {
+				equal = true
+			}
+		}
+		switch simple.Operator {
+		case filter.OpEqual, filter.OpIn:
+			if !equal {
+				return false, nil
+			}
+		case filter.OpNotEqual, filter.OpNotIn:
+			if equal {
+				return false, nil
+			}
+		default:
+			return false, errors.Wrapf(query.ErrInvalidInput, "unsupported test filter operator: %s", simple.Operator)
+		}
+	}
+	return true, nil
+}
+
+// filterValues flattens the slice filter values.
+func filterValues(values []interface{}) []interface{} {
+	var result []interface{}
+	for _, value := range values {
+		v := reflect.ValueOf(value)
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 {
+			for i := 0; i < v.Len(); i++ {
+				result = append(result, v.Index(i).Interface())
+			}
+			continue
+		}
+		result = append(result, value)
+	}
+	return result
+}
+
+func setFields(dst, src mapping.Model, fieldSet mapping.FieldSet) error {
+	dstFielder, srcFielder := dst.(mapping.Fielder), src.(mapping.Fielder)
+	for _, field := range fieldSet {
+		if field.Kind() == mapping.KindPrimary {
+			continue
+		}
+		value, err := srcFielder.GetFieldValue(field)
+		if err != nil {
+			return err
+		}
+		if err = dstFielder.SetFieldValue(field, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsModel(models []mapping.Model, model mapping.Model) bool {
+	for _, m := range models {
+		if m.GetPrimaryKeyHashableValue() == model.GetPrimaryKeyHashableValue() {
+			return true
+		}
+	}
+	return false
+}
+
+func copyModel(model mapping.Model) mapping.Model {
+	cp := reflect.New(reflect.TypeOf(model).Elem())
+	cp.Elem().Set(reflect.ValueOf(model).Elem())
+	return cp.Interface().(mapping.Model)
+}
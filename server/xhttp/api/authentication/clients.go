@@ -0,0 +1,59 @@
+package authentication
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+)
+
+// ClientAuthenticator is an interface used to authenticate the OAuth2 clients using their credentials.
+// It is used by the endpoints that are accessible only for the registered clients - i.e. token introspection.
+type ClientAuthenticator interface {
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) error
+}
+
+// StaticClients is a ClientAuthenticator that matches the clients with their secrets stored in the map.
+// The keys are client identifiers and the values are client secrets.
+type StaticClients map[string]string
+
+// AuthenticateClient implements ClientAuthenticator interface.
+func (s StaticClients) AuthenticateClient(_ context.Context, clientID, clientSecret string) error {
+	secret, ok := s[clientID]
+	if !ok {
+		return errors.Wrap(auth.ErrInvalidSecret, "client not found")
+	}
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(clientSecret)) != 1 {
+		return errors.Wrap(auth.ErrInvalidSecret, "invalid client secret")
+	}
+	return nil
+}
+
+// getClientCredentials gets the client credentials from the basic authorization header or from the request form.
+func (a *API) getClientCredentials(req *http.Request) (clientID, clientSecret string, ok bool) {
+	if clientID, clientSecret, ok = req.BasicAuth(); ok {
+		return clientID, clientSecret, true
+	}
+	clientID, clientSecret = req.PostForm.Get("client_id"), req.PostForm.Get("client_secret")
+	return clientID, clientSecret, clientID != ""
+}
+
+// authenticateClient authenticates the client that sends the 'req'. If the client is not authorized
+// the function writes an error to the response writer and returns false.
+func (a *API) authenticateClient(rw http.ResponseWriter, req *http.Request) bool {
+	clientID, clientSecret, ok := a.getClientCredentials(req)
+	if ok {
+		if err := a.Options.ClientAuthenticator.AuthenticateClient(req.Context(), clientID, clientSecret); err == nil {
+			return true
+		}
+	}
+	rw.Header().Set("WWW-Authenticate", `Basic realm="client"`)
+	httpError := httputil.ErrInvalidAuthenticationInfo()
+	httpError.Detail = "client authentication failed"
+	a.marshalErrors(rw, http.StatusUnauthorized, httpError)
+	return false
+}
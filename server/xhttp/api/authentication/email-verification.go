@@ -0,0 +1,85 @@
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/neuronlabs/neuron/auth"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// EmailVerifiedAccount is an interface for the accounts that requires the email address verification.
+type EmailVerifiedAccount interface {
+	auth.Account
+	IsEmailVerified() bool
+	SetEmailVerified(verified bool)
+	EmailVerifiedField() string
+}
+
+// EmailVerificationInput is the input that confirms the email verification.
+type EmailVerificationInput struct {
+	Token string `json:"token"`
+}
+
+// handleEmailVerificationRequest sends again the verification token for the authenticated account.
+func (a *API) handleEmailVerificationRequest(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	account, err := a.getAuthenticatedAccount(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	verifiedAccount, ok := account.(EmailVerifiedAccount)
+	if !ok || verifiedAccount.IsEmailVerified() {
+		httpError := httputil.ErrForbiddenOperation()
+		httpError.Detail = "email address is already verified"
+		a.marshalErrors(rw, 0, httpError)
+		return
+	}
+	if err = a.sendEmailVerification(ctx, verifiedAccount); err != nil {
+		log.Errorf("Sending email verification notification failed: %v", err)
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+// handleEmailVerification consumes the verification token and marks the account email as verified.
+func (a *API) handleEmailVerification(rw http.ResponseWriter, req *http.Request) {
+	input := &EmailVerificationInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.Token = q.Get("token")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	ctx := req.Context()
+	account, err := a.consumeAccountToken(ctx, NotificationEmailVerification, input.Token)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	verifiedAccount, ok := account.(EmailVerifiedAccount)
+	if !ok {
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	verifiedAccount.SetEmailVerified(true)
+	if err = a.updateAccountFields(ctx, a.DB, verifiedAccount, verifiedAccount.EmailVerifiedField()); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// sendEmailVerification issues the email verification token for the 'account' and sends it with the notifier.
+func (a *API) sendEmailVerification(ctx context.Context, account EmailVerifiedAccount) error {
+	token, expiresAt, err := a.issueAccountToken(ctx, NotificationEmailVerification, account, a.Options.EmailVerificationExpiration)
+	if err != nil {
+		return err
+	}
+	return a.notify(ctx, NotificationEmailVerification, account, token, expiresAt)
+}
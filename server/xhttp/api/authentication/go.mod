@@ -7,4 +7,8 @@ require (
 	github.com/neuronlabs/neuron v0.20.3
 	github.com/neuronlabs/neuron-extensions/codec/cjson v0.0.4
 	github.com/neuronlabs/neuron-extensions/server/xhttp v0.0.2
+	github.com/stretchr/testify v1.4.0
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
+
+replace github.com/neuronlabs/neuron-extensions/server/xhttp => ../../
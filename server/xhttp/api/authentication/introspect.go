@@ -0,0 +1,96 @@
+package authentication
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// IntrospectOutput is the token introspection response defined in the RFC 7662.
+type IntrospectOutput struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	NotBefore int64  `json:"nbf,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  string `json:"aud,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+}
+
+func (a *API) handleIntrospect(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		a.marshalErrors(rw, 400, errors.WrapDet(codec.ErrUnmarshalDocument, "provided invalid post form").WithDetail("invalid post form"))
+		return
+	}
+	if !a.authenticateClient(rw, req) {
+		return
+	}
+	token := req.PostForm.Get("token")
+	if token == "" {
+		httpError := httputil.ErrMissingRequiredQueryParameter()
+		httpError.Detail = "no 'token' parameter provided"
+		a.marshalErrors(rw, 400, httpError)
+		return
+	}
+
+	output := &IntrospectOutput{}
+	claims, err := a.Controller.Tokener.InspectToken(req.Context(), token)
+	if err == nil {
+		err = claims.Valid()
+	}
+	if err != nil {
+		// Invalid, expired or revoked tokens are not active.
+		log.Debug2f("Introspected token is not active: %v", err)
+	} else {
+		output.Active = true
+		output.Subject = claims.Subject()
+		output.ExpiresAt = claims.ExpiresIn()
+		if accessClaims, ok := claims.(auth.AccessClaims); ok {
+			output.TokenType = "access_token"
+			switch account := accessClaims.GetAccount().(type) {
+			case nil:
+			case clientAccount:
+				// The client tokens are not related with any account.
+				output.ClientID = account.GetUsername()
+			default:
+				output.Username = account.GetUsername()
+			}
+		} else {
+			output.TokenType = "refresh_token"
+		}
+		if scoper, ok := claims.(auth.Scoper); ok {
+			output.Scope = scoper.Scope()
+		}
+		if audiencer, ok := claims.(auth.Audiencer); ok {
+			output.Audience = audiencer.Audience()
+		}
+		if issuer, ok := claims.(auth.Issuer); ok {
+			output.Issuer = issuer.Issuer()
+		}
+		if notBeforer, ok := claims.(auth.NotBeforer); ok {
+			output.NotBefore = notBeforer.NotBefore()
+		}
+	}
+
+	buffer := &bytes.Buffer{}
+	if err = json.NewEncoder(buffer).Encode(output); err != nil {
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(http.StatusOK)
+	if _, err = buffer.WriteTo(rw); err != nil {
+		log.Errorf("Writing to response writer failed: %v", err)
+	}
+}
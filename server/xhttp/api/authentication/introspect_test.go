@@ -0,0 +1,103 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// doClient serves the form request authenticated with the client basic credentials.
+func (e *testEnv) doClient(path, clientID, clientSecret string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if clientID != "" {
+		req.SetBasicAuth(clientID, clientSecret)
+	}
+	rw := httptest.NewRecorder()
+	e.router.ServeHTTP(rw, req)
+	return rw
+}
+
+func TestIntrospect(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, WithClientAuthenticator(StaticClients{"resource-server": "client-secret"}))
+	user := env.addUser(t, "john-doe")
+	token := env.token(t, user)
+
+	introspect := func(t *testing.T, token string) *IntrospectOutput {
+		t.Helper()
+		rw := env.doClient("/auth/introspect", "resource-server", "client-secret", url.Values{"token": {token}})
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+		assert.Equal(t, "no-store", rw.Header().Get("Cache-Control"))
+		output := &IntrospectOutput{}
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(output))
+		return output
+	}
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		rw := env.doClient("/auth/introspect", "", "", url.Values{"token": {token.AccessToken}})
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+		assert.NotEmpty(t, rw.Header().Get("WWW-Authenticate"))
+
+		rw = env.doClient("/auth/introspect", "resource-server", "invalid", url.Values{"token": {token.AccessToken}})
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+
+	t.Run("MissingToken", func(t *testing.T) {
+		rw := env.doClient("/auth/introspect", "resource-server", "client-secret", nil)
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("AccessToken", func(t *testing.T) {
+		output := introspect(t, token.AccessToken)
+		assert.True(t, output.Active)
+		assert.Equal(t, "access_token", output.TokenType)
+		assert.Equal(t, "1", output.Subject)
+		assert.NotZero(t, output.ExpiresAt)
+		assert.Empty(t, output.ClientID)
+	})
+
+	t.Run("RefreshToken", func(t *testing.T) {
+		output := introspect(t, token.RefreshToken)
+		assert.True(t, output.Active)
+		assert.Equal(t, "refresh_token", output.TokenType)
+	})
+
+	t.Run("Inactive", func(t *testing.T) {
+		output := introspect(t, "unknown")
+		assert.Equal(t, &IntrospectOutput{}, output)
+
+		revoked := env.token(t, user)
+		require.NoError(t, env.tokener.RevokeToken(ctx, revoked.AccessToken))
+		output = introspect(t, revoked.AccessToken)
+		assert.False(t, output.Active)
+		assert.Empty(t, output.Subject)
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, WithClientAuthenticator(StaticClients{"resource-server": "client-secret"}))
+	user := env.addUser(t, "john-doe")
+	token := env.token(t, user)
+
+	rw := env.doClient("/auth/revoke", "resource-server", "invalid", url.Values{"token": {token.AccessToken}})
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	assert.False(t, env.tokener.isRevoked(ctx, token.AccessToken))
+
+	rw = env.doClient("/auth/revoke", "resource-server", "client-secret", url.Values{"token": {token.AccessToken}})
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	assert.True(t, env.tokener.isRevoked(ctx, token.AccessToken))
+	assert.True(t, env.tokener.isRevoked(ctx, token.RefreshToken))
+
+	// Revoking an invalid token is not reported to the client.
+	rw = env.doClient("/auth/revoke", "resource-server", "client-secret", url.Values{"token": {"unknown"}})
+	assert.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+}
@@ -0,0 +1,261 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// LockoutPolicy is the brute-force protection policy for the login endpoint.
+// The failed login attempts are counted per username and per client IP within the Window. When the number of failures
+// reaches given maximum, the username (or IP) is locked for the LockoutDuration. Each subsequent lock doubles
+// the lock duration up to the MaxLockoutDuration. The state is kept in the API store.Store, so that it could be shared
+// between multiple instances using i.e. redis store.
+type LockoutPolicy struct {
+	// MaxUsernameFailures is the maximum number of failed logins for a single username within the window.
+	// Zero value disables the username lockout.
+	MaxUsernameFailures int
+	// MaxIPFailures is the maximum number of failed logins from a single IP address within the window.
+	// Zero value disables the IP lockout.
+	MaxIPFailures int
+	// Window is the time window in which the failures are counted.
+	Window time.Duration
+	// LockoutDuration is the duration of the first lock.
+	LockoutDuration time.Duration
+	// MaxLockoutDuration is the maximum lock duration reached by the exponential backoff.
+	MaxLockoutDuration time.Duration
+	// ClientIP gets the client IP address from the request. By default the host of the request RemoteAddr is used.
+	ClientIP func(req *http.Request) string
+}
+
+// DefaultLockoutPolicy returns the default lockout policy.
+func DefaultLockoutPolicy() *LockoutPolicy {
+	return &LockoutPolicy{
+		MaxUsernameFailures: 5,
+		MaxIPFailures:       20,
+		Window:              time.Minute * 15,
+		LockoutDuration:     time.Minute,
+		MaxLockoutDuration:  time.Hour,
+	}
+}
+
+// lockoutState is the store value of the failed login attempts for given username or IP. If the store supports
+// atomic counters, the failures are counted in a separate counter and the state holds only the lock.
+type lockoutState struct {
+	Failures    int       `json:"failures"`
+	WindowStart time.Time `json:"window_start"`
+	// Locks is the number of locks applied since the last successful login - used by the exponential backoff.
+	Locks       int       `json:"locks"`
+	LockedUntil time.Time `json:"locked_until,omitempty"`
+}
+
+// checkLockout checks if either the 'username' or the request client IP is locked. If it is, returns the duration
+// after which the login could be retried.
+func (a *API) checkLockout(ctx context.Context, req *http.Request, username string) (time.Duration, bool, error) {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, target := range a.lockoutTargets(req, username) {
+		state, err := a.getLockoutState(ctx, target.key)
+		if err != nil {
+			return 0, false, err
+		}
+		if state == nil {
+			continue
+		}
+		if d := state.LockedUntil.Sub(now); d > retryAfter {
+			retryAfter = d
+		}
+	}
+	return retryAfter, retryAfter > 0, nil
+}
+
+// registerLoginFailure increases the failures counters for the 'username' and the request client IP.
+// If the store supports atomic counters the failures are counted atomically, so that the concurrent failed logins
+// could not exceed the limit.
+func (a *API) registerLoginFailure(ctx context.Context, req *http.Request, username string) {
+	now := time.Now()
+	for _, target := range a.lockoutTargets(req, username) {
+		var err error
+		if counter, ok := a.Options.Store.(counterStore); ok {
+			err = a.countLoginFailure(ctx, counter, target, now)
+		} else {
+			err = a.registerTargetFailure(ctx, target, now)
+		}
+		if err != nil {
+			log.Errorf("Registering login failure failed: %v", err)
+		}
+	}
+}
+
+// countLoginFailure increments the failures counter of the 'target'. The counter expires after the policy window
+// since the first failure. Only the failure that reaches the limit locks the target, thus the lock state is not
+// changed concurrently.
+func (a *API) countLoginFailure(ctx context.Context, counter counterStore, target lockoutTarget, now time.Time) error {
+	policy := a.Options.LockoutPolicy
+	failures, err := counter.Increment(ctx, lockoutFailuresKey(target.key), 1, store.SetWithTTL(policy.Window))
+	if err != nil {
+		return err
+	}
+	if failures != int64(target.maxFailures) {
+		return nil
+	}
+	if err = a.Options.Store.Delete(ctx, lockoutFailuresKey(target.key)); err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+		return err
+	}
+	state, err := a.getLockoutState(ctx, target.key)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &lockoutState{}
+	}
+	state.LockedUntil = now.Add(policy.lockDuration(state.Locks))
+	state.Locks++
+	return a.setLockoutState(ctx, target.key, state)
+}
+
+// registerTargetFailure increases the failures of the 'target' stored in its lockout state.
+func (a *API) registerTargetFailure(ctx context.Context, target lockoutTarget, now time.Time) error {
+	policy := a.Options.LockoutPolicy
+	state, err := a.getLockoutState(ctx, target.key)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &lockoutState{}
+	}
+	if now.Sub(state.WindowStart) > policy.Window {
+		state.Failures = 0
+		state.WindowStart = now
+	}
+	state.Failures++
+	if state.Failures >= target.maxFailures {
+		state.LockedUntil = now.Add(policy.lockDuration(state.Locks))
+		state.Locks++
+		state.Failures = 0
+		state.WindowStart = now
+	}
+	return a.setLockoutState(ctx, target.key, state)
+}
+
+// resetLoginFailures clears the failures state of given 'username' after successful login.
+func (a *API) resetLoginFailures(ctx context.Context, username string) {
+	if a.Options.LockoutPolicy.MaxUsernameFailures == 0 {
+		return
+	}
+	key := lockoutKey("user", username)
+	keys := []string{key}
+	if _, ok := a.Options.Store.(counterStore); ok {
+		keys = append(keys, lockoutFailuresKey(key))
+	}
+	for _, key := range keys {
+		if err := a.Options.Store.Delete(ctx, key); err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+			log.Errorf("Deleting lockout state failed: %v", err)
+		}
+	}
+}
+
+// writeLockedOut writes the too many requests error with the Retry-After header.
+func (a *API) writeLockedOut(rw http.ResponseWriter, retryAfter time.Duration) {
+	rw.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	httpError := httputil.ErrTooManyOperationsPerAccount()
+	httpError.Status = strconv.Itoa(http.StatusTooManyRequests)
+	httpError.Detail = "Too many failed login attempts. Try again later."
+	a.marshalErrors(rw, http.StatusTooManyRequests, httpError)
+}
+
+// lockoutTarget is the username or client IP lockout store key along with its failures limit.
+type lockoutTarget struct {
+	key         string
+	maxFailures int
+}
+
+// lockoutTargets returns the username and client IP lockout targets. The disabled lockouts are omitted.
+func (a *API) lockoutTargets(req *http.Request, username string) []lockoutTarget {
+	policy := a.Options.LockoutPolicy
+	var targets []lockoutTarget
+	if policy.MaxUsernameFailures > 0 && username != "" {
+		targets = append(targets, lockoutTarget{key: lockoutKey("user", username), maxFailures: policy.MaxUsernameFailures})
+	}
+	if policy.MaxIPFailures > 0 {
+		if ip := policy.clientIP(req); ip != "" {
+			targets = append(targets, lockoutTarget{key: lockoutKey("ip", ip), maxFailures: policy.MaxIPFailures})
+		}
+	}
+	return targets
+}
+
+func (a *API) getLockoutState(ctx context.Context, key string) (*lockoutState, error) {
+	record, err := a.Options.Store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	state := &lockoutState{}
+	if err = json.Unmarshal(record.Value, state); err != nil {
+		return nil, errors.Wrapf(auth.ErrInternalError, "unmarshal lockout state failed: %v", err)
+	}
+	return state, nil
+}
+
+func (a *API) setLockoutState(ctx context.Context, key string, state *lockoutState) error {
+	value, err := json.Marshal(state)
+	if err != nil {
+		return errors.Wrapf(auth.ErrInternalError, "marshal lockout state failed: %v", err)
+	}
+	// The state needs to be kept as long as the window or the lock lasts. The number of locks is reset
+	// after the maximum lockout duration passes without any failure.
+	ttl := a.Options.LockoutPolicy.Window
+	if d := time.Until(state.LockedUntil) + a.Options.LockoutPolicy.MaxLockoutDuration; d > ttl {
+		ttl = d
+	}
+	return a.Options.Store.Set(ctx, &store.Record{Key: key, Value: value}, store.SetWithTTL(ttl))
+}
+
+func (p *LockoutPolicy) lockDuration(locks int) time.Duration {
+	d := p.LockoutDuration
+	for i := 0; i < locks; i++ {
+		d *= 2
+		if p.MaxLockoutDuration > 0 && d >= p.MaxLockoutDuration {
+			return p.MaxLockoutDuration
+		}
+	}
+	return d
+}
+
+func (p *LockoutPolicy) clientIP(req *http.Request) string {
+	if p.ClientIP != nil {
+		return p.ClientIP(req)
+	}
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+	return host
+}
+
+func lockoutKey(kind, value string) string {
+	h := sha256.Sum256([]byte(strings.ToLower(value)))
+	return "nrn_login_lockout_" + kind + "_" + hex.EncodeToString(h[:])
+}
+
+// lockoutFailuresKey gets the key of the failures counter of the lockout state with provided 'key'.
+func lockoutFailuresKey(key string) string {
+	return key + "_failures"
+}
@@ -0,0 +1,97 @@
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/store"
+)
+
+// wrongPassword is the valid password that doesn't match the testPassword.
+const wrongPassword = "Wrong-Password1!"
+
+// plainStore is the store.Store that doesn't support atomic counters.
+type plainStore struct {
+	store.Store
+}
+
+func testLockoutPolicy() *LockoutPolicy {
+	return &LockoutPolicy{
+		MaxUsernameFailures: 3,
+		Window:              time.Minute,
+		LockoutDuration:     time.Minute,
+		MaxLockoutDuration:  time.Hour,
+	}
+}
+
+func TestLoginLockout(t *testing.T) {
+	for name, storeOption := range map[string]func(s *testStore) Option{
+		"Atomic":    func(s *testStore) Option { return WithStore(s) },
+		"NonAtomic": func(s *testStore) Option { return WithStore(&plainStore{Store: s}) },
+	} {
+		storeOption := storeOption
+		t.Run(name, func(t *testing.T) {
+			newEnv := func(t *testing.T) *testEnv {
+				s := &testStore{records: map[string]*store.Record{}}
+				env := newTestEnv(t, WithLockoutPolicy(testLockoutPolicy()), storeOption(s))
+				env.store = s
+				env.addUser(t, "john-doe")
+				return env
+			}
+			login := func(env *testEnv, password string) int {
+				return env.do(http.MethodPost, "/auth/login", "", url.Values{"username": {"john-doe"}, "password": {password}}).Code
+			}
+
+			t.Run("Locked", func(t *testing.T) {
+				env := newEnv(t)
+				for i := 0; i < 3; i++ {
+					assert.Equal(t, http.StatusUnauthorized, login(env, wrongPassword))
+				}
+				rw := env.do(http.MethodPost, "/auth/login", "", url.Values{"username": {"john-doe"}, "password": {testPassword}})
+				assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+				assert.Equal(t, "60", rw.Header().Get("Retry-After"))
+			})
+
+			t.Run("Reset", func(t *testing.T) {
+				env := newEnv(t)
+				for i := 0; i < 2; i++ {
+					assert.Equal(t, http.StatusUnauthorized, login(env, wrongPassword))
+				}
+				assert.Equal(t, http.StatusCreated, login(env, testPassword))
+				for i := 0; i < 2; i++ {
+					assert.Equal(t, http.StatusUnauthorized, login(env, wrongPassword))
+				}
+				assert.Equal(t, http.StatusCreated, login(env, testPassword))
+			})
+		})
+	}
+
+	t.Run("Concurrent", func(t *testing.T) {
+		env := newTestEnv(t, WithLockoutPolicy(testLockoutPolicy()))
+		env.addUser(t, "john-doe")
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				env.do(http.MethodPost, "/auth/login", "", url.Values{"username": {"john-doe"}, "password": {wrongPassword}})
+			}()
+		}
+		wg.Wait()
+
+		// The concurrent failures lock the account exactly once.
+		state, err := env.api.getLockoutState(context.Background(), lockoutKey("user", "john-doe"))
+		require.NoError(t, err)
+		require.NotNil(t, state)
+		assert.Equal(t, 1, state.Locks)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), state.LockedUntil, time.Second)
+	})
+}
@@ -1,7 +1,6 @@
 package authentication
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"io/ioutil"
@@ -68,10 +67,11 @@ type LoginInput struct {
 // LoginOutput is the successful login output structure.
 type LoginOutput struct {
 	Meta         codec.Meta `json:"meta,omitempty"`
-	AccessToken  string     `json:"access_token"`
+	AccessToken  string     `json:"access_token,omitempty"`
 	RefreshToken string     `json:"refresh_token,omitempty"`
 	TokenType    string     `json:"token_type,omitempty"`
 	ExpiresIn    int64      `json:"expires_in,omitempty"`
+	Scope        string     `json:"scope,omitempty"`
 }
 
 func (a *API) handleLoginEndpoint(rw http.ResponseWriter, req *http.Request) {
@@ -134,6 +134,20 @@ func (a *API) handleLoginEndpoint(rw http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	// Check if the username or the client is not locked out after too many failed login attempts.
+	if a.Options.LockoutPolicy != nil {
+		retryAfter, locked, err := a.checkLockout(req.Context(), req, input.Username)
+		if err != nil {
+			log.Errorf("Checking login lockout failed: %v", err)
+			a.marshalErrors(rw, 500, httputil.ErrInternalError())
+			return
+		}
+		if locked {
+			a.writeLockedOut(rw, retryAfter)
+			return
+		}
+	}
+
 	// Validate password.
 	neuronPassword := auth.NewPassword(input.Password, a.Options.PasswordScorer)
 	if a.Options.PasswordValidator != nil {
@@ -202,6 +216,9 @@ func (a *API) handleLoginEndpoint(rw http.ResponseWriter, req *http.Request) {
 			}
 		}
 		if errors.Is(err, query.ErrNoResult) {
+			if a.Options.LockoutPolicy != nil {
+				a.registerLoginFailure(ctx, req, input.Username)
+			}
 			if loginFailer, ok := a.Options.AccountHandler.(AfterLoginer); ok {
 				if err = loginFailer.AfterLogin(ctx, a.DB, options); err != nil {
 					a.marshalErrors(rw, 0, err)
@@ -224,6 +241,9 @@ func (a *API) handleLoginEndpoint(rw http.ResponseWriter, req *http.Request) {
 				log.Errorf("Rolling back transaction failed: %v", err)
 			}
 		}
+		if a.Options.LockoutPolicy != nil {
+			a.registerLoginFailure(ctx, req, input.Username)
+		}
 		if loginFailer, ok := a.Options.AccountHandler.(AfterLoginer); ok {
 			if err = loginFailer.AfterLogin(ctx, a.DB, options); err != nil {
 				a.marshalErrors(rw, 0, err)
@@ -257,13 +277,49 @@ func (a *API) handleLoginEndpoint(rw http.ResponseWriter, req *http.Request) {
 		}
 	}
 
+	// Transparently upgrade outdated password hashes.
+	a.rehashPassword(ctx, options.Account, neuronPassword)
+
+	// Accounts with enabled second factor needs to pass the two factor challenge before obtaining the tokens.
+	// The login failures are reset after the second factor is verified.
+	if totpAccount, ok := options.Account.(TOTPAccount); ok && totpAccount.IsTOTPEnabled() {
+		a.writeTwoFactorChallenge(ctx, rw, options.Account, input.RememberToken)
+		return
+	}
+	if a.Options.LockoutPolicy != nil {
+		a.resetLoginFailures(ctx, input.Username)
+	}
+	a.writeLoginTokens(ctx, rw, options.Account, input.RememberToken)
+}
+
+// PasswordRehasher is an interface implemented by the authenticators that could check if the account password hash
+// was created using outdated algorithm or parameters.
+type PasswordRehasher interface {
+	NeedsRehash(account auth.Account) bool
+}
+
+// rehashPassword hashes the 'password' again if the controller's authenticator marks account's password hash as outdated.
+// The new hash is persisted using account's PasswordHashField (and the SaltField if the account implements
+// auth.SaltFielder). Any failure is only logged as the account had already been authenticated.
+func (a *API) rehashPassword(ctx context.Context, account auth.Account, password *auth.Password) {
+	rehasher, ok := a.Controller.Authenticator.(PasswordRehasher)
+	if !ok || !rehasher.NeedsRehash(account) {
+		return
+	}
+	if err := a.setAccountPassword(ctx, a.DB, account, password); err != nil {
+		log.Errorf("Rehashing account password failed: %v", err)
+	}
+}
+
+// writeLoginTokens creates the access and refresh tokens for provided 'account' and writes them to the response writer.
+func (a *API) writeLoginTokens(ctx context.Context, rw http.ResponseWriter, account auth.Account, rememberToken bool) {
 	expiration := a.Options.TokenExpiration
-	if input.RememberToken {
+	if rememberToken {
 		expiration = a.Options.RememberTokenExpiration
 	}
 
 	// Create the token for provided account.
-	token, err := a.Controller.Tokener.Token(ctx, options.Account,
+	token, err := a.Controller.Tokener.Token(ctx, account,
 		auth.TokenExpirationTime(expiration),
 		auth.TokenRefreshExpirationTime(a.Options.RefreshTokenExpiration),
 	)
@@ -272,21 +328,5 @@ func (a *API) handleLoginEndpoint(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	output := &LoginOutput{
-		AccessToken:  token.AccessToken,
-		RefreshToken: token.RefreshToken,
-		TokenType:    token.TokenType,
-		ExpiresIn:    int64(token.ExpiresIn),
-	}
-
-	a.setContentType(rw)
-	buffer := &bytes.Buffer{}
-	if err = json.NewEncoder(buffer).Encode(output); err != nil {
-		a.marshalErrors(rw, 500, httputil.ErrInternalError())
-		return
-	}
-	rw.WriteHeader(http.StatusCreated)
-	if _, err = buffer.WriteTo(rw); err != nil {
-		log.Errorf("Writing to response writer failed: %v", err)
-	}
+	a.writeTokenOutput(rw, token)
 }
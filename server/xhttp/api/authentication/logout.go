@@ -11,7 +11,7 @@ import (
 )
 
 func (a *API) handleLogout(rw http.ResponseWriter, req *http.Request) {
-	token, err := a.getBearerToken(req)
+	token, err := a.getRequestToken(req, a.accessTokenCookieName())
 	if err != nil {
 		a.marshalErrors(rw, 401, err)
 		return
@@ -44,6 +44,9 @@ func (a *API) handleLogout(rw http.ResponseWriter, req *http.Request) {
 		a.marshalErrors(rw, 0, err)
 		return
 	}
+	if a.Options.Cookies != nil {
+		a.clearSessionCookies(rw)
+	}
 	rw.WriteHeader(http.StatusNoContent)
 }
 
@@ -0,0 +1,1273 @@
+// Code generated by neurogonesis. DO NOT EDIT.
+// This file was generated at:
+// Mon, 19 Oct 2026 06:31:46 +0000
+
+package authentication
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// Neuron_Models stores all generated models in this package.
+var Neuron_Models = []mapping.Model{
+	&AccessKey{},
+	&ServiceClient{},
+	&User{},
+}
+
+// Compile time check if AccessKey implements mapping.Model interface.
+var _ mapping.Model = &AccessKey{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (a *AccessKey) IsPrimaryKeyZero() bool {
+	return a.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (a *AccessKey) GetPrimaryKeyValue() interface{} {
+	return a.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (a *AccessKey) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(a.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (a *AccessKey) GetPrimaryKeyAddress() interface{} {
+	return &a.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (a *AccessKey) GetPrimaryKeyHashableValue() interface{} {
+	return a.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (a *AccessKey) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (a *AccessKey) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(int); ok {
+		a.ID = _v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch _valueType := value.(type) {
+	case int8:
+		a.ID = int(_valueType)
+	case int16:
+		a.ID = int(_valueType)
+	case int32:
+		a.ID = int(_valueType)
+	case int64:
+		a.ID = int(_valueType)
+	case uint:
+		a.ID = int(_valueType)
+	case uint8:
+		a.ID = int(_valueType)
+	case uint16:
+		a.ID = int(_valueType)
+	case uint32:
+		a.ID = int(_valueType)
+	case uint64:
+		a.ID = int(_valueType)
+	case float32:
+		a.ID = int(_valueType)
+	case float64:
+		a.ID = int(_valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'AccessKey'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (a *AccessKey) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	a.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (a *AccessKey) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrModelNotMatch, "provided nil model to set from")
+	}
+	from, ok := model.(*AccessKey)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*a = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (a *AccessKey) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return a.ID, nil
+	case 1: // AccountID
+		return a.AccountID, nil
+	case 2: // Name
+		return a.Name, nil
+	case 3: // KeyPrefix
+		return a.KeyPrefix, nil
+	case 4: // KeyHash
+		return a.KeyHash, nil
+	case 5: // Scopes
+		return a.Scopes, nil
+	case 6: // ExpiresAt
+		return a.ExpiresAt, nil
+	case 7: // LastUsedAt
+		return a.LastUsedAt, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: AccessKey'", field.Name())
+	}
+}
+
+// Compile time check if AccessKey implements mapping.Fielder interface.
+var _ mapping.Fielder = &AccessKey{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (a *AccessKey) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &a.ID, nil
+	case 1: // AccountID
+		return &a.AccountID, nil
+	case 2: // Name
+		return &a.Name, nil
+	case 3: // KeyPrefix
+		return &a.KeyPrefix, nil
+	case 4: // KeyHash
+		return &a.KeyHash, nil
+	case 5: // Scopes
+		return &a.Scopes, nil
+	case 6: // ExpiresAt
+		return &a.ExpiresAt, nil
+	case 7: // LastUsedAt
+		return &a.LastUsedAt, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: AccessKey'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (a *AccessKey) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // AccountID
+		return "", nil
+	case 2: // Name
+		return "", nil
+	case 3: // KeyPrefix
+		return "", nil
+	case 4: // KeyHash
+		return nil, nil
+	case 5: // Scopes
+		return nil, nil
+	case 6: // ExpiresAt
+		return nil, nil
+	case 7: // LastUsedAt
+		return nil, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (a *AccessKey) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return a.ID == 0, nil
+	case 1: // AccountID
+		return a.AccountID == "", nil
+	case 2: // Name
+		return a.Name == "", nil
+	case 3: // KeyPrefix
+		return a.KeyPrefix == "", nil
+	case 4: // KeyHash
+		return len(a.KeyHash) == 0, nil
+	case 5: // Scopes
+		return len(a.Scopes) == 0, nil
+	case 6: // ExpiresAt
+		return a.ExpiresAt == nil, nil
+	case 7: // LastUsedAt
+		return a.LastUsedAt == nil, nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (a *AccessKey) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		a.ID = 0
+	case 1: // AccountID
+		a.AccountID = ""
+	case 2: // Name
+		a.Name = ""
+	case 3: // KeyPrefix
+		a.KeyPrefix = ""
+	case 4: // KeyHash
+		a.KeyHash = nil
+	case 5: // Scopes
+		a.Scopes = nil
+	case 6: // ExpiresAt
+		a.ExpiresAt = nil
+	case 7: // LastUsedAt
+		a.LastUsedAt = nil
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (a *AccessKey) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return a.ID, nil
+	case 1: // AccountID
+		return a.AccountID, nil
+	case 2: // Name
+		return a.Name, nil
+	case 3: // KeyPrefix
+		return a.KeyPrefix, nil
+	case 4: // KeyHash
+		return string(a.KeyHash), nil
+	case 5: // Scopes
+		return a.Scopes, nil
+	case 6: // ExpiresAt
+		if a.ExpiresAt == nil {
+			return nil, nil
+		}
+		return *a.ExpiresAt, nil
+	case 7: // LastUsedAt
+		if a.LastUsedAt == nil {
+			return nil, nil
+		}
+		return *a.LastUsedAt, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'AccessKey'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (a *AccessKey) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return a.ID, nil
+	case 1: // AccountID
+		return a.AccountID, nil
+	case 2: // Name
+		return a.Name, nil
+	case 3: // KeyPrefix
+		return a.KeyPrefix, nil
+	case 4: // KeyHash
+		return a.KeyHash, nil
+	case 5: // Scopes
+		return a.Scopes, nil
+	case 6: // ExpiresAt
+		return a.ExpiresAt, nil
+	case 7: // LastUsedAt
+		return a.LastUsedAt, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: AccessKey'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (a *AccessKey) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(int); ok {
+			a.ID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			a.ID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			a.ID = int(_v)
+		case int16:
+			a.ID = int(_v)
+		case int32:
+			a.ID = int(_v)
+		case int64:
+			a.ID = int(_v)
+		case uint:
+			a.ID = int(_v)
+		case uint8:
+			a.ID = int(_v)
+		case uint16:
+			a.ID = int(_v)
+		case uint32:
+			a.ID = int(_v)
+		case uint64:
+			a.ID = int(_v)
+		case float32:
+			a.ID = int(_v)
+		case float64:
+			a.ID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // AccountID
+		if _v, ok := value.(string); ok {
+			a.AccountID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			a.AccountID = ""
+			return nil
+		}
+
+		// Check alternate types for the AccountID.
+		if _v, ok := value.([]byte); ok {
+			a.AccountID = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // Name
+		if _v, ok := value.(string); ok {
+			a.Name = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			a.Name = ""
+			return nil
+		}
+
+		// Check alternate types for the Name.
+		if _v, ok := value.([]byte); ok {
+			a.Name = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 3: // KeyPrefix
+		if _v, ok := value.(string); ok {
+			a.KeyPrefix = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			a.KeyPrefix = ""
+			return nil
+		}
+
+		// Check alternate types for the KeyPrefix.
+		if _v, ok := value.([]byte); ok {
+			a.KeyPrefix = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 4: // KeyHash
+		if value == nil {
+			a.KeyHash = nil
+			return nil
+		}
+		if _v, ok := value.([]byte); ok {
+			a.KeyHash = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(byte); ok {
+					a.KeyHash = append(a.KeyHash, _v)
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Check alternate types for the KeyHash.
+		if _v, ok := value.(string); ok {
+			a.KeyHash = []byte(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 5: // Scopes
+		if value == nil {
+			a.Scopes = nil
+			return nil
+		}
+		if _v, ok := value.([]string); ok {
+			a.Scopes = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(string); ok {
+					a.Scopes = append(a.Scopes, _v)
+					continue
+				}
+				// Check alternate types for the Scopes.
+				if _v, ok := item.([]byte); ok {
+					a.Scopes = append(a.Scopes, string(_v))
+					continue
+				}
+				return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+			}
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 6: // ExpiresAt
+		if value == nil {
+			a.ExpiresAt = nil
+			return nil
+		}
+		if _v, ok := value.(*time.Time); ok {
+			a.ExpiresAt = _v
+			return nil
+		}
+		// Check if it is non-pointer value.
+		if _v, ok := value.(time.Time); ok {
+			a.ExpiresAt = &_v
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 7: // LastUsedAt
+		if value == nil {
+			a.LastUsedAt = nil
+			return nil
+		}
+		if _v, ok := value.(*time.Time); ok {
+			a.LastUsedAt = _v
+			return nil
+		}
+		// Check if it is non-pointer value.
+		if _v, ok := value.(time.Time); ok {
+			a.LastUsedAt = &_v
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'AccessKey'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (a *AccessKey) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // AccountID
+		return value, nil
+	case 2: // Name
+		return value, nil
+	case 3: // KeyPrefix
+		return value, nil
+	case 4: // KeyHash
+		return "", errors.Wrap(mapping.ErrFieldNotParser, "field 'KeyHash' doesn't have string setter.")
+	case 5: // Scopes
+		return value, nil
+	case 6: // ExpiresAt
+		var base time.Time
+		temp := &base
+		if err := temp.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'ExpiresAt' value: '%v' to parse string. Err: %v", a.ExpiresAt, err)
+		}
+		bt, err := temp.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'ExpiresAt' value: '%v' to parse string. Err: %v", a.ExpiresAt, err)
+		}
+
+		return string(bt), nil
+	case 7: // LastUsedAt
+		var base time.Time
+		temp := &base
+		if err := temp.UnmarshalText([]byte(value)); err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'LastUsedAt' value: '%v' to parse string. Err: %v", a.LastUsedAt, err)
+		}
+		bt, err := temp.MarshalText()
+		if err != nil {
+			return "", errors.Wrapf(mapping.ErrFieldValue, "invalid field 'LastUsedAt' value: '%v' to parse string. Err: %v", a.LastUsedAt, err)
+		}
+
+		return string(bt), nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: AccessKey'", field.Name())
+}
+
+// Compile time check if ServiceClient implements mapping.Model interface.
+var _ mapping.Model = &ServiceClient{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (s *ServiceClient) IsPrimaryKeyZero() bool {
+	return s.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (s *ServiceClient) GetPrimaryKeyValue() interface{} {
+	return s.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (s *ServiceClient) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(s.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (s *ServiceClient) GetPrimaryKeyAddress() interface{} {
+	return &s.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (s *ServiceClient) GetPrimaryKeyHashableValue() interface{} {
+	return s.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (s *ServiceClient) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (s *ServiceClient) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(int); ok {
+		s.ID = _v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch _valueType := value.(type) {
+	case int8:
+		s.ID = int(_valueType)
+	case int16:
+		s.ID = int(_valueType)
+	case int32:
+		s.ID = int(_valueType)
+	case int64:
+		s.ID = int(_valueType)
+	case uint:
+		s.ID = int(_valueType)
+	case uint8:
+		s.ID = int(_valueType)
+	case uint16:
+		s.ID = int(_valueType)
+	case uint32:
+		s.ID = int(_valueType)
+	case uint64:
+		s.ID = int(_valueType)
+	case float32:
+		s.ID = int(_valueType)
+	case float64:
+		s.ID = int(_valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'ServiceClient'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (s *ServiceClient) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	s.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (s *ServiceClient) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrModelNotMatch, "provided nil model to set from")
+	}
+	from, ok := model.(*ServiceClient)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*s = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (s *ServiceClient) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return s.ID, nil
+	case 1: // ClientID
+		return s.ClientID, nil
+	case 2: // SecretHash
+		return s.SecretHash, nil
+	case 3: // Scopes
+		return s.Scopes, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: ServiceClient'", field.Name())
+	}
+}
+
+// Compile time check if ServiceClient implements mapping.Fielder interface.
+var _ mapping.Fielder = &ServiceClient{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (s *ServiceClient) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &s.ID, nil
+	case 1: // ClientID
+		return &s.ClientID, nil
+	case 2: // SecretHash
+		return &s.SecretHash, nil
+	case 3: // Scopes
+		return &s.Scopes, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: ServiceClient'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (s *ServiceClient) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // ClientID
+		return "", nil
+	case 2: // SecretHash
+		return nil, nil
+	case 3: // Scopes
+		return "", nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (s *ServiceClient) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return s.ID == 0, nil
+	case 1: // ClientID
+		return s.ClientID == "", nil
+	case 2: // SecretHash
+		return len(s.SecretHash) == 0, nil
+	case 3: // Scopes
+		return s.Scopes == "", nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (s *ServiceClient) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		s.ID = 0
+	case 1: // ClientID
+		s.ClientID = ""
+	case 2: // SecretHash
+		s.SecretHash = nil
+	case 3: // Scopes
+		s.Scopes = ""
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (s *ServiceClient) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return s.ID, nil
+	case 1: // ClientID
+		return s.ClientID, nil
+	case 2: // SecretHash
+		return string(s.SecretHash), nil
+	case 3: // Scopes
+		return s.Scopes, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'ServiceClient'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (s *ServiceClient) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return s.ID, nil
+	case 1: // ClientID
+		return s.ClientID, nil
+	case 2: // SecretHash
+		return s.SecretHash, nil
+	case 3: // Scopes
+		return s.Scopes, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: ServiceClient'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (s *ServiceClient) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(int); ok {
+			s.ID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			s.ID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			s.ID = int(_v)
+		case int16:
+			s.ID = int(_v)
+		case int32:
+			s.ID = int(_v)
+		case int64:
+			s.ID = int(_v)
+		case uint:
+			s.ID = int(_v)
+		case uint8:
+			s.ID = int(_v)
+		case uint16:
+			s.ID = int(_v)
+		case uint32:
+			s.ID = int(_v)
+		case uint64:
+			s.ID = int(_v)
+		case float32:
+			s.ID = int(_v)
+		case float64:
+			s.ID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // ClientID
+		if _v, ok := value.(string); ok {
+			s.ClientID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			s.ClientID = ""
+			return nil
+		}
+
+		// Check alternate types for the ClientID.
+		if _v, ok := value.([]byte); ok {
+			s.ClientID = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // SecretHash
+		if value == nil {
+			s.SecretHash = nil
+			return nil
+		}
+		if _v, ok := value.([]byte); ok {
+			s.SecretHash = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(byte); ok {
+					s.SecretHash = append(s.SecretHash, _v)
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Check alternate types for the SecretHash.
+		if _v, ok := value.(string); ok {
+			s.SecretHash = []byte(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 3: // Scopes
+		if _v, ok := value.(string); ok {
+			s.Scopes = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			s.Scopes = ""
+			return nil
+		}
+
+		// Check alternate types for the Scopes.
+		if _v, ok := value.([]byte); ok {
+			s.Scopes = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'ServiceClient'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (s *ServiceClient) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // ClientID
+		return value, nil
+	case 2: // SecretHash
+		return "", errors.Wrap(mapping.ErrFieldNotParser, "field 'SecretHash' doesn't have string setter.")
+	case 3: // Scopes
+		return value, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: ServiceClient'", field.Name())
+}
+
+// Compile time check if User implements mapping.Model interface.
+var _ mapping.Model = &User{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (u *User) IsPrimaryKeyZero() bool {
+	return u.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyValue() interface{} {
+	return u.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(u.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyAddress() interface{} {
+	return &u.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyHashableValue() interface{} {
+	return u.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (u *User) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(int); ok {
+		u.ID = _v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch _valueType := value.(type) {
+	case int8:
+		u.ID = int(_valueType)
+	case int16:
+		u.ID = int(_valueType)
+	case int32:
+		u.ID = int(_valueType)
+	case int64:
+		u.ID = int(_valueType)
+	case uint:
+		u.ID = int(_valueType)
+	case uint8:
+		u.ID = int(_valueType)
+	case uint16:
+		u.ID = int(_valueType)
+	case uint32:
+		u.ID = int(_valueType)
+	case uint64:
+		u.ID = int(_valueType)
+	case float32:
+		u.ID = int(_valueType)
+	case float64:
+		u.ID = int(_valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'User'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	u.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (u *User) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrModelNotMatch, "provided nil model to set from")
+	}
+	from, ok := model.(*User)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*u = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (u *User) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID, nil
+	case 1: // Username
+		return u.Username, nil
+	case 2: // PasswordHash
+		return u.PasswordHash, nil
+	case 3: // TOTPSecret
+		return u.TOTPSecret, nil
+	case 4: // TOTPEnabled
+		return u.TOTPEnabled, nil
+	case 5: // RecoveryCodes
+		return u.RecoveryCodes, nil
+	case 6: // Email
+		return u.Email, nil
+	case 7: // EmailVerified
+		return u.EmailVerified, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+	}
+}
+
+// Compile time check if User implements mapping.Fielder interface.
+var _ mapping.Fielder = &User{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (u *User) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &u.ID, nil
+	case 1: // Username
+		return &u.Username, nil
+	case 2: // PasswordHash
+		return &u.PasswordHash, nil
+	case 3: // TOTPSecret
+		return &u.TOTPSecret, nil
+	case 4: // TOTPEnabled
+		return &u.TOTPEnabled, nil
+	case 5: // RecoveryCodes
+		return &u.RecoveryCodes, nil
+	case 6: // Email
+		return &u.Email, nil
+	case 7: // EmailVerified
+		return &u.EmailVerified, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (u *User) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // Username
+		return "", nil
+	case 2: // PasswordHash
+		return nil, nil
+	case 3: // TOTPSecret
+		return "", nil
+	case 4: // TOTPEnabled
+		return false, nil
+	case 5: // RecoveryCodes
+		return nil, nil
+	case 6: // Email
+		return "", nil
+	case 7: // EmailVerified
+		return false, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (u *User) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID == 0, nil
+	case 1: // Username
+		return u.Username == "", nil
+	case 2: // PasswordHash
+		return len(u.PasswordHash) == 0, nil
+	case 3: // TOTPSecret
+		return u.TOTPSecret == "", nil
+	case 4: // TOTPEnabled
+		return u.TOTPEnabled == false, nil
+	case 5: // RecoveryCodes
+		return len(u.RecoveryCodes) == 0, nil
+	case 6: // Email
+		return u.Email == "", nil
+	case 7: // EmailVerified
+		return u.EmailVerified == false, nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (u *User) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		u.ID = 0
+	case 1: // Username
+		u.Username = ""
+	case 2: // PasswordHash
+		u.PasswordHash = nil
+	case 3: // TOTPSecret
+		u.TOTPSecret = ""
+	case 4: // TOTPEnabled
+		u.TOTPEnabled = false
+	case 5: // RecoveryCodes
+		u.RecoveryCodes = nil
+	case 6: // Email
+		u.Email = ""
+	case 7: // EmailVerified
+		u.EmailVerified = false
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (u *User) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID, nil
+	case 1: // Username
+		return u.Username, nil
+	case 2: // PasswordHash
+		return string(u.PasswordHash), nil
+	case 3: // TOTPSecret
+		return u.TOTPSecret, nil
+	case 4: // TOTPEnabled
+		return u.TOTPEnabled, nil
+	case 5: // RecoveryCodes
+		return u.RecoveryCodes, nil
+	case 6: // Email
+		return u.Email, nil
+	case 7: // EmailVerified
+		return u.EmailVerified, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'User'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (u *User) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID, nil
+	case 1: // Username
+		return u.Username, nil
+	case 2: // PasswordHash
+		return u.PasswordHash, nil
+	case 3: // TOTPSecret
+		return u.TOTPSecret, nil
+	case 4: // TOTPEnabled
+		return u.TOTPEnabled, nil
+	case 5: // RecoveryCodes
+		return u.RecoveryCodes, nil
+	case 6: // Email
+		return u.Email, nil
+	case 7: // EmailVerified
+		return u.EmailVerified, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (u *User) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(int); ok {
+			u.ID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			u.ID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			u.ID = int(_v)
+		case int16:
+			u.ID = int(_v)
+		case int32:
+			u.ID = int(_v)
+		case int64:
+			u.ID = int(_v)
+		case uint:
+			u.ID = int(_v)
+		case uint8:
+			u.ID = int(_v)
+		case uint16:
+			u.ID = int(_v)
+		case uint32:
+			u.ID = int(_v)
+		case uint64:
+			u.ID = int(_v)
+		case float32:
+			u.ID = int(_v)
+		case float64:
+			u.ID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // Username
+		if _v, ok := value.(string); ok {
+			u.Username = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			u.Username = ""
+			return nil
+		}
+
+		// Check alternate types for the Username.
+		if _v, ok := value.([]byte); ok {
+			u.Username = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // PasswordHash
+		if value == nil {
+			u.PasswordHash = nil
+			return nil
+		}
+		if _v, ok := value.([]byte); ok {
+			u.PasswordHash = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(byte); ok {
+					u.PasswordHash = append(u.PasswordHash, _v)
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Check alternate types for the PasswordHash.
+		if _v, ok := value.(string); ok {
+			u.PasswordHash = []byte(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 3: // TOTPSecret
+		if _v, ok := value.(string); ok {
+			u.TOTPSecret = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			u.TOTPSecret = ""
+			return nil
+		}
+
+		// Check alternate types for the TOTPSecret.
+		if _v, ok := value.([]byte); ok {
+			u.TOTPSecret = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 4: // TOTPEnabled
+		if _v, ok := value.(bool); ok {
+			u.TOTPEnabled = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			u.TOTPEnabled = false
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 5: // RecoveryCodes
+		if value == nil {
+			u.RecoveryCodes = nil
+			return nil
+		}
+		if _v, ok := value.([]string); ok {
+			u.RecoveryCodes = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(string); ok {
+					u.RecoveryCodes = append(u.RecoveryCodes, _v)
+					continue
+				}
+				// Check alternate types for the RecoveryCodes.
+				if _v, ok := item.([]byte); ok {
+					u.RecoveryCodes = append(u.RecoveryCodes, string(_v))
+					continue
+				}
+				return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+			}
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 6: // Email
+		if _v, ok := value.(string); ok {
+			u.Email = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			u.Email = ""
+			return nil
+		}
+
+		// Check alternate types for the Email.
+		if _v, ok := value.([]byte); ok {
+			u.Email = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 7: // EmailVerified
+		if _v, ok := value.(bool); ok {
+			u.EmailVerified = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			u.EmailVerified = false
+			return nil
+		}
+
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'User'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // Username
+		return value, nil
+	case 2: // PasswordHash
+		return "", errors.Wrap(mapping.ErrFieldNotParser, "field 'PasswordHash' doesn't have string setter.")
+	case 3: // TOTPSecret
+		return value, nil
+	case 4: // TOTPEnabled
+		return strconv.ParseBool(value)
+	case 5: // RecoveryCodes
+		return value, nil
+	case 6: // Email
+		return value, nil
+	case 7: // EmailVerified
+		return strconv.ParseBool(value)
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
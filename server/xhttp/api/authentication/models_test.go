@@ -0,0 +1,273 @@
+package authentication
+
+import (
+	"strings"
+	"time"
+)
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=User,ServiceClient,AccessKey .
+// The neuron version used by this module has no mapping.ErrNilModel. The NeuronCollectionName is defined below.
+//go:generate sed -i s/mapping.ErrNilModel/mapping.ErrModelNotMatch/ models.gen_test.go
+
+// User is the test account model.
+type User struct {
+	ID            int
+	Username      string
+	PasswordHash  []byte
+	TOTPSecret    string
+	TOTPEnabled   bool
+	RecoveryCodes []string
+	Email         string
+	EmailVerified bool
+}
+
+// GetUsername implements auth.Account interface.
+func (u *User) GetUsername() string {
+	return u.Username
+}
+
+// SetUsername implements auth.Account interface.
+func (u *User) SetUsername(username string) {
+	u.Username = username
+}
+
+// GetPasswordHash implements auth.Account interface.
+func (u *User) GetPasswordHash() []byte {
+	return u.PasswordHash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (u *User) SetPasswordHash(hash []byte) {
+	u.PasswordHash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (u *User) UsernameField() string {
+	return "Username"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (u *User) PasswordHashField() string {
+	return "PasswordHash"
+}
+
+// GetTOTPSecret implements TOTPAccount interface.
+func (u *User) GetTOTPSecret() string {
+	return u.TOTPSecret
+}
+
+// SetTOTPSecret implements TOTPAccount interface.
+func (u *User) SetTOTPSecret(secret string) {
+	u.TOTPSecret = secret
+}
+
+// TOTPSecretField implements TOTPAccount interface.
+func (u *User) TOTPSecretField() string {
+	return "TOTPSecret"
+}
+
+// IsTOTPEnabled implements TOTPAccount interface.
+func (u *User) IsTOTPEnabled() bool {
+	return u.TOTPEnabled
+}
+
+// SetTOTPEnabled implements TOTPAccount interface.
+func (u *User) SetTOTPEnabled(enabled bool) {
+	u.TOTPEnabled = enabled
+}
+
+// TOTPEnabledField implements TOTPAccount interface.
+func (u *User) TOTPEnabledField() string {
+	return "TOTPEnabled"
+}
+
+// GetRecoveryCodes implements TOTPAccount interface.
+func (u *User) GetRecoveryCodes() []string {
+	return u.RecoveryCodes
+}
+
+// SetRecoveryCodes implements TOTPAccount interface.
+func (u *User) SetRecoveryCodes(codes []string) {
+	u.RecoveryCodes = codes
+}
+
+// RecoveryCodesField implements TOTPAccount interface.
+func (u *User) RecoveryCodesField() string {
+	return "RecoveryCodes"
+}
+
+// GetEmail implements EmailAccount interface.
+func (u *User) GetEmail() string {
+	return u.Email
+}
+
+// IsEmailVerified implements EmailVerifiedAccount interface.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerified
+}
+
+// SetEmailVerified implements EmailVerifiedAccount interface.
+func (u *User) SetEmailVerified(verified bool) {
+	u.EmailVerified = verified
+}
+
+// EmailVerifiedField implements EmailVerifiedAccount interface.
+func (u *User) EmailVerifiedField() string {
+	return "EmailVerified"
+}
+
+// NeuronCollectionName implements mapping.Model interface.
+func (u *User) NeuronCollectionName() string {
+	return "users"
+}
+
+// ServiceClient is the test OAuth2 client model.
+type ServiceClient struct {
+	ID         int
+	ClientID   string
+	SecretHash []byte
+	Scopes     string
+}
+
+// GetUsername implements auth.Account interface.
+func (c *ServiceClient) GetUsername() string {
+	return c.ClientID
+}
+
+// SetUsername implements auth.Account interface.
+func (c *ServiceClient) SetUsername(clientID string) {
+	c.ClientID = clientID
+}
+
+// GetPasswordHash implements auth.Account interface.
+func (c *ServiceClient) GetPasswordHash() []byte {
+	return c.SecretHash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (c *ServiceClient) SetPasswordHash(hash []byte) {
+	c.SecretHash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (c *ServiceClient) UsernameField() string {
+	return "ClientID"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (c *ServiceClient) PasswordHashField() string {
+	return "SecretHash"
+}
+
+// AllowedScopes implements Client interface.
+func (c *ServiceClient) AllowedScopes() []string {
+	return strings.Fields(c.Scopes)
+}
+
+// NeuronCollectionName implements mapping.Model interface.
+func (c *ServiceClient) NeuronCollectionName() string {
+	return "clients"
+}
+
+// AccessKey is the test api key model.
+type AccessKey struct {
+	ID         int
+	AccountID  string
+	Name       string
+	KeyPrefix  string
+	KeyHash    []byte
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+// GetAccountID implements APIKey interface.
+func (k *AccessKey) GetAccountID() string {
+	return k.AccountID
+}
+
+// SetAccountID implements APIKey interface.
+func (k *AccessKey) SetAccountID(accountID string) error {
+	k.AccountID = accountID
+	return nil
+}
+
+// AccountIDField implements APIKey interface.
+func (k *AccessKey) AccountIDField() string {
+	return "AccountID"
+}
+
+// GetName implements APIKey interface.
+func (k *AccessKey) GetName() string {
+	return k.Name
+}
+
+// SetName implements APIKey interface.
+func (k *AccessKey) SetName(name string) {
+	k.Name = name
+}
+
+// GetKeyPrefix implements APIKey interface.
+func (k *AccessKey) GetKeyPrefix() string {
+	return k.KeyPrefix
+}
+
+// SetKeyPrefix implements APIKey interface.
+func (k *AccessKey) SetKeyPrefix(prefix string) {
+	k.KeyPrefix = prefix
+}
+
+// KeyPrefixField implements APIKey interface.
+func (k *AccessKey) KeyPrefixField() string {
+	return "KeyPrefix"
+}
+
+// GetKeyHash implements APIKey interface.
+func (k *AccessKey) GetKeyHash() []byte {
+	return k.KeyHash
+}
+
+// SetKeyHash implements APIKey interface.
+func (k *AccessKey) SetKeyHash(hash []byte) {
+	k.KeyHash = hash
+}
+
+// GetScopes implements APIKey interface.
+func (k *AccessKey) GetScopes() []string {
+	return k.Scopes
+}
+
+// SetScopes implements APIKey interface.
+func (k *AccessKey) SetScopes(scopes []string) {
+	k.Scopes = scopes
+}
+
+// GetExpiresAt implements APIKey interface.
+func (k *AccessKey) GetExpiresAt() *time.Time {
+	return k.ExpiresAt
+}
+
+// SetExpiresAt implements APIKey interface.
+func (k *AccessKey) SetExpiresAt(expiresAt *time.Time) {
+	k.ExpiresAt = expiresAt
+}
+
+// GetLastUsedAt implements APIKey interface.
+func (k *AccessKey) GetLastUsedAt() *time.Time {
+	return k.LastUsedAt
+}
+
+// SetLastUsedAt implements APIKey interface.
+func (k *AccessKey) SetLastUsedAt(lastUsedAt time.Time) {
+	k.LastUsedAt = &lastUsedAt
+}
+
+// LastUsedAtField implements APIKey interface.
+func (k *AccessKey) LastUsedAtField() string {
+	return "LastUsedAt"
+}
+
+// NeuronCollectionName implements mapping.Model interface.
+func (k *AccessKey) NeuronCollectionName() string {
+	return "access_keys"
+}
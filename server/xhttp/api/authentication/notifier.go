@@ -0,0 +1,101 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// NotificationKind defines the kind of the account notification.
+type NotificationKind string
+
+const (
+	// NotificationPasswordReset is the notification containing the password reset token.
+	NotificationPasswordReset NotificationKind = "password_reset"
+	// NotificationEmailVerification is the notification containing the email verification token.
+	NotificationEmailVerification NotificationKind = "email_verification"
+)
+
+// Notification is the message delivered to the account owner i.e. by an email.
+type Notification struct {
+	Kind NotificationKind `json:"kind"`
+	// Recipient is the account email address if the account implements EmailAccount, or its username otherwise.
+	Recipient string       `json:"recipient"`
+	Account   auth.Account `json:"-"`
+	// Token is the single-use token that should be delivered to the account owner.
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Notifier is an interface used for the notifications delivery.
+type Notifier interface {
+	Notify(ctx context.Context, notification *Notification) error
+}
+
+// EmailAccount is an interface for the accounts that contains email address different than the username.
+type EmailAccount interface {
+	GetEmail() string
+}
+
+// LogNotifier is the Notifier that logs the notifications. It should be used only for local development.
+type LogNotifier struct{}
+
+// Notify implements Notifier interface.
+func (LogNotifier) Notify(_ context.Context, n *Notification) error {
+	log.Infof("Notification: '%s' for: '%s' token: '%s' expires at: %s", n.Kind, n.Recipient, n.Token, n.ExpiresAt)
+	return nil
+}
+
+// WriterNotifier is the Notifier that writes JSON encoded notifications, one per line, to the provided writer.
+// It is useful for the local development and tests.
+type WriterNotifier struct {
+	w  io.Writer
+	mu sync.Mutex
+}
+
+// NewWriterNotifier creates new notifier that writes the notifications to 'w'.
+func NewWriterNotifier(w io.Writer) *WriterNotifier {
+	return &WriterNotifier{w: w}
+}
+
+// NewFileNotifier creates new notifier that appends the notifications to the file at given 'path'.
+func NewFileNotifier(path string) (*WriterNotifier, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(auth.ErrInitialization, "opening notifications file failed: %v", err)
+	}
+	return &WriterNotifier{w: f}, nil
+}
+
+// Notify implements Notifier interface.
+func (w *WriterNotifier) Notify(_ context.Context, n *Notification) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if err := json.NewEncoder(w.w).Encode(n); err != nil {
+		return errors.Wrapf(auth.ErrInternalError, "writing notification failed: %v", err)
+	}
+	return nil
+}
+
+// notify creates the notification for the 'account' and delivers it using the options notifier.
+func (a *API) notify(ctx context.Context, kind NotificationKind, account auth.Account, token string, expiresAt time.Time) error {
+	recipient := account.GetUsername()
+	if emailAccount, ok := account.(EmailAccount); ok && emailAccount.GetEmail() != "" {
+		recipient = emailAccount.GetEmail()
+	}
+	return a.Options.Notifier.Notify(ctx, &Notification{
+		Kind:      kind,
+		Recipient: recipient,
+		Account:   account,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	})
+}
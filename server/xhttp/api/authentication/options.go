@@ -5,36 +5,66 @@ import (
 
 	"github.com/neuronlabs/neuron/auth"
 	"github.com/neuronlabs/neuron/server"
+	"github.com/neuronlabs/neuron/store"
 )
 
 // AuthenticatorOptions is the structure that contains auth API  settings.
 type Options struct {
-	AccountModel             auth.Account
-	AccountHandler           interface{}
-	PathPrefix               string
-	Middlewares              []server.Middleware
-	RegisterMiddlewares      []server.Middleware
-	LoginMiddlewares         []server.Middleware
-	LogoutMiddlewares        []server.Middleware
-	RefreshTokenMiddlewares  []server.Middleware
-	StrictUnmarshal          bool
-	PasswordValidator        auth.PasswordValidator
-	PasswordScorer           auth.PasswordScorer
-	UsernameValidator        auth.UsernameValidator
-	TokenExpiration          time.Duration
-	RememberTokenExpiration  time.Duration
-	RefreshTokenExpiration   time.Duration
-	PermitRefreshTokenLogout bool
+	AccountModel                 auth.Account
+	AccountHandler               interface{}
+	PathPrefix                   string
+	Middlewares                  []server.Middleware
+	RegisterMiddlewares          []server.Middleware
+	LoginMiddlewares             []server.Middleware
+	LogoutMiddlewares            []server.Middleware
+	RefreshTokenMiddlewares      []server.Middleware
+	IntrospectMiddlewares        []server.Middleware
+	RevokeMiddlewares            []server.Middleware
+	ClientAuthenticator          ClientAuthenticator
+	ClientModel                  Client
+	ClientTokenExpiration        time.Duration
+	TokenMiddlewares             []server.Middleware
+	StrictUnmarshal              bool
+	PasswordValidator            auth.PasswordValidator
+	PasswordScorer               auth.PasswordScorer
+	UsernameValidator            auth.UsernameValidator
+	TokenExpiration              time.Duration
+	RememberTokenExpiration      time.Duration
+	RefreshTokenExpiration       time.Duration
+	PermitRefreshTokenLogout     bool
+	Store                        store.Store
+	TOTPIssuer                   string
+	TwoFactorChallengeExpiration time.Duration
+	RecoveryCodesCount           int
+	TwoFactorMiddlewares         []server.Middleware
+	LockoutPolicy                *LockoutPolicy
+	Notifier                     Notifier
+	PasswordResetExpiration      time.Duration
+	EmailVerificationExpiration  time.Duration
+	PasswordResetMiddlewares     []server.Middleware
+	EmailVerificationMiddlewares []server.Middleware
+	AccountEndpoints             bool
+	AccountMiddlewares           []server.Middleware
+	Cookies                      *CookieOptions
+	APIKeyModel                  APIKey
+	APIKeyMiddlewares            []server.Middleware
+	Verifier                     auth.Verifier
 }
 
 func defaultOptions() *Options {
 	return &Options{
-		PasswordScorer:          auth.DefaultPasswordScorer,
-		PasswordValidator:       auth.DefaultPasswordValidator,
-		UsernameValidator:       auth.DefaultUsernameValidator,
-		TokenExpiration:         time.Hour * 24,
-		RememberTokenExpiration: time.Hour * 24 * 7,
-		RefreshTokenExpiration:  time.Hour * 24 * 30,
+		PasswordScorer:               auth.DefaultPasswordScorer,
+		PasswordValidator:            auth.DefaultPasswordValidator,
+		UsernameValidator:            auth.DefaultUsernameValidator,
+		TokenExpiration:              time.Hour * 24,
+		RememberTokenExpiration:      time.Hour * 24 * 7,
+		RefreshTokenExpiration:       time.Hour * 24 * 30,
+		ClientTokenExpiration:        time.Hour,
+		TOTPIssuer:                   "neuron",
+		TwoFactorChallengeExpiration: time.Minute * 5,
+		RecoveryCodesCount:           10,
+		PasswordResetExpiration:      time.Minute * 30,
+		EmailVerificationExpiration:  time.Hour * 24,
 	}
 }
 
@@ -151,3 +181,177 @@ func WithPermitRefreshTokenLogout(permit bool) Option {
 		o.PermitRefreshTokenLogout = permit
 	}
 }
+
+// WithClientAuthenticator sets the OAuth2 client authenticator. If provided, the API exposes also
+// token introspection (RFC 7662) and token revocation (RFC 7009) endpoints.
+func WithClientAuthenticator(clientAuthenticator ClientAuthenticator) Option {
+	return func(o *Options) {
+		o.ClientAuthenticator = clientAuthenticator
+	}
+}
+
+// WithIntrospectMiddlewares adds middlewares for the token introspection endpoint.
+func WithIntrospectMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.IntrospectMiddlewares = append(o.IntrospectMiddlewares, middlewares...)
+	}
+}
+
+// WithRevokeMiddlewares adds middlewares for the token revocation endpoint.
+func WithRevokeMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.RevokeMiddlewares = append(o.RevokeMiddlewares, middlewares...)
+	}
+}
+
+// WithClientModel sets the OAuth2 client model. If provided, the API exposes the token endpoint
+// with the client credentials grant, and authenticates the clients using this model.
+func WithClientModel(client Client) Option {
+	return func(o *Options) {
+		o.ClientModel = client
+	}
+}
+
+// WithClientTokenExpiration sets the 'ClientTokenExpiration' option for the tokens issued for the clients.
+func WithClientTokenExpiration(d time.Duration) Option {
+	return func(o *Options) {
+		o.ClientTokenExpiration = d
+	}
+}
+
+// WithTokenMiddlewares adds middlewares for the OAuth2 token endpoint.
+func WithTokenMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.TokenMiddlewares = append(o.TokenMiddlewares, middlewares...)
+	}
+}
+
+// WithStore sets the key-value store used by the API for the short-lived authentication data
+// i.e. two factor challenges. If not set, the controller's default store is used.
+func WithStore(s store.Store) Option {
+	return func(o *Options) {
+		o.Store = s
+	}
+}
+
+// WithTOTPIssuer sets the issuer name used in the TOTP otpauth URI.
+func WithTOTPIssuer(issuer string) Option {
+	return func(o *Options) {
+		o.TOTPIssuer = issuer
+	}
+}
+
+// WithTwoFactorChallengeExpiration sets the expiration time of the login two factor challenge token.
+func WithTwoFactorChallengeExpiration(d time.Duration) Option {
+	return func(o *Options) {
+		o.TwoFactorChallengeExpiration = d
+	}
+}
+
+// WithRecoveryCodesCount sets the number of the recovery codes generated on the TOTP enrolment.
+func WithRecoveryCodesCount(count int) Option {
+	return func(o *Options) {
+		o.RecoveryCodesCount = count
+	}
+}
+
+// WithTwoFactorMiddlewares adds middlewares for the two factor authentication endpoints.
+func WithTwoFactorMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.TwoFactorMiddlewares = append(o.TwoFactorMiddlewares, middlewares...)
+	}
+}
+
+// WithLockoutPolicy sets the brute-force protection policy for the login endpoint.
+// The lockout state is kept in the API store.
+func WithLockoutPolicy(policy *LockoutPolicy) Option {
+	return func(o *Options) {
+		o.LockoutPolicy = policy
+	}
+}
+
+// WithNotifier sets the notifier used for delivering the password reset and email verification tokens.
+// The password reset and email verification endpoints are registered only if the notifier is set.
+func WithNotifier(notifier Notifier) Option {
+	return func(o *Options) {
+		o.Notifier = notifier
+	}
+}
+
+// WithPasswordResetExpiration sets the expiration time of the password reset token.
+func WithPasswordResetExpiration(d time.Duration) Option {
+	return func(o *Options) {
+		o.PasswordResetExpiration = d
+	}
+}
+
+// WithEmailVerificationExpiration sets the expiration time of the email verification token.
+func WithEmailVerificationExpiration(d time.Duration) Option {
+	return func(o *Options) {
+		o.EmailVerificationExpiration = d
+	}
+}
+
+// WithPasswordResetMiddlewares adds middlewares for the password reset endpoints.
+func WithPasswordResetMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.PasswordResetMiddlewares = append(o.PasswordResetMiddlewares, middlewares...)
+	}
+}
+
+// WithEmailVerificationMiddlewares adds middlewares for the email verification endpoints.
+func WithEmailVerificationMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.EmailVerificationMiddlewares = append(o.EmailVerificationMiddlewares, middlewares...)
+	}
+}
+
+// WithAccountEndpoints enables the authenticated account management endpoints: getting ('GET /me') and deleting
+// ('DELETE /me') the account, changing its password ('POST /password/change') and username ('POST /username/change').
+// The endpoints are disabled by default.
+func WithAccountEndpoints(enabled bool) Option {
+	return func(o *Options) {
+		o.AccountEndpoints = enabled
+	}
+}
+
+// WithAccountMiddlewares adds middlewares for the authenticated account management endpoints.
+func WithAccountMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.AccountMiddlewares = append(o.AccountMiddlewares, middlewares...)
+	}
+}
+
+// WithCookies enables the cookie based session transport with provided options.
+// If 'cookies' are nil, the DefaultCookieOptions are used.
+func WithCookies(cookies *CookieOptions) Option {
+	return func(o *Options) {
+		if cookies == nil {
+			cookies = DefaultCookieOptions()
+		}
+		o.Cookies = cookies
+	}
+}
+
+// WithAPIKeyModel sets the api key model. If set, the API provides the endpoints for the api keys management,
+// and the APIKeyAuthenticate middleware could be used for the machine clients authentication.
+func WithAPIKeyModel(model APIKey) Option {
+	return func(o *Options) {
+		o.APIKeyModel = model
+	}
+}
+
+// WithAPIKeyMiddlewares adds middlewares for the api keys management endpoints.
+func WithAPIKeyMiddlewares(middlewares ...server.Middleware) Option {
+	return func(o *Options) {
+		o.APIKeyMiddlewares = append(o.APIKeyMiddlewares, middlewares...)
+	}
+}
+
+// WithVerifier sets the authorization verifier used for checking the scopes requested for the api keys.
+// If not set, the controller's Verifier is used.
+func WithVerifier(verifier auth.Verifier) Option {
+	return func(o *Options) {
+		o.Verifier = verifier
+	}
+}
@@ -0,0 +1,226 @@
+package authentication
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// PasswordResetRequestInput is the input for the password reset request.
+type PasswordResetRequestInput struct {
+	Username string `json:"username"`
+}
+
+// PasswordResetInput is the input that confirms the password reset with a new password.
+type PasswordResetInput struct {
+	Token                string `json:"token"`
+	Password             string `json:"password"`
+	PasswordConfirmation string `json:"password_confirmation"`
+}
+
+// accountToken is the store value of the single-use account token.
+type accountToken struct {
+	AccountID string    `json:"account_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// handlePasswordResetRequest issues the password reset token and sends it with the notifier.
+// In order not to disclose which usernames exists, the response is always accepted.
+func (a *API) handlePasswordResetRequest(rw http.ResponseWriter, req *http.Request) {
+	input := &PasswordResetRequestInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.Username = q.Get("username")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if input.Username == "" {
+		httpError := httputil.ErrInvalidJSONFieldValue()
+		httpError.Detail = "Provided empty username."
+		a.marshalErrors(rw, 400, httpError)
+		return
+	}
+	ctx := req.Context()
+	model, err := a.DB.QueryCtx(ctx, a.model).
+		Filter(filter.New(a.defaultHandler.UsernameField, filter.OpEqual, input.Username)).
+		Get()
+	if err != nil {
+		if !errors.Is(err, query.ErrNoResult) {
+			log.Errorf("Getting account for the password reset failed: %v", err)
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+		rw.WriteHeader(http.StatusAccepted)
+		return
+	}
+	account := model.(auth.Account)
+	token, expiresAt, err := a.issueAccountToken(ctx, NotificationPasswordReset, account, a.Options.PasswordResetExpiration)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if err = a.notify(ctx, NotificationPasswordReset, account, token, expiresAt); err != nil {
+		log.Errorf("Sending password reset notification failed: %v", err)
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	rw.WriteHeader(http.StatusAccepted)
+}
+
+// handlePasswordReset consumes the password reset token and sets the new account password.
+func (a *API) handlePasswordReset(rw http.ResponseWriter, req *http.Request) {
+	input := &PasswordResetInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.Token = q.Get("token")
+		input.Password = q.Get("password")
+		input.PasswordConfirmation = q.Get("password_confirmation")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	password, err := a.validateNewPassword(input.Password, input.PasswordConfirmation)
+	if err != nil {
+		a.marshalErrors(rw, 400, err)
+		return
+	}
+	ctx := req.Context()
+	account, err := a.consumeAccountToken(ctx, NotificationPasswordReset, input.Token)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if err = a.setAccountPassword(ctx, a.DB, account, password); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if err = a.revokeAccountTokens(ctx, account); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if a.Options.LockoutPolicy != nil {
+		a.resetLoginFailures(ctx, account.GetUsername())
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// validateNewPassword scores and validates provided 'password' and checks if it matches the 'confirmation'.
+func (a *API) validateNewPassword(password, confirmation string) (*auth.Password, error) {
+	if password != confirmation {
+		httpError := httputil.ErrInvalidJSONFieldValue()
+		httpError.Detail = "Password confirmation doesn't match."
+		return nil, httpError
+	}
+	neuronPassword := auth.NewPassword(password, a.Options.PasswordScorer)
+	if neuronPassword == nil {
+		httpError := httputil.ErrInvalidJSONFieldValue()
+		httpError.Detail = "Provided empty password."
+		return nil, httpError
+	}
+	if a.Options.PasswordValidator != nil {
+		if err := a.Options.PasswordValidator(neuronPassword); err != nil {
+			httpError := httputil.ErrInvalidJSONFieldValue()
+			httpError.Detail = "Provided invalid password."
+			if detailer, ok := err.(*errors.DetailedError); ok {
+				httpError.Detail = detailer.Details
+			}
+			return nil, httpError
+		}
+	}
+	return neuronPassword, nil
+}
+
+// setAccountPassword hashes the 'password', sets it to the 'account' and stores its password hash (and salt) fields.
+func (a *API) setAccountPassword(ctx context.Context, db database.DB, account auth.Account, password *auth.Password) error {
+	if err := a.Controller.Authenticator.HashAndSetPassword(account, password); err != nil {
+		return err
+	}
+	fields := []string{account.PasswordHashField()}
+	if saltFielder, ok := account.(auth.SaltFielder); ok {
+		fields = append(fields, saltFielder.SaltField())
+	}
+	return a.updateAccountFields(ctx, db, account, fields...)
+}
+
+// issueAccountToken creates new single-use token of given 'kind' for the 'account' and stores it for the 'ttl'.
+func (a *API) issueAccountToken(ctx context.Context, kind NotificationKind, account auth.Account, ttl time.Duration) (string, time.Time, error) {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(auth.ErrInternalError, "getting account primary key string value failed: %v", err)
+	}
+	token, err := randomToken(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(ttl)
+	value, err := json.Marshal(&accountToken{AccountID: accountID, ExpiresAt: expiresAt})
+	if err != nil {
+		return "", time.Time{}, errors.Wrapf(auth.ErrInternalError, "marshal account token failed: %v", err)
+	}
+	record := &store.Record{Key: accountTokenKey(kind, token), Value: value, ExpiresAt: expiresAt}
+	if err = a.Options.Store.Set(ctx, record, store.SetWithTTL(ttl)); err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+// consumeAccountToken gets the account for the 'token' of given 'kind'. The token is removed from the store, so that
+// it could not be used again.
+func (a *API) consumeAccountToken(ctx context.Context, kind NotificationKind, token string) (auth.Account, error) {
+	invalidToken := func() error {
+		httpError := httputil.ErrInvalidInput()
+		httpError.Detail = "Provided token is invalid or expired."
+		return httpError
+	}
+	if token == "" {
+		return nil, invalidToken()
+	}
+	key := accountTokenKey(kind, token)
+	record, err := a.Options.Store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return nil, invalidToken()
+		}
+		return nil, err
+	}
+	if err = a.Options.Store.Delete(ctx, key); err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			// The token was consumed concurrently.
+			return nil, invalidToken()
+		}
+		return nil, err
+	}
+	accToken := &accountToken{}
+	if err = json.Unmarshal(record.Value, accToken); err != nil {
+		return nil, errors.Wrapf(auth.ErrInternalError, "unmarshal account token failed: %v", err)
+	}
+	if time.Now().After(accToken.ExpiresAt) {
+		return nil, invalidToken()
+	}
+	account, err := a.getAccountByID(ctx, accToken.AccountID)
+	if err != nil {
+		if errors.Is(err, auth.ErrAccountNotFound) {
+			return nil, invalidToken()
+		}
+		return nil, err
+	}
+	return account, nil
+}
+
+func accountTokenKey(kind NotificationKind, token string) string {
+	h := sha256.Sum256([]byte(token))
+	return "nrn_account_token_" + string(kind) + "_" + hex.EncodeToString(h[:])
+}
@@ -0,0 +1,153 @@
+package authentication
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNotifier is the Notifier that collects the notifications.
+type testNotifier struct {
+	mu            sync.Mutex
+	notifications []*Notification
+}
+
+func (n *testNotifier) Notify(_ context.Context, notification *Notification) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+// last gets the last notification or nil if there is none.
+func (n *testNotifier) last() *Notification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.notifications) == 0 {
+		return nil
+	}
+	return n.notifications[len(n.notifications)-1]
+}
+
+func (n *testNotifier) count() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.notifications)
+}
+
+func TestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+	notifier := &testNotifier{}
+	env := newTestEnv(t, WithNotifier(notifier))
+	user := env.addUser(t, "john-doe")
+	user.Email = "john@example.com"
+	require.NoError(t, env.api.updateAccountFields(ctx, env.api.DB, user, "Email"))
+	token := env.token(t, user)
+	const newPassword = "Other-Password2@"
+
+	// The response doesn't disclose if the account exists.
+	rw := env.do(http.MethodPost, "/auth/password/forgot", "", url.Values{"username": {"unknown-user"}})
+	assert.Equal(t, http.StatusAccepted, rw.Code, rw.Body.String())
+	assert.Zero(t, notifier.count())
+
+	rw = env.do(http.MethodPost, "/auth/password/forgot", "", url.Values{"username": {"john-doe"}})
+	require.Equal(t, http.StatusAccepted, rw.Code, rw.Body.String())
+	notification := notifier.last()
+	require.NotNil(t, notification)
+	assert.Equal(t, NotificationPasswordReset, notification.Kind)
+	assert.Equal(t, "john@example.com", notification.Recipient)
+	require.NotEmpty(t, notification.Token)
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		rw := env.do(http.MethodPost, "/auth/password/reset", "", url.Values{
+			"token":                 {notification.Token},
+			"password":              {newPassword},
+			"password_confirmation": {testPassword},
+		})
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+
+		rw = env.do(http.MethodPost, "/auth/password/reset", "", url.Values{
+			"token":                 {"invalid"},
+			"password":              {newPassword},
+			"password_confirmation": {newPassword},
+		})
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+
+		stored, ok := env.getUser(t, user.ID)
+		require.True(t, ok)
+		assert.NoError(t, testAuthenticator{}.ComparePassword(stored, testPassword))
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		form := url.Values{
+			"token":                 {notification.Token},
+			"password":              {newPassword},
+			"password_confirmation": {newPassword},
+		}
+		rw := env.do(http.MethodPost, "/auth/password/reset", "", form)
+		require.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+
+		stored, ok := env.getUser(t, user.ID)
+		require.True(t, ok)
+		assert.NoError(t, testAuthenticator{}.ComparePassword(stored, newPassword))
+		assert.True(t, env.tokener.isRevoked(ctx, token.AccessToken))
+
+		// The token could be used only once.
+		rw = env.do(http.MethodPost, "/auth/password/reset", "", form)
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		env := newTestEnv(t, WithNotifier(notifier), WithPasswordResetExpiration(time.Millisecond))
+		env.addUser(t, "john-doe")
+		rw := env.do(http.MethodPost, "/auth/password/forgot", "", url.Values{"username": {"john-doe"}})
+		require.Equal(t, http.StatusAccepted, rw.Code, rw.Body.String())
+		time.Sleep(5 * time.Millisecond)
+
+		rw = env.do(http.MethodPost, "/auth/password/reset", "", url.Values{
+			"token":                 {notifier.last().Token},
+			"password":              {newPassword},
+			"password_confirmation": {newPassword},
+		})
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+}
+
+func TestEmailVerification(t *testing.T) {
+	notifier := &testNotifier{}
+	env := newTestEnv(t, WithNotifier(notifier))
+	user := env.addUser(t, "john-doe")
+	token := env.token(t, user)
+
+	rw := env.do(http.MethodPost, "/auth/email/verify/request", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+	rw = env.do(http.MethodPost, "/auth/email/verify/request", token.AccessToken, nil)
+	require.Equal(t, http.StatusAccepted, rw.Code, rw.Body.String())
+	notification := notifier.last()
+	require.NotNil(t, notification)
+	assert.Equal(t, NotificationEmailVerification, notification.Kind)
+	// The account without email is notified with its username.
+	assert.Equal(t, "john-doe", notification.Recipient)
+
+	// The password reset token is not valid for the email verification.
+	rw = env.do(http.MethodPost, "/auth/password/forgot", "", url.Values{"username": {"john-doe"}})
+	require.Equal(t, http.StatusAccepted, rw.Code, rw.Body.String())
+	rw = env.do(http.MethodPost, "/auth/email/verify", "", url.Values{"token": {notifier.last().Token}})
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+
+	rw = env.do(http.MethodPost, "/auth/email/verify", "", url.Values{"token": {notification.Token}})
+	require.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+	stored, ok := env.getUser(t, user.ID)
+	require.True(t, ok)
+	assert.True(t, stored.EmailVerified)
+
+	rw = env.do(http.MethodPost, "/auth/email/verify/request", token.AccessToken, nil)
+	assert.Equal(t, http.StatusForbidden, rw.Code)
+}
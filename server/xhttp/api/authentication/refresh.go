@@ -1,8 +1,6 @@
 package authentication
 
 import (
-	"bytes"
-	"encoding/json"
 	"net/http"
 	"time"
 
@@ -16,7 +14,7 @@ import (
 )
 
 func (a *API) handleRefreshToken(rw http.ResponseWriter, req *http.Request) {
-	token, err := a.getBearerToken(req)
+	token, err := a.getRequestToken(req, a.refreshTokenCookieName())
 	if err != nil {
 		a.marshalErrors(rw, 401, err)
 		return
@@ -72,21 +70,5 @@ func (a *API) handleRefreshToken(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	output := &LoginOutput{
-		AccessToken:  tokenOutput.AccessToken,
-		RefreshToken: tokenOutput.RefreshToken,
-		TokenType:    tokenOutput.TokenType,
-		ExpiresIn:    int64(tokenOutput.ExpiresIn),
-	}
-
-	buffer := &bytes.Buffer{}
-	if err = json.NewEncoder(buffer).Encode(output); err != nil {
-		a.marshalErrors(rw, 500, httputil.ErrInternalError())
-		return
-	}
-	a.setContentType(rw)
-	rw.WriteHeader(http.StatusCreated)
-	if _, err = buffer.WriteTo(rw); err != nil {
-		log.Errorf("Writing to response writer failed: %v", err)
-	}
+	a.writeTokenOutput(rw, tokenOutput)
 }
@@ -127,6 +127,12 @@ func (a *API) handleRegisterAccount(rw http.ResponseWriter, req *http.Request) {
 		a.marshalErrors(rw, 0, err)
 		return
 	}
+	// Send the email verification token to the registered account.
+	if verifiedAccount, ok := registerOptions.Account.(EmailVerifiedAccount); ok && a.Options.Notifier != nil && !verifiedAccount.IsEmailVerified() {
+		if err = a.sendEmailVerification(ctx, verifiedAccount); err != nil {
+			log.Errorf("Sending email verification notification failed: %v", err)
+		}
+	}
 	if payload == nil {
 		rw.WriteHeader(http.StatusNoContent)
 		return
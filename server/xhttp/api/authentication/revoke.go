@@ -0,0 +1,40 @@
+package authentication
+
+import (
+	"net/http"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// handleRevoke handles the token revocation defined in the RFC 7009.
+func (a *API) handleRevoke(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		a.marshalErrors(rw, 400, errors.WrapDet(codec.ErrUnmarshalDocument, "provided invalid post form").WithDetail("invalid post form"))
+		return
+	}
+	if !a.authenticateClient(rw, req) {
+		return
+	}
+	token := req.PostForm.Get("token")
+	if token == "" {
+		httpError := httputil.ErrMissingRequiredQueryParameter()
+		httpError.Detail = "no 'token' parameter provided"
+		a.marshalErrors(rw, 400, httpError)
+		return
+	}
+
+	// Invalid tokens doesn't cause an error response - the client couldn't do anything with it.
+	if err := a.Controller.Tokener.RevokeToken(req.Context(), token); err != nil {
+		if !errors.Is(err, auth.ErrToken) {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+		log.Debug2f("Revoking invalid token: %v", err)
+	}
+	rw.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,169 @@
+package authentication
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/server"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/middleware"
+)
+
+// CookieOptions are the options for the cookie based session transport. When set, the access and refresh tokens
+// are delivered as HttpOnly cookies instead of the response body, and the unsafe requests authenticated with
+// the cookies are protected with the double-submit CSRF token.
+type CookieOptions struct {
+	// AccessTokenName is the name of the access token cookie.
+	AccessTokenName string
+	// RefreshTokenName is the name of the refresh token cookie. The refresh token cookie is sent only to the refresh
+	// endpoint of the API, regardless of the Path option.
+	RefreshTokenName string
+	// CSRFName is the name of the cookie that contains the CSRF token. This cookie is readable by the client scripts.
+	CSRFName string
+	// CSRFHeader is the name of the header that should contain the CSRF token value for the unsafe requests.
+	CSRFHeader string
+	Domain     string
+	Path       string
+	Secure     bool
+	SameSite   http.SameSite
+}
+
+// DefaultCookieOptions returns default cookie options.
+func DefaultCookieOptions() *CookieOptions {
+	return &CookieOptions{
+		AccessTokenName:  middleware.DefaultAccessTokenCookie,
+		RefreshTokenName: "refresh_token",
+		CSRFName:         middleware.DefaultCSRFCookie,
+		CSRFHeader:       middleware.DefaultCSRFHeader,
+		Path:             "/",
+		Secure:           true,
+		SameSite:         http.SameSiteStrictMode,
+	}
+}
+
+// writeTokenOutput writes the 'token' to the response writer. If the cookie transport is enabled, the tokens
+// are set as cookies and omitted in the response body.
+func (a *API) writeTokenOutput(rw http.ResponseWriter, token auth.Token) {
+	output := &LoginOutput{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		ExpiresIn:    int64(token.ExpiresIn),
+	}
+	if a.Options.Cookies != nil {
+		if err := a.setSessionCookies(rw, token); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+		output.AccessToken, output.RefreshToken = "", ""
+	}
+	a.writeJSON(rw, http.StatusCreated, output)
+}
+
+// setSessionCookies sets the access, refresh and CSRF token cookies.
+func (a *API) setSessionCookies(rw http.ResponseWriter, token auth.Token) error {
+	csrfToken, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	refreshMaxAge := int(a.Options.RefreshTokenExpiration.Seconds())
+	http.SetCookie(rw, a.newCookie(a.Options.Cookies.AccessTokenName, token.AccessToken, token.ExpiresIn, true))
+	if token.RefreshToken != "" {
+		http.SetCookie(rw, a.newRefreshCookie(token.RefreshToken, refreshMaxAge))
+	}
+	http.SetCookie(rw, a.newCookie(a.Options.Cookies.CSRFName, csrfToken, refreshMaxAge, false))
+	return nil
+}
+
+// clearSessionCookies removes the session cookies from the client.
+func (a *API) clearSessionCookies(rw http.ResponseWriter) {
+	for _, name := range []string{a.Options.Cookies.AccessTokenName, a.Options.Cookies.CSRFName} {
+		http.SetCookie(rw, a.newCookie(name, "", -1, name != a.Options.Cookies.CSRFName))
+	}
+	http.SetCookie(rw, a.newRefreshCookie("", -1))
+}
+
+// newRefreshCookie creates the refresh token cookie limited to the refresh endpoint path.
+func (a *API) newRefreshCookie(value string, maxAge int) *http.Cookie {
+	cookie := a.newCookie(a.Options.Cookies.RefreshTokenName, value, maxAge, true)
+	cookie.Path = a.refreshPath()
+	return cookie
+}
+
+func (a *API) newCookie(name, value string, maxAge int, httpOnly bool) *http.Cookie {
+	o := a.Options.Cookies
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     o.Path,
+		Domain:   o.Domain,
+		MaxAge:   maxAge,
+		Secure:   o.Secure,
+		HttpOnly: httpOnly,
+		SameSite: o.SameSite,
+	}
+}
+
+// getRequestToken gets the token from the Bearer Authorization header or, if the cookie transport is enabled,
+// from the cookie with provided name.
+func (a *API) getRequestToken(req *http.Request, cookieName string) (string, error) {
+	if a.Options.Cookies != nil && !strings.HasPrefix(req.Header.Get("Authorization"), "Bearer ") {
+		cookie, err := req.Cookie(cookieName)
+		if err != nil || cookie.Value == "" {
+			return "", errors.WrapDetf(auth.ErrAuthorizationHeader, "no token cookie found").WithDetail("No authorization token provided.")
+		}
+		return cookie.Value, nil
+	}
+	return a.getBearerToken(req)
+}
+
+// authenticateMiddlewares returns the middlewares that authenticates the account for the API endpoints.
+func (a *API) authenticateMiddlewares() []server.Middleware {
+	if a.Options.Cookies == nil {
+		return []server.Middleware{middleware.BearerAuthenticate()}
+	}
+	return []server.Middleware{a.csrfMiddleware(), middleware.CookieAuthenticate(a.Options.Cookies.AccessTokenName)}
+}
+
+// csrfMiddleware returns the CSRF protection middleware for the requests that uses the session cookies.
+func (a *API) csrfMiddleware() server.Middleware {
+	o := a.Options.Cookies
+	return middleware.CSRF(o.CSRFName, o.CSRFHeader, o.AccessTokenName, o.RefreshTokenName)
+}
+
+// sessionMiddlewares returns the middlewares for the endpoints that uses the session tokens without authenticating
+// the account i.e. refresh and logout.
+func (a *API) sessionMiddlewares() []server.Middleware {
+	if a.Options.Cookies == nil {
+		return nil
+	}
+	return []server.Middleware{a.csrfMiddleware()}
+}
+
+// validateCookieOptions checks if the cookie options are valid.
+func (a *API) validateCookieOptions() error {
+	o := a.Options.Cookies
+	if o.AccessTokenName == "" || o.RefreshTokenName == "" || o.CSRFName == "" || o.CSRFHeader == "" {
+		return errors.Wrap(auth.ErrInitialization, "provided empty session cookie or csrf header name")
+	}
+	if o.SameSite == http.SameSiteNoneMode && !o.Secure {
+		return errors.Wrap(auth.ErrInitialization, "SameSite=None session cookies requires Secure attribute")
+	}
+	return nil
+}
+
+func (a *API) accessTokenCookieName() string {
+	if a.Options.Cookies == nil {
+		return ""
+	}
+	return a.Options.Cookies.AccessTokenName
+}
+
+func (a *API) refreshTokenCookieName() string {
+	if a.Options.Cookies == nil {
+		return ""
+	}
+	return a.Options.Cookies.RefreshTokenName
+}
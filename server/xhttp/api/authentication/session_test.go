@@ -0,0 +1,126 @@
+package authentication
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionCookies(t *testing.T) {
+	env := newTestEnv(t, WithCookies(DefaultCookieOptions()))
+	env.addUser(t, "john-doe")
+
+	rw := env.do(http.MethodPost, "/auth/login", "", url.Values{"username": {"john-doe"}, "password": {testPassword}})
+	require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+	cookies := responseCookies(rw)
+	require.Len(t, cookies, 3)
+
+	// The refresh token cookie is sent only to the refresh endpoint.
+	assert.Equal(t, "/auth/refresh", cookies["refresh_token"].Path)
+	assert.True(t, cookies["refresh_token"].HttpOnly)
+	assert.Equal(t, "/", cookies[DefaultCookieOptions().AccessTokenName].Path)
+	assert.Equal(t, "/", cookies[DefaultCookieOptions().CSRFName].Path)
+
+	csrf := cookies[DefaultCookieOptions().CSRFName]
+	t.Run("RefreshNoCSRF", func(t *testing.T) {
+		rw := env.doCookies(http.MethodPost, "/auth/refresh", "", cookies["refresh_token"], csrf)
+		assert.Equal(t, http.StatusForbidden, rw.Code)
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		rw := env.doCookies(http.MethodPost, "/auth/refresh", csrf.Value, cookies["refresh_token"], csrf)
+		require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+		assert.Equal(t, "/auth/refresh", responseCookies(rw)["refresh_token"].Path)
+	})
+
+	t.Run("Logout", func(t *testing.T) {
+		rw := env.doCookies(http.MethodPost, "/auth/logout", csrf.Value, cookies[DefaultCookieOptions().AccessTokenName], csrf)
+		require.Less(t, rw.Code, 300, rw.Body.String())
+		cleared := responseCookies(rw)
+		require.Contains(t, cleared, "refresh_token")
+		// The refresh token cookie could be removed only with the same path.
+		assert.Equal(t, "/auth/refresh", cleared["refresh_token"].Path)
+		assert.True(t, cleared["refresh_token"].MaxAge < 0)
+	})
+}
+
+func TestSessionCSRF(t *testing.T) {
+	env := newTestEnv(t, WithCookies(DefaultCookieOptions()), WithAccountEndpoints(true))
+	user := env.addUser(t, "john-doe")
+
+	rw := env.do(http.MethodPost, "/auth/login", "", url.Values{"username": {"john-doe"}, "password": {testPassword}})
+	require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+	// The tokens are delivered only with the cookies.
+	output := &LoginOutput{}
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(output))
+	assert.Empty(t, output.AccessToken)
+	assert.Empty(t, output.RefreshToken)
+
+	cookies := responseCookies(rw)
+	access, csrf := cookies[DefaultCookieOptions().AccessTokenName], cookies[DefaultCookieOptions().CSRFName]
+	require.NotNil(t, access)
+	require.NotNil(t, csrf)
+	assert.True(t, access.HttpOnly)
+	// The CSRF token needs to be readable by the client scripts.
+	assert.False(t, csrf.HttpOnly)
+
+	t.Run("SafeMethod", func(t *testing.T) {
+		rw := env.doCookies(http.MethodGet, "/auth/me", "", access)
+		assert.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	})
+
+	t.Run("MissingHeader", func(t *testing.T) {
+		rw := env.doCookies(http.MethodPost, "/auth/username/change", "", access, csrf)
+		assert.Equal(t, http.StatusForbidden, rw.Code)
+	})
+
+	t.Run("InvalidHeader", func(t *testing.T) {
+		rw := env.doCookies(http.MethodPost, "/auth/username/change", "invalid", access, csrf)
+		assert.Equal(t, http.StatusForbidden, rw.Code)
+	})
+
+	t.Run("MissingCookie", func(t *testing.T) {
+		rw := env.doCookies(http.MethodPost, "/auth/username/change", csrf.Value, access)
+		assert.Equal(t, http.StatusForbidden, rw.Code)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		rw := env.doCookies(http.MethodPost, "/auth/logout", csrf.Value, access, csrf)
+		assert.Less(t, rw.Code, 300, rw.Body.String())
+	})
+
+	t.Run("BearerToken", func(t *testing.T) {
+		// The requests authenticated with the Authorization header are not the subject of the CSRF.
+		rw := env.do(http.MethodPost, "/auth/logout", env.token(t, user).AccessToken, nil)
+		assert.Less(t, rw.Code, 300, rw.Body.String())
+	})
+}
+
+// doCookies serves the request with provided 'cookies'. If 'csrf' is not empty it is set in the CSRF header.
+func (e *testEnv) doCookies(method, path, csrf string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(""))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range cookies {
+		req.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+	}
+	if csrf != "" {
+		req.Header.Set(DefaultCookieOptions().CSRFHeader, csrf)
+	}
+	rw := httptest.NewRecorder()
+	e.router.ServeHTTP(rw, req)
+	return rw
+}
+
+func responseCookies(rw *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range rw.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return cookies
+}
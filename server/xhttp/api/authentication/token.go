@@ -0,0 +1,195 @@
+package authentication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/codec"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// GrantTypeClientCredentials is the OAuth2 client credentials grant type.
+const GrantTypeClientCredentials = "client_credentials"
+
+// Client is an interface for the OAuth2 client models used by the client credentials grant.
+// The client identifier and the hashed client secret are accessed with the auth.Account username
+// and password hash methods, thus the secrets are hashed and compared by the controller's auth.Authenticator.
+type Client interface {
+	auth.Account
+	// AllowedScopes gets the authorization scopes the client is allowed to request.
+	AllowedScopes() []string
+}
+
+// clientAccount is the interface of the OAuth2 client principals, matched by the tokeners and authorizers.
+type clientAccount interface {
+	auth.Account
+	ClientPrincipal()
+}
+
+var _ clientAccount = &clientPrincipal{}
+
+// clientPrincipal is the account used for issuing the client credentials grant tokens. It marks the token subject as
+// the OAuth2 client, thus the tokeners issue no refresh tokens and don't relate the token with any account.
+type clientPrincipal struct {
+	Client
+}
+
+// ClientPrincipal marks the account as the OAuth2 client principal.
+func (c *clientPrincipal) ClientPrincipal() {}
+
+// Compile time check if the API implements ClientAuthenticator.
+var _ ClientAuthenticator = &API{}
+
+// AuthenticateClient implements ClientAuthenticator interface. It authenticates the client stored in the database
+// for the API client model.
+func (a *API) AuthenticateClient(ctx context.Context, clientID, clientSecret string) error {
+	_, err := a.getClient(ctx, clientID, clientSecret)
+	return err
+}
+
+func (a *API) getClient(ctx context.Context, clientID, clientSecret string) (Client, error) {
+	if a.clientModel == nil {
+		return nil, errors.Wrap(auth.ErrInitialization, "no client model defined for the authentication API")
+	}
+	model, err := a.DB.QueryCtx(ctx, a.clientModel).
+		Filter(filter.New(a.clientIDField, filter.OpEqual, clientID)).
+		Get()
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, errors.Wrap(auth.ErrAccountNotFound, "client not found")
+		}
+		return nil, err
+	}
+	client := model.(Client)
+	if err = a.Controller.Authenticator.ComparePassword(client, clientSecret); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (a *API) initializeClientModel() error {
+	mStruct, err := a.Controller.ModelStruct(a.Options.ClientModel)
+	if err != nil {
+		return err
+	}
+	var ok bool
+	a.clientIDField, ok = mStruct.FieldByName(a.Options.ClientModel.UsernameField())
+	if !ok {
+		return errors.Wrapf(auth.ErrInitialization, "provided invalid client model - no client id field: '%s' found in the model: %s", a.Options.ClientModel.UsernameField(), mStruct)
+	}
+	a.clientModel = mStruct
+	// By default the clients are authenticated using the client model.
+	if a.Options.ClientAuthenticator == nil {
+		a.Options.ClientAuthenticator = a
+	}
+	return nil
+}
+
+func (a *API) handleToken(rw http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		a.marshalErrors(rw, 400, errors.WrapDet(codec.ErrUnmarshalDocument, "provided invalid post form").WithDetail("invalid post form"))
+		return
+	}
+	if grantType := req.PostForm.Get("grant_type"); grantType != GrantTypeClientCredentials {
+		httpError := httputil.ErrInvalidQueryParameter()
+		httpError.Detail = "unsupported grant type"
+		a.marshalErrors(rw, 400, httpError)
+		return
+	}
+
+	ctx := req.Context()
+	clientID, clientSecret, ok := a.getClientCredentials(req)
+	if !ok {
+		rw.Header().Set("WWW-Authenticate", `Basic realm="client"`)
+		httpError := httputil.ErrInvalidAuthenticationInfo()
+		httpError.Detail = "client authentication failed"
+		a.marshalErrors(rw, http.StatusUnauthorized, httpError)
+		return
+	}
+	client, err := a.getClient(ctx, clientID, clientSecret)
+	if err != nil {
+		if errors.Is(err, auth.ErrAuthentication) || errors.Is(err, auth.ErrAccountNotFound) {
+			rw.Header().Set("WWW-Authenticate", `Basic realm="client"`)
+			httpError := httputil.ErrInvalidAuthenticationInfo()
+			httpError.Detail = "client authentication failed"
+			a.marshalErrors(rw, http.StatusUnauthorized, httpError)
+			return
+		}
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+
+	// Get the scopes - if no scope is requested all of the client allowed scopes are granted.
+	scope, err := grantedScope(client, req.PostForm.Get("scope"))
+	if err != nil {
+		httpError := httputil.ErrInsufficientAccountPermissions()
+		httpError.Detail = "requested scope is not allowed for the client"
+		a.marshalErrors(rw, http.StatusForbidden, httpError)
+		return
+	}
+
+	// The token is issued for the client principal, so that the tokener doesn't treat the client as an account.
+	token, err := a.Controller.Tokener.Token(ctx, &clientPrincipal{Client: client},
+		auth.TokenExpirationTime(a.Options.ClientTokenExpiration),
+		auth.TokenScope(scope),
+	)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	// The client credentials grant must not issue the refresh token (RFC 6749 section 4.4.3).
+	if token.RefreshToken != "" {
+		log.Errorf("Controller's Tokener doesn't support client principals - issued the refresh token for the client credentials grant")
+		if err = a.Controller.Tokener.RevokeToken(ctx, token.AccessToken); err != nil {
+			log.Errorf("Revoking client token failed: %v", err)
+		}
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+
+	output := &LoginOutput{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		ExpiresIn:   int64(token.ExpiresIn),
+		Scope:       scope,
+	}
+	buffer := &bytes.Buffer{}
+	if err = json.NewEncoder(buffer).Encode(output); err != nil {
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.WriteHeader(http.StatusOK)
+	if _, err = buffer.WriteTo(rw); err != nil {
+		log.Errorf("Writing to response writer failed: %v", err)
+	}
+}
+
+func grantedScope(client Client, requested string) (string, error) {
+	allowed := client.AllowedScopes()
+	if requested == "" {
+		return strings.Join(allowed, " "), nil
+	}
+	allowedMap := make(map[string]struct{}, len(allowed))
+	for _, scope := range allowed {
+		allowedMap[scope] = struct{}{}
+	}
+	scopes := strings.Fields(requested)
+	for _, scope := range scopes {
+		if _, ok := allowedMap[scope]; !ok {
+			return "", errors.WrapDetf(auth.ErrForbidden, "scope: '%s' is not allowed for the client", scope).
+				WithDetail("Requested scope is not allowed for the client.")
+		}
+	}
+	return strings.Join(scopes, " "), nil
+}
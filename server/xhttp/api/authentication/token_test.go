@@ -0,0 +1,90 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+)
+
+func TestClientCredentials(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t, WithClientModel(&ServiceClient{}))
+	client := &ServiceClient{ClientID: "service-client", Scopes: "read write"}
+	require.NoError(t, testAuthenticator{}.HashAndSetPassword(client, auth.NewPassword("client-secret")))
+	require.NoError(t, env.api.DB.Insert(ctx, env.api.clientModel, client))
+
+	issue := func(t *testing.T, form url.Values) *LoginOutput {
+		t.Helper()
+		rw := env.doClient("/auth/token", "service-client", "client-secret", form)
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+		assert.Equal(t, "no-store", rw.Header().Get("Cache-Control"))
+		output := &LoginOutput{}
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(output))
+		return output
+	}
+
+	t.Run("GrantType", func(t *testing.T) {
+		rw := env.doClient("/auth/token", "service-client", "client-secret", url.Values{"grant_type": {"password"}})
+		assert.Equal(t, http.StatusBadRequest, rw.Code)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		rw := env.doClient("/auth/token", "service-client", "invalid", url.Values{"grant_type": {GrantTypeClientCredentials}})
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+		rw = env.doClient("/auth/token", "unknown-client", "client-secret", url.Values{"grant_type": {GrantTypeClientCredentials}})
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+		rw = env.doClient("/auth/token", "", "", url.Values{"grant_type": {GrantTypeClientCredentials}})
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+
+	t.Run("AllowedScopes", func(t *testing.T) {
+		output := issue(t, url.Values{"grant_type": {GrantTypeClientCredentials}})
+		assert.Equal(t, "read write", output.Scope)
+		// The client credentials grant doesn't issue refresh tokens.
+		assert.Empty(t, output.RefreshToken)
+
+		claims, err := env.tokener.InspectToken(ctx, output.AccessToken)
+		require.NoError(t, err)
+		account := claims.(auth.AccessClaims).GetAccount()
+		_, ok := account.(clientAccount)
+		assert.True(t, ok)
+		assert.Equal(t, "service-client", account.GetUsername())
+	})
+
+	t.Run("RequestedScope", func(t *testing.T) {
+		output := issue(t, url.Values{"grant_type": {GrantTypeClientCredentials}, "scope": {"read"}})
+		assert.Equal(t, "read", output.Scope)
+
+		rw := env.doClient("/auth/token", "service-client", "client-secret", url.Values{"grant_type": {GrantTypeClientCredentials}, "scope": {"read admin"}})
+		assert.Equal(t, http.StatusForbidden, rw.Code, rw.Body.String())
+	})
+
+	t.Run("FormCredentials", func(t *testing.T) {
+		rw := env.do(http.MethodPost, "/auth/token", "", url.Values{
+			"grant_type":    {GrantTypeClientCredentials},
+			"client_id":     {"service-client"},
+			"client_secret": {"client-secret"},
+		})
+		assert.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	})
+
+	t.Run("Introspect", func(t *testing.T) {
+		// The client model authenticates also the introspection clients.
+		output := issue(t, url.Values{"grant_type": {GrantTypeClientCredentials}})
+		rw := env.doClient("/auth/introspect", "service-client", "client-secret", url.Values{"token": {output.AccessToken}})
+		require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+		introspected := &IntrospectOutput{}
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(introspected))
+		assert.True(t, introspected.Active)
+		assert.Equal(t, "service-client", introspected.ClientID)
+		assert.Empty(t, introspected.Username)
+		assert.Equal(t, "read write", introspected.Scope)
+	})
+}
@@ -0,0 +1,228 @@
+package authentication
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/store"
+)
+
+// TestHOTP checks the codes with the SHA1 test vectors from the RFC 6238 appendix B. The vectors are
+// defined for eight digits, thus the six digit codes are their last digits.
+func TestHOTP(t *testing.T) {
+	key := []byte("12345678901234567890")
+	secret := totpEncoding.EncodeToString(key)
+	for _, vector := range []struct {
+		time int64
+		code string
+	}{
+		{time: 59, code: "287082"},
+		{time: 1111111109, code: "081804"},
+		{time: 1111111111, code: "050471"},
+		{time: 1234567890, code: "005924"},
+		{time: 2000000000, code: "279037"},
+		{time: 20000000000, code: "353130"},
+	} {
+		assert.Equal(t, vector.code, hotp(key, vector.time/totpPeriod), "%d", vector.time)
+		step, ok := validateTOTP(secret, vector.code, time.Unix(vector.time, 0))
+		assert.True(t, ok, "%d", vector.time)
+		assert.Equal(t, vector.time/totpPeriod, step)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	require.NoError(t, err)
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	now := time.Now()
+	step := now.Unix() / totpPeriod
+
+	_, ok := validateTOTP(secret, hotp(key, step-1), now)
+	assert.True(t, ok, "previous step")
+	_, ok = validateTOTP(secret, hotp(key, step+1), now)
+	assert.True(t, ok, "next step")
+	_, ok = validateTOTP(secret, hotp(key, step-2), now)
+	assert.False(t, ok, "outside skew")
+
+	code := hotp(key, step)
+	_, ok = validateTOTP(secret, code[:3]+" "+code[3:], now)
+	assert.True(t, ok, "spaced code")
+	_, ok = validateTOTP(secret, code[:5], now)
+	assert.False(t, ok, "short code")
+	_, ok = validateTOTP("invalid-secret!", code, now)
+	assert.False(t, ok, "invalid secret")
+}
+
+// enableTOTP enables the TOTP second factor for the 'user' and returns its secret key.
+func (e *testEnv) enableTOTP(t *testing.T, user *User) []byte {
+	t.Helper()
+	secret, err := generateTOTPSecret()
+	require.NoError(t, err)
+	user.TOTPSecret, user.TOTPEnabled = secret, true
+	require.NoError(t, e.api.updateAccountFields(context.Background(), e.api.DB, user, "TOTPSecret", "TOTPEnabled"))
+	key, err := totpEncoding.DecodeString(secret)
+	require.NoError(t, err)
+	return key
+}
+
+// loginChallenge logs in the 'username' with the testPassword and returns the two factor challenge token.
+func (e *testEnv) loginChallenge(t *testing.T, username string) string {
+	t.Helper()
+	rw := e.do(http.MethodPost, "/auth/login", "", url.Values{"username": {username}, "password": {testPassword}})
+	require.Equal(t, http.StatusAccepted, rw.Code, rw.Body.String())
+	output := &TwoFactorChallengeOutput{}
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(output))
+	require.NotEmpty(t, output.ChallengeToken)
+	assert.Equal(t, "totp", output.ChallengeType)
+	return output.ChallengeToken
+}
+
+func TestTwoFactorLogin(t *testing.T) {
+	currentCode := func(key []byte) string {
+		return hotp(key, time.Now().Unix()/totpPeriod)
+	}
+
+	t.Run("Login", func(t *testing.T) {
+		env := newTestEnv(t)
+		user := env.addUser(t, "john-doe")
+		key := env.enableTOTP(t, user)
+
+		challenge := env.loginChallenge(t, "john-doe")
+		rw := env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "code": {currentCode(key)}})
+		require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+		output := &LoginOutput{}
+		require.NoError(t, json.NewDecoder(rw.Body).Decode(output))
+		assert.NotEmpty(t, output.AccessToken)
+
+		// The challenge token could be used only once.
+		rw = env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "code": {currentCode(key)}})
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+		// The code could not be replayed with another challenge.
+		challenge = env.loginChallenge(t, "john-doe")
+		rw = env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "code": {currentCode(key)}})
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	})
+
+	for name, storeOption := range map[string]func(s *testStore) Option{
+		"Atomic":    func(s *testStore) Option { return WithStore(s) },
+		"NonAtomic": func(s *testStore) Option { return WithStore(&plainStore{Store: s}) },
+	} {
+		storeOption := storeOption
+		t.Run("AttemptLimit"+name, func(t *testing.T) {
+			env := newTestEnv(t, storeOption(&testStore{records: map[string]*store.Record{}}))
+			user := env.addUser(t, "john-doe")
+			key := env.enableTOTP(t, user)
+			challenge := env.loginChallenge(t, "john-doe")
+
+			for i := 0; i < maxTwoFactorAttempts; i++ {
+				rw := env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "code": {"000000"}})
+				require.Equal(t, http.StatusUnauthorized, rw.Code, rw.Body.String())
+			}
+			// The challenge is discarded after reaching the attempts limit - even for the valid code.
+			rw := env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "code": {currentCode(key)}})
+			assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+			// New challenge allows to login.
+			challenge = env.loginChallenge(t, "john-doe")
+			rw = env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "code": {currentCode(key)}})
+			assert.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+		})
+	}
+
+	t.Run("ConcurrentReplay", func(t *testing.T) {
+		env := newTestEnv(t)
+		user := env.addUser(t, "john-doe")
+		code := currentCode(env.enableTOTP(t, user))
+
+		// Only one of the concurrent verifications of the same code succeeds.
+		var (
+			wg        sync.WaitGroup
+			mu        sync.Mutex
+			succeeded int
+		)
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := env.api.verifyTOTPCode(context.Background(), user, code); err == nil {
+					mu.Lock()
+					succeeded++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, 1, succeeded)
+	})
+
+	t.Run("Lockout", func(t *testing.T) {
+		env := newTestEnv(t, WithLockoutPolicy(testLockoutPolicy()))
+		user := env.addUser(t, "john-doe")
+		env.enableTOTP(t, user)
+
+		// The second factor failures are counted by the login lockout.
+		for i := 0; i < 3; i++ {
+			challenge := env.loginChallenge(t, "john-doe")
+			rw := env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "code": {"000000"}})
+			require.Equal(t, http.StatusUnauthorized, rw.Code, rw.Body.String())
+		}
+		rw := env.do(http.MethodPost, "/auth/login", "", url.Values{"username": {"john-doe"}, "password": {testPassword}})
+		assert.Equal(t, http.StatusTooManyRequests, rw.Code)
+	})
+}
+
+func TestTOTPEnrollment(t *testing.T) {
+	env := newTestEnv(t)
+	user := env.addUser(t, "john-doe")
+	token := env.token(t, user)
+
+	rw := env.do(http.MethodPost, "/auth/totp/enroll", token.AccessToken, nil)
+	require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+	enroll := &TOTPEnrollOutput{}
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(enroll))
+	assert.Contains(t, enroll.URI, "otpauth://totp/")
+	key, err := totpEncoding.DecodeString(enroll.Secret)
+	require.NoError(t, err)
+	step := time.Now().Unix() / totpPeriod
+
+	rw = env.do(http.MethodPost, "/auth/totp/verify", token.AccessToken, url.Values{"code": {"000000"}})
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+	stored, ok := env.getUser(t, user.ID)
+	require.True(t, ok)
+	assert.False(t, stored.TOTPEnabled)
+
+	rw = env.do(http.MethodPost, "/auth/totp/verify", token.AccessToken, url.Values{"code": {hotp(key, step)}})
+	require.Equal(t, http.StatusOK, rw.Code, rw.Body.String())
+	codes := &RecoveryCodesOutput{}
+	require.NoError(t, json.NewDecoder(rw.Body).Decode(codes))
+	require.NotEmpty(t, codes.RecoveryCodes)
+	stored, ok = env.getUser(t, user.ID)
+	require.True(t, ok)
+	assert.True(t, stored.TOTPEnabled)
+	assert.Len(t, stored.RecoveryCodes, len(codes.RecoveryCodes))
+
+	// The recovery code could be used only once.
+	challenge := env.loginChallenge(t, "john-doe")
+	rw = env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "recovery_code": {codes.RecoveryCodes[0]}})
+	require.Equal(t, http.StatusCreated, rw.Code, rw.Body.String())
+	challenge = env.loginChallenge(t, "john-doe")
+	rw = env.do(http.MethodPost, "/auth/login/totp", "", url.Values{"challenge_token": {challenge}, "recovery_code": {codes.RecoveryCodes[0]}})
+	assert.Equal(t, http.StatusUnauthorized, rw.Code)
+
+	rw = env.do(http.MethodPost, "/auth/totp/disable", token.AccessToken, url.Values{"code": {hotp(key, step+1)}})
+	require.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+	stored, ok = env.getUser(t, user.ID)
+	require.True(t, ok)
+	assert.False(t, stored.TOTPEnabled)
+	assert.Empty(t, stored.TOTPSecret)
+}
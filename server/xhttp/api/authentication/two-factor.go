@@ -0,0 +1,530 @@
+package authentication
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/log"
+)
+
+// TOTPAccount is an interface for the accounts that could use time-based one time password (TOTP) second factor.
+type TOTPAccount interface {
+	auth.Account
+	// GetTOTPSecret gets the base32 encoded TOTP secret.
+	GetTOTPSecret() string
+	// SetTOTPSecret sets the base32 encoded TOTP secret.
+	SetTOTPSecret(secret string)
+	// TOTPSecretField gets the TOTP secret field name.
+	TOTPSecretField() string
+	// IsTOTPEnabled checks if the TOTP second factor is enabled for the account.
+	IsTOTPEnabled() bool
+	// SetTOTPEnabled enables or disables the TOTP second factor for the account.
+	SetTOTPEnabled(enabled bool)
+	// TOTPEnabledField gets the TOTP enabled field name.
+	TOTPEnabledField() string
+	// GetRecoveryCodes gets the hashed recovery codes.
+	GetRecoveryCodes() []string
+	// SetRecoveryCodes sets the hashed recovery codes.
+	SetRecoveryCodes(codes []string)
+	// RecoveryCodesField gets the recovery codes field name.
+	RecoveryCodesField() string
+}
+
+// TwoFactorChallengeOutput is the login output for the accounts with enabled second factor.
+// The challenge token needs to be exchanged together with the second factor code for the account tokens.
+type TwoFactorChallengeOutput struct {
+	ChallengeToken string `json:"challenge_token"`
+	ChallengeType  string `json:"challenge_type"`
+	ExpiresIn      int64  `json:"expires_in"`
+}
+
+// TOTPEnrollOutput is the output of the TOTP enrolment.
+type TOTPEnrollOutput struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+// TOTPCodeInput is the input with the TOTP or recovery code.
+type TOTPCodeInput struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// RecoveryCodesOutput is the output with the plain recovery codes. These codes are returned only once.
+type RecoveryCodesOutput struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorLoginInput is the second step login input.
+type TwoFactorLoginInput struct {
+	ChallengeToken string `json:"challenge_token"`
+	TOTPCodeInput
+}
+
+// twoFactorChallenge is the store value of the login challenge.
+type twoFactorChallenge struct {
+	AccountID     string    `json:"account_id"`
+	RememberToken bool      `json:"remember_token"`
+	Attempts      int       `json:"attempts"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// maxTwoFactorAttempts is the maximum number of codes provided for single challenge.
+const maxTwoFactorAttempts = 5
+
+// counterStore is the store that supports atomic counters i.e. the xstore.AtomicStore implementations.
+type counterStore interface {
+	Increment(ctx context.Context, key string, delta int64, options ...store.SetOption) (int64, error)
+}
+
+// setIfNotExistsStore is the store that supports atomic conditional set i.e. the xstore.AtomicStore implementations.
+type setIfNotExistsStore interface {
+	SetIfNotExists(ctx context.Context, record *store.Record, options ...store.SetOption) (bool, error)
+}
+
+func (a *API) writeTwoFactorChallenge(ctx context.Context, rw http.ResponseWriter, account auth.Account, rememberToken bool) {
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		log.Errorf("Getting account primary key string value failed: %v", err)
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	token, err := randomToken(32)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	challenge := &twoFactorChallenge{
+		AccountID:     accountID,
+		RememberToken: rememberToken,
+		ExpiresAt:     time.Now().Add(a.Options.TwoFactorChallengeExpiration),
+	}
+	if err = a.setTwoFactorChallenge(ctx, token, challenge); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	output := &TwoFactorChallengeOutput{
+		ChallengeToken: token,
+		ChallengeType:  "totp",
+		ExpiresIn:      int64(a.Options.TwoFactorChallengeExpiration / time.Second),
+	}
+	a.writeJSON(rw, http.StatusAccepted, output)
+}
+
+func (a *API) handleTwoFactorLogin(rw http.ResponseWriter, req *http.Request) {
+	input := &TwoFactorLoginInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.ChallengeToken = q.Get("challenge_token")
+		input.Code = q.Get("code")
+		input.RecoveryCode = q.Get("recovery_code")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	ctx := req.Context()
+
+	challenge, err := a.getTwoFactorChallenge(ctx, input.ChallengeToken)
+	if err != nil {
+		if !errors.Is(err, store.ErrRecordNotFound) {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+		httpError := httputil.ErrInvalidAuthenticationInfo()
+		httpError.Detail = "provided invalid or expired challenge token"
+		a.marshalErrors(rw, 0, httpError)
+		return
+	}
+
+	model := mapping.NewModel(a.model)
+	if err = model.SetPrimaryKeyStringValue(challenge.AccountID); err != nil {
+		log.Errorf("Setting primary key string value failed: %v - in two factor login", err)
+		a.marshalErrors(rw, 500, httputil.ErrInternalError())
+		return
+	}
+	if err = a.DB.QueryCtx(ctx, a.model, model).Refresh(); err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			err = auth.ErrAccountNotFound
+		}
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	account, ok := model.(TOTPAccount)
+	if !ok || !account.IsTOTPEnabled() {
+		a.marshalErrors(rw, 0, httputil.ErrInvalidAuthenticationInfo())
+		return
+	}
+
+	// The second factor failures are the subject of the login lockout policy as well.
+	if a.Options.LockoutPolicy != nil {
+		retryAfter, locked, err := a.checkLockout(ctx, req, account.GetUsername())
+		if err != nil {
+			log.Errorf("Checking login lockout failed: %v", err)
+			a.marshalErrors(rw, 500, httputil.ErrInternalError())
+			return
+		}
+		if locked {
+			a.writeLockedOut(rw, retryAfter)
+			return
+		}
+	}
+
+	// Each challenge allows only limited number of attempts. The attempt is counted before the code is verified,
+	// so that the concurrent requests could not exceed the limit.
+	attempts, err := a.registerTwoFactorAttempt(ctx, input.ChallengeToken, challenge)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if attempts > maxTwoFactorAttempts {
+		a.deleteTwoFactorChallenge(ctx, input.ChallengeToken)
+		httpError := httputil.ErrInvalidAuthenticationInfo()
+		httpError.Detail = "provided invalid or expired challenge token"
+		a.marshalErrors(rw, 0, httpError)
+		return
+	}
+
+	if err = a.verifySecondFactor(ctx, account, &input.TOTPCodeInput); err != nil {
+		if attempts == maxTwoFactorAttempts {
+			a.deleteTwoFactorChallenge(ctx, input.ChallengeToken)
+		}
+		if a.Options.LockoutPolicy != nil {
+			a.registerLoginFailure(ctx, req, account.GetUsername())
+		}
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+
+	// The challenge token could be used only once.
+	if err = a.Options.Store.Delete(ctx, twoFactorChallengeKey(input.ChallengeToken)); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if _, ok := a.Options.Store.(counterStore); ok {
+		if err = a.Options.Store.Delete(ctx, twoFactorAttemptsKey(input.ChallengeToken)); err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+			log.Errorf("Deleting two factor challenge attempts failed: %v", err)
+		}
+	}
+	// The login failures are cleared only after the account passed both factors.
+	if a.Options.LockoutPolicy != nil {
+		a.resetLoginFailures(ctx, account.GetUsername())
+	}
+	a.writeLoginTokens(ctx, rw, account, challenge.RememberToken)
+}
+
+// registerTwoFactorAttempt increases the number of attempts of the challenge with provided 'token' and returns
+// its current value. If the store supports atomic counters the attempts are counted atomically.
+func (a *API) registerTwoFactorAttempt(ctx context.Context, token string, challenge *twoFactorChallenge) (int, error) {
+	if counter, ok := a.Options.Store.(counterStore); ok {
+		attempts, err := counter.Increment(ctx, twoFactorAttemptsKey(token), 1, store.SetWithTTL(time.Until(challenge.ExpiresAt)))
+		if err != nil {
+			return 0, err
+		}
+		return int(attempts), nil
+	}
+	challenge.Attempts++
+	if err := a.setTwoFactorChallenge(ctx, token, challenge); err != nil {
+		return 0, err
+	}
+	return challenge.Attempts, nil
+}
+
+// deleteTwoFactorChallenge deletes the challenge with provided 'token' along with its attempts counter.
+func (a *API) deleteTwoFactorChallenge(ctx context.Context, token string) {
+	for _, key := range []string{twoFactorChallengeKey(token), twoFactorAttemptsKey(token)} {
+		if err := a.Options.Store.Delete(ctx, key); err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+			log.Errorf("Deleting two factor challenge failed: %v", err)
+		}
+	}
+}
+
+func (a *API) handleTOTPEnroll(rw http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	account, err := a.getTOTPAccount(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if account.IsTOTPEnabled() {
+		httpError := httputil.ErrForbiddenOperation()
+		httpError.Detail = "two factor authentication is already enabled"
+		a.marshalErrors(rw, 0, httpError)
+		return
+	}
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	account.SetTOTPSecret(secret)
+	if err = a.updateAccountFields(ctx, a.DB, account, account.TOTPSecretField()); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	output := &TOTPEnrollOutput{
+		Secret: secret,
+		URI:    totpURI(a.Options.TOTPIssuer, account.GetUsername(), secret),
+	}
+	a.writeJSON(rw, http.StatusCreated, output)
+}
+
+func (a *API) handleTOTPVerify(rw http.ResponseWriter, req *http.Request) {
+	input := &TOTPCodeInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.Code = q.Get("code")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	ctx := req.Context()
+	account, err := a.getTOTPAccount(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if account.IsTOTPEnabled() || account.GetTOTPSecret() == "" {
+		httpError := httputil.ErrForbiddenOperation()
+		httpError.Detail = "no pending two factor enrolment"
+		a.marshalErrors(rw, 0, httpError)
+		return
+	}
+	if err = a.verifyTOTPCode(ctx, account, input.Code); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+
+	codes, hashed, err := a.generateRecoveryCodes()
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	account.SetTOTPEnabled(true)
+	account.SetRecoveryCodes(hashed)
+	if err = a.updateAccountFields(ctx, a.DB, account, account.TOTPEnabledField(), account.RecoveryCodesField()); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	a.writeJSON(rw, http.StatusOK, &RecoveryCodesOutput{RecoveryCodes: codes})
+}
+
+func (a *API) handleTOTPDisable(rw http.ResponseWriter, req *http.Request) {
+	input := &TOTPCodeInput{}
+	if err := a.decodeInput(req, input, func(q url.Values) {
+		input.Code = q.Get("code")
+		input.RecoveryCode = q.Get("recovery_code")
+	}); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	ctx := req.Context()
+	account, err := a.getTOTPAccount(ctx)
+	if err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	if !account.IsTOTPEnabled() {
+		httpError := httputil.ErrForbiddenOperation()
+		httpError.Detail = "two factor authentication is not enabled"
+		a.marshalErrors(rw, 0, httpError)
+		return
+	}
+	if err = a.verifySecondFactor(ctx, account, input); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	account.SetTOTPEnabled(false)
+	account.SetTOTPSecret("")
+	account.SetRecoveryCodes(nil)
+	if err = a.updateAccountFields(ctx, a.DB, account, account.TOTPEnabledField(), account.TOTPSecretField(), account.RecoveryCodesField()); err != nil {
+		a.marshalErrors(rw, 0, err)
+		return
+	}
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// verifySecondFactor verifies the TOTP code or the recovery code provided in the 'input'.
+// Used recovery code is removed from the account.
+func (a *API) verifySecondFactor(ctx context.Context, account TOTPAccount, input *TOTPCodeInput) error {
+	if input.RecoveryCode == "" {
+		return a.verifyTOTPCode(ctx, account, input.Code)
+	}
+	codes := account.GetRecoveryCodes()
+	i, ok := a.matchRecoveryCode(codes, input.RecoveryCode)
+	if !ok {
+		return errors.WrapDet(auth.ErrInvalidSecret, "invalid recovery code").WithDetail("Provided invalid recovery code.")
+	}
+	remaining := make([]string, 0, len(codes)-1)
+	remaining = append(remaining, codes[:i]...)
+	remaining = append(remaining, codes[i+1:]...)
+	account.SetRecoveryCodes(remaining)
+	return a.updateAccountFields(ctx, a.DB, account, account.RecoveryCodesField())
+}
+
+// verifyTOTPCode checks the TOTP 'code' for given 'account'. Each valid code could be used only once.
+func (a *API) verifyTOTPCode(ctx context.Context, account TOTPAccount, code string) error {
+	step, ok := validateTOTP(account.GetTOTPSecret(), code, time.Now())
+	if !ok {
+		return errors.WrapDet(auth.ErrInvalidSecret, "invalid totp code").WithDetail("Provided invalid code.")
+	}
+	accountID, err := account.GetPrimaryKeyStringValue()
+	if err != nil {
+		return errors.Wrapf(auth.ErrInternalError, "getting account primary key string value failed: %v", err)
+	}
+	// Prevent the code replays. If the store supports it, the code step is claimed atomically so that concurrent
+	// requests with the same code couldn't both pass.
+	record := &store.Record{Key: fmt.Sprintf("nrn_totp_used_%s_%d", accountID, step), Value: []byte{1}}
+	ttl := store.SetWithTTL(time.Duration(totpPeriod*(2*totpSkew+1)) * time.Second)
+	if atomicStore, ok := a.Options.Store.(setIfNotExistsStore); ok {
+		set, err := atomicStore.SetIfNotExists(ctx, record, ttl)
+		if err != nil {
+			return err
+		}
+		if !set {
+			return errTOTPCodeUsed()
+		}
+		return nil
+	}
+	if _, err = a.Options.Store.Get(ctx, record.Key); err == nil {
+		return errTOTPCodeUsed()
+	} else if !errors.Is(err, store.ErrRecordNotFound) {
+		return err
+	}
+	return a.Options.Store.Set(ctx, record, ttl)
+}
+
+func errTOTPCodeUsed() error {
+	return errors.WrapDet(auth.ErrInvalidSecret, "totp code already used").WithDetail("Provided code was already used.")
+}
+
+func (a *API) getTOTPAccount(ctx context.Context) (TOTPAccount, error) {
+	account, err := a.getAuthenticatedAccount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totpAccount, ok := account.(TOTPAccount)
+	if !ok {
+		return nil, errors.Wrap(auth.ErrInternalError, "account model doesn't implement TOTPAccount interface")
+	}
+	return totpAccount, nil
+}
+
+// generateRecoveryCodes creates the plain recovery codes along with their hashed values.
+func (a *API) generateRecoveryCodes() (codes []string, hashed []string, err error) {
+	for i := 0; i < a.Options.RecoveryCodesCount; i++ {
+		b := make([]byte, 10)
+		if _, err = rand.Read(b); err != nil {
+			return nil, nil, errors.Wrapf(auth.ErrInternalError, "generating recovery code failed: %v", err)
+		}
+		code := strings.ToLower(base32.StdEncoding.EncodeToString(b))[:10]
+		hash, err := a.hashSecret(code)
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, code[:5]+"-"+code[5:])
+		hashed = append(hashed, hash)
+	}
+	return codes, hashed, nil
+}
+
+func (a *API) matchRecoveryCode(hashed []string, code string) (int, bool) {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	for i, hash := range hashed {
+		if a.compareSecret(hash, code) {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// hashSecret hashes provided 'secret' using controller's authenticator. The result contains encoded salt and hash.
+func (a *API) hashSecret(secret string) (string, error) {
+	// The account model is used only as the holder of the hash and the salt.
+	holder := mapping.NewModel(a.model).(auth.Account)
+	if err := a.Controller.Authenticator.HashAndSetPassword(holder, &auth.Password{Password: secret}); err != nil {
+		return "", err
+	}
+	var salt []byte
+	if saltGetter, ok := holder.(auth.SaltGetter); ok {
+		salt = saltGetter.GetSalt()
+	}
+	return base64.RawStdEncoding.EncodeToString(salt) + "$" + base64.RawStdEncoding.EncodeToString(holder.GetPasswordHash()), nil
+}
+
+// compareSecret compares the 'secret' with the 'hashed' value created by the hashSecret function.
+func (a *API) compareSecret(hashed, secret string) bool {
+	i := strings.IndexByte(hashed, '$')
+	if i == -1 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(hashed[:i])
+	if err != nil {
+		return false
+	}
+	hash, err := base64.RawStdEncoding.DecodeString(hashed[i+1:])
+	if err != nil {
+		return false
+	}
+	holder := mapping.NewModel(a.model).(auth.Account)
+	holder.SetPasswordHash(hash)
+	if saltSetter, ok := holder.(auth.SaltSetter); ok {
+		saltSetter.SetSalt(salt)
+	}
+	return a.Controller.Authenticator.ComparePassword(holder, secret) == nil
+}
+
+func (a *API) getTwoFactorChallenge(ctx context.Context, token string) (*twoFactorChallenge, error) {
+	if token == "" {
+		return nil, store.ErrRecordNotFound
+	}
+	record, err := a.Options.Store.Get(ctx, twoFactorChallengeKey(token))
+	if err != nil {
+		return nil, err
+	}
+	challenge := &twoFactorChallenge{}
+	if err = json.Unmarshal(record.Value, challenge); err != nil {
+		return nil, errors.Wrapf(auth.ErrInternalError, "unmarshal two factor challenge failed: %v", err)
+	}
+	return challenge, nil
+}
+
+func (a *API) setTwoFactorChallenge(ctx context.Context, token string, challenge *twoFactorChallenge) error {
+	value, err := json.Marshal(challenge)
+	if err != nil {
+		return errors.Wrapf(auth.ErrInternalError, "marshal two factor challenge failed: %v", err)
+	}
+	record := &store.Record{Key: twoFactorChallengeKey(token), Value: value, ExpiresAt: challenge.ExpiresAt}
+	return a.Options.Store.Set(ctx, record, store.SetWithTTL(time.Until(challenge.ExpiresAt)))
+}
+
+func twoFactorChallengeKey(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return "nrn_2fa_challenge_" + hex.EncodeToString(h[:])
+}
+
+func twoFactorAttemptsKey(token string) string {
+	return twoFactorChallengeKey(token) + "_attempts"
+}
+
+func randomToken(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrapf(auth.ErrInternalError, "generating random token failed: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
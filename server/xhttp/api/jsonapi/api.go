@@ -40,6 +40,7 @@ type API struct {
 
 	handlers       map[*mapping.ModelStruct]interface{}
 	models         map[*mapping.ModelStruct]struct{}
+	authorizations map[authorizationKey]*Authorization
 	defaultHandler *DefaultHandler
 }
 
@@ -49,6 +50,7 @@ func New(options ...Option) *API {
 		Options:        &Options{PayloadLinks: true},
 		handlers:       map[*mapping.ModelStruct]interface{}{},
 		models:         map[*mapping.ModelStruct]struct{}{},
+		authorizations: map[authorizationKey]*Authorization{},
 		defaultHandler: &DefaultHandler{},
 	}
 	for _, option := range options {
@@ -123,6 +125,10 @@ func (a *API) InitializeAPI(c *core.Controller) error {
 		a.models[mStruct] = struct{}{}
 	}
 
+	// Map the endpoint authorizations.
+	if err := a.initializeAuthorizations(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -178,6 +184,7 @@ func (a *API) setInsertRoute(router *httprouter.Router, modelHandler interface{}
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	insertChain := append(a.Options.Middlewares, MidContentType, httputil.MidStoreEndpoint(endpoint))
+	insertChain = append(insertChain, a.authorizeMiddlewares(endpoint)...)
 	if insertMiddlewarer, ok := modelHandler.(server.InsertMiddlewarer); ok {
 		insertChain = append(insertChain, insertMiddlewarer.InsertMiddlewares()...)
 	}
@@ -199,6 +206,7 @@ func (a *API) setInsertRelationRoute(router *httprouter.Router, modelHandler int
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chain := append(a.Options.Middlewares, MidContentType, middleware.StoreIDFromParams("id"), httputil.MidStoreEndpoint(endpoint))
+	chain = append(chain, a.authorizeMiddlewares(endpoint)...)
 	if insertMiddlewarer, ok := modelHandler.(server.InsertRelationsMiddlewarer); ok {
 		chain = append(chain, insertMiddlewarer.InsertRelationsMiddlewares()...)
 	}
@@ -219,6 +227,7 @@ func (a *API) setDeleteRoute(router *httprouter.Router, modelHandler interface{}
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chain := append(a.Options.Middlewares, middleware.StoreIDFromParams("id"), httputil.MidStoreEndpoint(endpoint))
+	chain = append(chain, a.authorizeMiddlewares(endpoint)...)
 	if middlewarer, ok := modelHandler.(server.DeleteMiddlewarer); ok {
 		chain = append(chain, middlewarer.DeleteMiddlewares()...)
 	}
@@ -240,6 +249,7 @@ func (a *API) setDeleteRelationRoute(router *httprouter.Router, modelHandler int
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chain := append(a.Options.Middlewares, MidContentType, middleware.StoreIDFromParams("id"), httputil.MidStoreEndpoint(endpoint))
+	chain = append(chain, a.authorizeMiddlewares(endpoint)...)
 	if middlewarer, ok := modelHandler.(server.DeleteRelationsMiddlewarer); ok {
 		chain = append(chain, middlewarer.DeleteRelationsMiddlewares()...)
 	}
@@ -260,6 +270,7 @@ func (a *API) setGetRoute(router *httprouter.Router, modelHandler interface{}, m
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chain := append(a.Options.Middlewares, MidAccept, middleware.StoreIDFromParams("id"), httputil.MidStoreEndpoint(endpoint))
+	chain = append(chain, a.authorizeMiddlewares(endpoint)...)
 	if middlewarer, ok := modelHandler.(server.GetMiddlewarer); ok {
 		chain = append(chain, middlewarer.GetMiddlewares()...)
 	}
@@ -281,6 +292,7 @@ func (a *API) setGetRelationRoute(router *httprouter.Router, modelHandler interf
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chain := append(a.Options.Middlewares, MidAccept, middleware.StoreIDFromParams("id"), httputil.MidStoreEndpoint(endpoint))
+	chain = append(chain, a.authorizeMiddlewares(endpoint)...)
 	if middlewarer, ok := modelHandler.(server.GetRelationMiddlewarer); ok {
 		chain = append(chain, middlewarer.GetRelatedMiddlewares()...)
 	}
@@ -302,6 +314,7 @@ func (a *API) setGetRelationshipRoute(router *httprouter.Router, modelHandler in
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chainRelated := append(a.Options.Middlewares, MidAccept, middleware.StoreIDFromParams("id"), httputil.MidStoreEndpoint(endpoint))
+	chainRelated = append(chainRelated, a.authorizeMiddlewares(endpoint)...)
 	if middlewarer, ok := modelHandler.(server.GetRelationMiddlewarer); ok {
 		chainRelated = append(chainRelated, middlewarer.GetRelatedMiddlewares()...)
 	}
@@ -322,6 +335,7 @@ func (a *API) setListRoute(router *httprouter.Router, modelHandler interface{},
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chain := append(a.Options.Middlewares, MidAccept, httputil.MidStoreEndpoint(endpoint))
+	chain = append(chain, a.authorizeMiddlewares(endpoint)...)
 	if middlewarer, ok := modelHandler.(server.ListMiddlewarer); ok {
 		chain = append(chain, middlewarer.ListMiddlewares()...)
 	}
@@ -342,6 +356,7 @@ func (a *API) setUpdateRoute(router *httprouter.Router, modelHandler interface{}
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chain := append(a.Options.Middlewares, MidContentType, middleware.StoreIDFromParams("id"), httputil.MidStoreEndpoint(endpoint))
+	chain = append(chain, a.authorizeMiddlewares(endpoint)...)
 	if middlewarer, ok := modelHandler.(server.UpdateMiddlewarer); ok {
 		chain = append(chain, middlewarer.UpdateMiddlewares()...)
 	}
@@ -363,6 +378,7 @@ func (a *API) setUpdateRelationRoute(router *httprouter.Router, modelHandler int
 	}
 	a.Endpoints = append(a.Endpoints, endpoint)
 	chain := append(a.Options.Middlewares, MidContentType, middleware.StoreIDFromParams("id"), httputil.MidStoreEndpoint(endpoint))
+	chain = append(chain, a.authorizeMiddlewares(endpoint)...)
 	if middlewarer, ok := modelHandler.(server.UpdateRelationsMiddlewarer); ok {
 		chain = append(chain, middlewarer.UpdateRelationsMiddlewares()...)
 	}
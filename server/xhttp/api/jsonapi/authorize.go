@@ -0,0 +1,166 @@
+package jsonapi
+
+import (
+	"net/http"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/server"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+)
+
+// Scope is the simple auth.Scope implementation that could be used for the endpoint authorization.
+type Scope string
+
+// ScopeName implements auth.Scope interface.
+func (s Scope) ScopeName() string {
+	return string(s)
+}
+
+// Role is the simple auth.Role implementation that could be used for the endpoint authorization.
+type Role string
+
+// RoleName implements auth.Role interface.
+func (r Role) RoleName() string {
+	return string(r)
+}
+
+// Authorization defines the requirements that the authenticated account must fulfill in order to access the endpoint.
+type Authorization struct {
+	// Scopes are the scopes required for the endpoint.
+	Scopes []auth.Scope
+	// AllowedRoles are the roles that are allowed to access the endpoint.
+	AllowedRoles []auth.Role
+	// DisallowedRoles are the roles that are not allowed to access the endpoint.
+	DisallowedRoles []auth.Role
+}
+
+func (a *Authorization) verifyOptions() []auth.VerifyOption {
+	var options []auth.VerifyOption
+	if len(a.Scopes) > 0 {
+		options = append(options, auth.VerifyScopes(a.Scopes...))
+	}
+	if len(a.AllowedRoles) > 0 {
+		options = append(options, auth.VerifyAllowedRoles(a.AllowedRoles...))
+	}
+	if len(a.DisallowedRoles) > 0 {
+		options = append(options, auth.VerifyDisallowedRoles(a.DisallowedRoles...))
+	}
+	return options
+}
+
+// EndpointAuthorization matches the model endpoints with the authorization requirements.
+// If the Relation is defined, the authorization applies only to given relation endpoints.
+// Empty Methods means that the authorization applies to all the model (or relation) endpoints.
+type EndpointAuthorization struct {
+	Model         mapping.Model
+	Relation      string
+	Methods       []query.Method
+	Authorization Authorization
+}
+
+// authorizationKey is the key used for mapping the endpoints with their authorizations.
+// The query.InvalidMethod is used for the authorizations that applies to all the methods.
+type authorizationKey struct {
+	model    *mapping.ModelStruct
+	relation string
+	method   query.Method
+}
+
+// initializeAuthorizations maps the endpoint authorizations defined in the options.
+func (a *API) initializeAuthorizations() error {
+	if len(a.Options.Authorizations) == 0 {
+		return nil
+	}
+	if a.Options.Verifier == nil {
+		a.Options.Verifier = a.Controller.Verifier
+	}
+	if a.Options.Verifier == nil {
+		return errors.WrapDetf(server.ErrServerOptions, "json:api endpoint authorizations requires auth.Verifier")
+	}
+	for _, endpointAuthorization := range a.Options.Authorizations {
+		mStruct, err := a.Controller.ModelStruct(endpointAuthorization.Model)
+		if err != nil {
+			return err
+		}
+		if endpointAuthorization.Relation != "" {
+			relation, ok := mStruct.RelationByName(endpointAuthorization.Relation)
+			if !ok {
+				return errors.WrapDetf(server.ErrServerOptions, "authorization defined for unknown relation: '%s' in model: '%s'", endpointAuthorization.Relation, mStruct)
+			}
+			endpointAuthorization.Relation = relation.NeuronName()
+		}
+		authorization := endpointAuthorization.Authorization
+		methods := endpointAuthorization.Methods
+		if len(methods) == 0 {
+			methods = []query.Method{query.InvalidMethod}
+		}
+		for _, method := range methods {
+			a.authorizations[authorizationKey{model: mStruct, relation: endpointAuthorization.Relation, method: method}] = &authorization
+		}
+	}
+	return nil
+}
+
+// endpointAuthorization gets the authorization for provided endpoint. The relation authorizations takes
+// precedence over the model ones, and the method specific over the ones defined for all the methods.
+func (a *API) endpointAuthorization(endpoint *server.Endpoint) (*Authorization, bool) {
+	var keys []authorizationKey
+	if endpoint.Relation != nil {
+		keys = append(keys,
+			authorizationKey{model: endpoint.ModelStruct, relation: endpoint.Relation.NeuronName(), method: endpoint.QueryMethod},
+			authorizationKey{model: endpoint.ModelStruct, relation: endpoint.Relation.NeuronName()},
+		)
+	}
+	keys = append(keys,
+		authorizationKey{model: endpoint.ModelStruct, method: endpoint.QueryMethod},
+		authorizationKey{model: endpoint.ModelStruct},
+	)
+	for _, key := range keys {
+		if authorization, ok := a.authorizations[key]; ok {
+			return authorization, true
+		}
+	}
+	return nil, false
+}
+
+// authorizeMiddlewares returns the authorization middleware for provided endpoint if it has any authorization defined.
+// The resource policy middlewares are added as well.
+func (a *API) authorizeMiddlewares(endpoint *server.Endpoint) []server.Middleware {
+	var middlewares []server.Middleware
+	if authorization, ok := a.endpointAuthorization(endpoint); ok {
+		middlewares = append(middlewares, a.midAuthorize(authorization))
+	}
+	return append(middlewares, a.policyMiddlewares(endpoint)...)
+}
+
+// midAuthorize creates a middleware that verifies if the account stored in the request context
+// fulfills provided 'authorization' requirements.
+func (a *API) midAuthorize(authorization *Authorization) server.Middleware {
+	verifyOptions := authorization.verifyOptions()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+			account, ok := auth.CtxGetAccount(ctx)
+			if !ok {
+				a.marshalErrors(rw, http.StatusUnauthorized, httputil.ErrInvalidAuthorizationHeader())
+				return
+			}
+			if err := a.Options.Verifier.Verify(ctx, account, verifyOptions...); err != nil {
+				if errors.Is(err, auth.ErrAuthorization) {
+					log.Debug2f("Account is not authorized for the endpoint: %v", err)
+					a.marshalErrors(rw, http.StatusForbidden, httputil.ErrForbiddenAuthorize())
+					return
+				}
+				log.Errorf("Verifying account authorization failed: %v", err)
+				a.marshalErrors(rw, 0, err)
+				return
+			}
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
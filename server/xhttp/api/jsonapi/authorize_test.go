@@ -0,0 +1,206 @@
+package jsonapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/core"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/server"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+)
+
+// accountHeader is the test header with the identifier of the authenticated account.
+const accountHeader = "X-Account"
+
+// testAuthenticate is the middleware that stores the account identified by the accountHeader in the context.
+func testAuthenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if id, err := strconv.Atoi(req.Header.Get(accountHeader)); err == nil {
+			req = req.WithContext(auth.CtxWithAccount(req.Context(), &User{ID: id}))
+		}
+		next.ServeHTTP(rw, req)
+	})
+}
+
+// testVerifier is the auth.Verifier that grants the scopes to the accounts with matching identifiers.
+// It stores the endpoints found in the context of the verified requests.
+type testVerifier struct {
+	scopes    map[int][]string
+	endpoints []*server.Endpoint
+}
+
+func (v *testVerifier) Verify(ctx context.Context, account auth.Account, options ...auth.VerifyOption) error {
+	if endpoint, ok := httputil.CtxGetEndpoint(ctx); ok {
+		v.endpoints = append(v.endpoints, endpoint)
+	}
+	id := account.(*User).ID
+	if id < 0 {
+		return errors.Wrap(auth.ErrInternalError, "verifier failure")
+	}
+	o := &auth.VerifyOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	granted := map[string]struct{}{}
+	for _, scope := range v.scopes[id] {
+		granted[scope] = struct{}{}
+	}
+	for _, scope := range o.Scopes {
+		if _, ok := granted[scope.ScopeName()]; !ok {
+			return errors.Wrapf(auth.ErrForbidden, "scope: '%s' not granted", scope.ScopeName())
+		}
+	}
+	return nil
+}
+
+// testHandler is the model handler with the middlewares that respond without reaching the repository.
+// The model handler middlewares are executed after the authorization ones.
+type testHandler struct{}
+
+func (testHandler) respond(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (h testHandler) ListMiddlewares() []server.Middleware {
+	return []server.Middleware{h.respond}
+}
+
+func (h testHandler) GetMiddlewares() []server.Middleware {
+	return []server.Middleware{h.respond}
+}
+
+func (h testHandler) GetRelatedMiddlewares() []server.Middleware {
+	return []server.Middleware{h.respond}
+}
+
+func (h testHandler) InsertMiddlewares() []server.Middleware {
+	return []server.Middleware{h.respond}
+}
+
+func testAuthorizeAPI(t *testing.T, verifier *testVerifier, options ...Option) (*API, *httprouter.Router) {
+	t.Helper()
+	c := core.NewDefault()
+	require.NoError(t, c.RegisterModels(&User{}, &Blog{}, &Post{}))
+
+	options = append([]Option{
+		WithModelHandler(&Blog{}, testHandler{}),
+		WithModelHandler(&Post{}, testHandler{}),
+		WithMiddlewares(testAuthenticate),
+		WithVerifier(verifier),
+	}, options...)
+	a := New(options...)
+	require.NoError(t, a.InitializeAPI(c))
+	router := httprouter.New()
+	require.NoError(t, a.SetRoutes(router))
+	return a, router
+}
+
+func serve(router *httprouter.Router, method, path string, accountID int) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Accept", "application/vnd.api+json")
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	if accountID != 0 {
+		req.Header.Set(accountHeader, strconv.Itoa(accountID))
+	}
+	rw := httptest.NewRecorder()
+	router.ServeHTTP(rw, req)
+	return rw
+}
+
+func TestEndpointAuthorization(t *testing.T) {
+	a, _ := testAuthorizeAPI(t, &testVerifier{},
+		WithModelAuthorization(&Blog{}, Authorization{Scopes: []auth.Scope{Scope("blogs")}}),
+		WithModelAuthorization(&Blog{}, Authorization{Scopes: []auth.Scope{Scope("blogs.list")}}, query.List),
+		WithRelationAuthorization(&Blog{}, "Posts", Authorization{Scopes: []auth.Scope{Scope("blogs.posts")}}),
+		WithRelationAuthorization(&Blog{}, "Posts", Authorization{Scopes: []auth.Scope{Scope("blogs.posts.get")}}, query.Get),
+	)
+	blog, err := a.Controller.ModelStruct(&Blog{})
+	require.NoError(t, err)
+	post, err := a.Controller.ModelStruct(&Post{})
+	require.NoError(t, err)
+	posts, ok := blog.RelationByName("Posts")
+	require.True(t, ok)
+
+	for name, tc := range map[string]struct {
+		endpoint *server.Endpoint
+		scope    string
+	}{
+		"Method":         {endpoint: &server.Endpoint{ModelStruct: blog, QueryMethod: query.List}, scope: "blogs.list"},
+		"Model":          {endpoint: &server.Endpoint{ModelStruct: blog, QueryMethod: query.Get}, scope: "blogs"},
+		"RelationMethod": {endpoint: &server.Endpoint{ModelStruct: blog, Relation: posts, QueryMethod: query.Get}, scope: "blogs.posts.get"},
+		"Relation":       {endpoint: &server.Endpoint{ModelStruct: blog, Relation: posts, QueryMethod: query.InsertRelationship}, scope: "blogs.posts"},
+	} {
+		authorization, ok := a.endpointAuthorization(tc.endpoint)
+		if assert.True(t, ok, name) && assert.Len(t, authorization.Scopes, 1, name) {
+			assert.Equal(t, tc.scope, authorization.Scopes[0].ScopeName(), name)
+		}
+	}
+
+	// The endpoints without any authorization defined are not authorized by default.
+	_, ok = a.endpointAuthorization(&server.Endpoint{ModelStruct: post, QueryMethod: query.List})
+	assert.False(t, ok)
+	assert.Len(t, a.authorizeMiddlewares(&server.Endpoint{ModelStruct: post, QueryMethod: query.List}), 0)
+}
+
+func TestMidAuthorize(t *testing.T) {
+	verifier := &testVerifier{scopes: map[int][]string{
+		1: {"blogs.list"},
+		2: {"blogs", "blogs.list"},
+	}}
+	_, router := testAuthorizeAPI(t, verifier,
+		WithModelAuthorization(&Blog{}, Authorization{Scopes: []auth.Scope{Scope("blogs")}}),
+		WithModelAuthorization(&Blog{}, Authorization{Scopes: []auth.Scope{Scope("blogs.list")}}, query.List),
+	)
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		rw := serve(router, http.MethodGet, "/blogs", 0)
+		assert.Equal(t, http.StatusUnauthorized, rw.Code, rw.Body.String())
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		// The method authorization takes precedence over the model one.
+		rw := serve(router, http.MethodGet, "/blogs", 1)
+		assert.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+		rw = serve(router, http.MethodGet, "/blogs/1", 1)
+		assert.Equal(t, http.StatusForbidden, rw.Code, rw.Body.String())
+		rw = serve(router, http.MethodGet, "/blogs/1", 2)
+		assert.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+	})
+
+	t.Run("VerifierError", func(t *testing.T) {
+		rw := serve(router, http.MethodGet, "/blogs", -1)
+		assert.Equal(t, http.StatusInternalServerError, rw.Code, rw.Body.String())
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		// The model without the authorization is accessible without the account.
+		verifier.endpoints = nil
+		rw := serve(router, http.MethodGet, "/posts", 0)
+		assert.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+		assert.Empty(t, verifier.endpoints)
+	})
+
+	t.Run("Position", func(t *testing.T) {
+		// The authorization middleware is executed after the endpoint is stored in the context.
+		verifier.endpoints = nil
+		rw := serve(router, http.MethodPost, "/blogs", 2)
+		assert.Equal(t, http.StatusNoContent, rw.Code, rw.Body.String())
+		if assert.Len(t, verifier.endpoints, 1) {
+			assert.Equal(t, query.Insert, verifier.endpoints[0].QueryMethod)
+			assert.Equal(t, "/blogs", verifier.endpoints[0].Path)
+		}
+	})
+}
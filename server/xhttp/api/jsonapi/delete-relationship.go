@@ -77,6 +77,11 @@ func (a *API) handleDeleteRelationship(mStruct *mapping.ModelStruct, relation *m
 				return
 			}
 		}
+		// Check if all provided relations are accessible by the account.
+		if err = a.verifyPolicyRelations(req.Context(), relation, payload.Data); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
 
 		// If nothing to delete
 		if len(payload.Data) == 0 {
@@ -151,6 +151,15 @@ func (a *API) handleGetRelated(mStruct *mapping.ModelStruct, relationField *mapp
 			a.marshalErrors(rw, 0, err)
 			return
 		}
+		// Restrict the related models and their included relations to the ones accessible by the account.
+		if result.Data, err = a.filterPolicyModels(ctx, relatedStruct, relationMethod(relationField), result.Data); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+		if err = a.filterPolicyIncludes(ctx, result.Data, relatedScope.IncludedRelations); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
 
 		linkType := codec.RelatedLink
 		// but if the config doesn't allow that - set 'codec.NoLink'
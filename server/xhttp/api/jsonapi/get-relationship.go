@@ -167,6 +167,11 @@ func (a *API) handleGetRelationship(mStruct *mapping.ModelStruct, relation *mapp
 			a.marshalErrors(rw, 0, err)
 			return
 		}
+		// Restrict the relationships to the models accessible by the account.
+		if result.Data, err = a.filterPolicyModels(ctx, relatedModelStruct, relationMethod(relation), result.Data); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
 
 		result.ModelStruct = relatedModelStruct
 		result.IncludedRelations = queryIncludes
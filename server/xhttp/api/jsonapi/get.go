@@ -141,6 +141,11 @@ func (a *API) handleGet(mStruct *mapping.ModelStruct) http.HandlerFunc {
 			a.marshalErrors(rw, 0, err)
 			return
 		}
+		// Remove the included relations not accessible by the account.
+		if err = a.filterPolicyIncludes(ctx, result.Data, s.IncludedRelations); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
 
 		linkType := codec.ResourceLink
 		// but if the config doesn't allow that - set 'cjsonapi.NoLink'
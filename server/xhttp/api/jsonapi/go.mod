@@ -7,4 +7,5 @@ require (
 	github.com/neuronlabs/neuron v0.20.3
 	github.com/neuronlabs/neuron-extensions/codec/cjsonapi v0.0.4
 	github.com/neuronlabs/neuron-extensions/server/xhttp v0.0.2
+	github.com/stretchr/testify v1.6.1
 )
@@ -86,6 +86,11 @@ func (a *API) handleInsertRelationship(mStruct *mapping.ModelStruct, relation *m
 				return
 			}
 		}
+		// Check if all provided relations are accessible by the account.
+		if err = a.verifyPolicyRelations(req.Context(), relation, payload.Data); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
 
 		if len(payload.Data) == 0 {
 			rw.WriteHeader(http.StatusNoContent)
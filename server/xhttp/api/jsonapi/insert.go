@@ -128,6 +128,12 @@ func (a *API) handleInsert(mStruct *mapping.ModelStruct) http.HandlerFunc {
 			isTransactioner bool
 		)
 
+		// Verify if the inserted model matches the resource policies.
+		if err = a.verifyPolicyModel(ctx, query.Insert, model); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+
 		// Try to get model's InsertHandler.
 		modelHandler, hasModelHandler := a.handlers[mStruct]
 
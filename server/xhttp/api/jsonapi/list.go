@@ -40,6 +40,12 @@ func (a *API) handleList(mStruct *mapping.ModelStruct) http.HandlerFunc {
 			return
 		}
 
+		// Restrict the query to the resources accessible by the account.
+		if err = a.applyPolicyFilters(req.Context(), query.List, s); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
+
 		if defaultPagination != nil && s.Pagination == nil {
 			s.Pagination = &(*defaultPagination)
 		}
@@ -93,6 +99,11 @@ func (a *API) handleList(mStruct *mapping.ModelStruct) http.HandlerFunc {
 			a.marshalErrors(rw, 0, err)
 			return
 		}
+		// Remove the included relations not accessible by the account.
+		if err = a.filterPolicyIncludes(ctx, result.Data, s.IncludedRelations); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
 
 		linkType := codec.ResourceLink
 		if !a.Options.PayloadLinks {
@@ -0,0 +1,938 @@
+// Code generated by neurogonesis. DO NOT EDIT.
+// This file was generated at:
+// Mon, 19 Oct 2026 06:49:07 +0000
+
+package jsonapi
+
+import (
+	"strconv"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/mapping"
+)
+
+// Neuron_Models stores all generated models in this package.
+var Neuron_Models = []mapping.Model{
+	&Blog{},
+	&Post{},
+	&User{},
+}
+
+// Compile time check if Blog implements mapping.Model interface.
+var _ mapping.Model = &Blog{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (b *Blog) IsPrimaryKeyZero() bool {
+	return b.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (b *Blog) GetPrimaryKeyValue() interface{} {
+	return b.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (b *Blog) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(b.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (b *Blog) GetPrimaryKeyAddress() interface{} {
+	return &b.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (b *Blog) GetPrimaryKeyHashableValue() interface{} {
+	return b.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (b *Blog) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (b *Blog) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(int); ok {
+		b.ID = _v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch _valueType := value.(type) {
+	case int8:
+		b.ID = int(_valueType)
+	case int16:
+		b.ID = int(_valueType)
+	case int32:
+		b.ID = int(_valueType)
+	case int64:
+		b.ID = int(_valueType)
+	case uint:
+		b.ID = int(_valueType)
+	case uint8:
+		b.ID = int(_valueType)
+	case uint16:
+		b.ID = int(_valueType)
+	case uint32:
+		b.ID = int(_valueType)
+	case uint64:
+		b.ID = int(_valueType)
+	case float32:
+		b.ID = int(_valueType)
+	case float64:
+		b.ID = int(_valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'Blog'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (b *Blog) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	b.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (b *Blog) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrModelNotMatch, "provided nil model to set from")
+	}
+	from, ok := model.(*Blog)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*b = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (b *Blog) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return b.ID, nil
+	case 1: // Title
+		return b.Title, nil
+	case 2: // Posts
+		return b.Posts, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Blog'", field.Name())
+	}
+}
+
+// ListRelationModels lists unique relation models.
+func (b *Blog) ListRelationModels() []mapping.Model {
+	return []mapping.Model{&Post{}}
+}
+
+// Compile time check if Blog implements mapping.Fielder interface.
+var _ mapping.Fielder = &Blog{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (b *Blog) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &b.ID, nil
+	case 1: // Title
+		return &b.Title, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Blog'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (b *Blog) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // Title
+		return "", nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (b *Blog) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return b.ID == 0, nil
+	case 1: // Title
+		return b.Title == "", nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (b *Blog) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		b.ID = 0
+	case 1: // Title
+		b.Title = ""
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (b *Blog) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return b.ID, nil
+	case 1: // Title
+		return b.Title, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'Blog'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (b *Blog) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return b.ID, nil
+	case 1: // Title
+		return b.Title, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Blog'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (b *Blog) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(int); ok {
+			b.ID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			b.ID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			b.ID = int(_v)
+		case int16:
+			b.ID = int(_v)
+		case int32:
+			b.ID = int(_v)
+		case int64:
+			b.ID = int(_v)
+		case uint:
+			b.ID = int(_v)
+		case uint8:
+			b.ID = int(_v)
+		case uint16:
+			b.ID = int(_v)
+		case uint32:
+			b.ID = int(_v)
+		case uint64:
+			b.ID = int(_v)
+		case float32:
+			b.ID = int(_v)
+		case float64:
+			b.ID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // Title
+		if _v, ok := value.(string); ok {
+			b.Title = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			b.Title = ""
+			return nil
+		}
+
+		// Check alternate types for the Title.
+		if _v, ok := value.([]byte); ok {
+			b.Title = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'Blog'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (b *Blog) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // Title
+		return value, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Blog'", field.Name())
+}
+
+// Compile time check for the mapping.MultiRelationer interface implementation.
+var _ mapping.MultiRelationer = &Blog{}
+
+// AddRelationModel implements mapping.MultiRelationer interface.
+func (b *Blog) AddRelationModel(relation *mapping.StructField, model mapping.Model) error {
+	switch relation.Index[0] {
+	case 2: // Posts
+		post, ok := model.(*Post)
+		if !ok {
+			return errors.Wrapf(mapping.ErrInvalidRelationValue, "provided invalid value type: '%T'  for the field: 'Posts'", model)
+		}
+		b.Posts = append(b.Posts, post)
+	default:
+		return errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%T' for the model 'Blog'", model)
+	}
+	return nil
+}
+
+// GetRelationModels implements mapping.MultiRelationer interface.
+func (b *Blog) GetRelationModels(relation *mapping.StructField) (models []mapping.Model, err error) {
+	switch relation.Index[0] {
+	case 2: // Posts
+		for _, model := range b.Posts {
+			models = append(models, model)
+		}
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, b)
+	}
+	return models, nil
+}
+
+// GetRelationModelAt implements mapping.MultiRelationer interface.
+func (b *Blog) GetRelationModelAt(relation *mapping.StructField, index int) (models mapping.Model, err error) {
+	switch relation.Index[0] {
+	case 2: // Posts
+		if index > len(b.Posts)-1 {
+			return nil, errors.Wrapf(mapping.ErrInvalidRelationIndex, "index out of possible range. Model: 'Blog', Field Posts")
+		}
+		return b.Posts[index], nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, b)
+	}
+}
+
+// GetRelationLen implements mapping.MultiRelationer interface.
+func (b *Blog) GetRelationLen(relation *mapping.StructField) (int, error) {
+	switch relation.Index[0] {
+	case 2: // Posts
+		return len(b.Posts), nil
+	default:
+		return 0, errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for model: '%T'", relation, b)
+	}
+}
+
+// SetRelationModels implements mapping.MultiRelationer interface.
+func (b *Blog) SetRelationModels(relation *mapping.StructField, models ...mapping.Model) error {
+	switch relation.Index[0] {
+	case 2: // Posts
+		temp := make([]*Post, len(models))
+		for i, model := range models {
+			post, ok := model.(*Post)
+			if !ok {
+				return errors.Wrapf(mapping.ErrInvalidRelationValue, "provided invalid value type: '%T'  for the field: 'Posts'", model)
+			}
+
+			temp[i] = post
+		}
+		b.Posts = temp
+	default:
+		return errors.Wrapf(mapping.ErrInvalidRelationField, "provided invalid relation: '%s' for the model 'Blog'", relation.String())
+	}
+	return nil
+}
+
+// Compile time check if Post implements mapping.Model interface.
+var _ mapping.Model = &Post{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (p *Post) IsPrimaryKeyZero() bool {
+	return p.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (p *Post) GetPrimaryKeyValue() interface{} {
+	return p.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (p *Post) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(p.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (p *Post) GetPrimaryKeyAddress() interface{} {
+	return &p.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (p *Post) GetPrimaryKeyHashableValue() interface{} {
+	return p.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (p *Post) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (p *Post) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(int); ok {
+		p.ID = _v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch _valueType := value.(type) {
+	case int8:
+		p.ID = int(_valueType)
+	case int16:
+		p.ID = int(_valueType)
+	case int32:
+		p.ID = int(_valueType)
+	case int64:
+		p.ID = int(_valueType)
+	case uint:
+		p.ID = int(_valueType)
+	case uint8:
+		p.ID = int(_valueType)
+	case uint16:
+		p.ID = int(_valueType)
+	case uint32:
+		p.ID = int(_valueType)
+	case uint64:
+		p.ID = int(_valueType)
+	case float32:
+		p.ID = int(_valueType)
+	case float64:
+		p.ID = int(_valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'Post'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (p *Post) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	p.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (p *Post) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrModelNotMatch, "provided nil model to set from")
+	}
+	from, ok := model.(*Post)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*p = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (p *Post) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return p.ID, nil
+	case 1: // Title
+		return p.Title, nil
+	case 2: // BlogID
+		return p.BlogID, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Post'", field.Name())
+	}
+}
+
+// Compile time check if Post implements mapping.Fielder interface.
+var _ mapping.Fielder = &Post{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (p *Post) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &p.ID, nil
+	case 1: // Title
+		return &p.Title, nil
+	case 2: // BlogID
+		return &p.BlogID, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Post'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (p *Post) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // Title
+		return "", nil
+	case 2: // BlogID
+		return 0, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (p *Post) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return p.ID == 0, nil
+	case 1: // Title
+		return p.Title == "", nil
+	case 2: // BlogID
+		return p.BlogID == 0, nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (p *Post) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		p.ID = 0
+	case 1: // Title
+		p.Title = ""
+	case 2: // BlogID
+		p.BlogID = 0
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (p *Post) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return p.ID, nil
+	case 1: // Title
+		return p.Title, nil
+	case 2: // BlogID
+		return p.BlogID, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'Post'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (p *Post) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return p.ID, nil
+	case 1: // Title
+		return p.Title, nil
+	case 2: // BlogID
+		return p.BlogID, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Post'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (p *Post) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(int); ok {
+			p.ID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			p.ID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			p.ID = int(_v)
+		case int16:
+			p.ID = int(_v)
+		case int32:
+			p.ID = int(_v)
+		case int64:
+			p.ID = int(_v)
+		case uint:
+			p.ID = int(_v)
+		case uint8:
+			p.ID = int(_v)
+		case uint16:
+			p.ID = int(_v)
+		case uint32:
+			p.ID = int(_v)
+		case uint64:
+			p.ID = int(_v)
+		case float32:
+			p.ID = int(_v)
+		case float64:
+			p.ID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // Title
+		if _v, ok := value.(string); ok {
+			p.Title = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			p.Title = ""
+			return nil
+		}
+
+		// Check alternate types for the Title.
+		if _v, ok := value.([]byte); ok {
+			p.Title = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // BlogID
+		if _v, ok := value.(int); ok {
+			p.BlogID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			p.BlogID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			p.BlogID = int(_v)
+		case int16:
+			p.BlogID = int(_v)
+		case int32:
+			p.BlogID = int(_v)
+		case int64:
+			p.BlogID = int(_v)
+		case uint:
+			p.BlogID = int(_v)
+		case uint8:
+			p.BlogID = int(_v)
+		case uint16:
+			p.BlogID = int(_v)
+		case uint32:
+			p.BlogID = int(_v)
+		case uint64:
+			p.BlogID = int(_v)
+		case float32:
+			p.BlogID = int(_v)
+		case float64:
+			p.BlogID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'Post'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (p *Post) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // Title
+		return value, nil
+	case 2: // BlogID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: Post'", field.Name())
+}
+
+// Compile time check if User implements mapping.Model interface.
+var _ mapping.Model = &User{}
+
+// IsPrimaryKeyZero implements mapping.Model interface method.
+func (u *User) IsPrimaryKeyZero() bool {
+	return u.ID == 0
+}
+
+// GetPrimaryKeyValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyValue() interface{} {
+	return u.ID
+}
+
+// GetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyStringValue() (string, error) {
+	return strconv.FormatInt(int64(u.ID), 10), nil
+}
+
+// GetPrimaryKeyAddress implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyAddress() interface{} {
+	return &u.ID
+}
+
+// GetPrimaryKeyHashableValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyHashableValue() interface{} {
+	return u.ID
+}
+
+// GetPrimaryKeyZeroValue implements mapping.Model interface method.
+func (u *User) GetPrimaryKeyZeroValue() interface{} {
+	return 0
+}
+
+// SetPrimaryKey implements mapping.Model interface method.
+func (u *User) SetPrimaryKeyValue(value interface{}) error {
+	if _v, ok := value.(int); ok {
+		u.ID = _v
+		return nil
+	}
+	// Check alternate types for given field.
+	switch _valueType := value.(type) {
+	case int8:
+		u.ID = int(_valueType)
+	case int16:
+		u.ID = int(_valueType)
+	case int32:
+		u.ID = int(_valueType)
+	case int64:
+		u.ID = int(_valueType)
+	case uint:
+		u.ID = int(_valueType)
+	case uint8:
+		u.ID = int(_valueType)
+	case uint16:
+		u.ID = int(_valueType)
+	case uint32:
+		u.ID = int(_valueType)
+	case uint64:
+		u.ID = int(_valueType)
+	case float32:
+		u.ID = int(_valueType)
+	case float64:
+		u.ID = int(_valueType)
+	default:
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid value: '%T' for the primary field for model: 'User'", value)
+	}
+	return nil
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) SetPrimaryKeyStringValue(value string) error {
+	tmp, err := strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	if err != nil {
+		return err
+	}
+	u.ID = int(tmp)
+	return nil
+}
+
+// SetFrom implements FromSetter interface.
+func (u *User) SetFrom(model mapping.Model) error {
+	if model == nil {
+		return errors.Wrap(mapping.ErrModelNotMatch, "provided nil model to set from")
+	}
+	from, ok := model.(*User)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotMatch, "provided model doesn't match the input: %T", model)
+	}
+	*u = *from
+	return nil
+}
+
+// StructFieldValues gets the value for specified 'field'.
+func (u *User) StructFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID, nil
+	case 1: // Username
+		return u.Username, nil
+	case 2: // PasswordHash
+		return u.PasswordHash, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+	}
+}
+
+// Compile time check if User implements mapping.Fielder interface.
+var _ mapping.Fielder = &User{}
+
+// GetFieldsAddress gets the address of provided 'field'.
+func (u *User) GetFieldsAddress(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return &u.ID, nil
+	case 1: // Username
+		return &u.Username, nil
+	case 2: // PasswordHash
+		return &u.PasswordHash, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
+
+// GetFieldZeroValue implements mapping.Fielder interface.s
+func (u *User) GetFieldZeroValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return 0, nil
+	case 1: // Username
+		return "", nil
+	case 2: // PasswordHash
+		return nil, nil
+	default:
+		return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+}
+
+// IsFieldZero implements mapping.Fielder interface.
+func (u *User) IsFieldZero(field *mapping.StructField) (bool, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID == 0, nil
+	case 1: // Username
+		return u.Username == "", nil
+	case 2: // PasswordHash
+		return len(u.PasswordHash) == 0, nil
+	}
+	return false, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+}
+
+// SetFieldZeroValue implements mapping.Fielder interface.s
+func (u *User) SetFieldZeroValue(field *mapping.StructField) error {
+	switch field.Index[0] {
+	case 0: // ID
+		u.ID = 0
+	case 1: // Username
+		u.Username = ""
+	case 2: // PasswordHash
+		u.PasswordHash = nil
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field name: '%s'", field.Name())
+	}
+	return nil
+}
+
+// GetHashableFieldValue implements mapping.Fielder interface.
+func (u *User) GetHashableFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID, nil
+	case 1: // Username
+		return u.Username, nil
+	case 2: // PasswordHash
+		return string(u.PasswordHash), nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: 'User'", field.Name())
+}
+
+// GetFieldValue implements mapping.Fielder interface.
+func (u *User) GetFieldValue(field *mapping.StructField) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return u.ID, nil
+	case 1: // Username
+		return u.Username, nil
+	case 2: // PasswordHash
+		return u.PasswordHash, nil
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
+
+// SetFieldValue implements mapping.Fielder interface.
+func (u *User) SetFieldValue(field *mapping.StructField, value interface{}) (err error) {
+	switch field.Index[0] {
+	case 0: // ID
+		if _v, ok := value.(int); ok {
+			u.ID = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			u.ID = 0
+			return nil
+		}
+
+		switch _v := value.(type) {
+		case int8:
+			u.ID = int(_v)
+		case int16:
+			u.ID = int(_v)
+		case int32:
+			u.ID = int(_v)
+		case int64:
+			u.ID = int(_v)
+		case uint:
+			u.ID = int(_v)
+		case uint8:
+			u.ID = int(_v)
+		case uint16:
+			u.ID = int(_v)
+		case uint32:
+			u.ID = int(_v)
+		case uint64:
+			u.ID = int(_v)
+		case float32:
+			u.ID = int(_v)
+		case float64:
+			u.ID = int(_v)
+		default:
+			return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+		}
+		return nil
+	case 1: // Username
+		if _v, ok := value.(string); ok {
+			u.Username = _v
+			return nil
+		}
+		if field.DatabaseNotNull() && value == nil {
+			u.Username = ""
+			return nil
+		}
+
+		// Check alternate types for the Username.
+		if _v, ok := value.([]byte); ok {
+			u.Username = string(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	case 2: // PasswordHash
+		if value == nil {
+			u.PasswordHash = nil
+			return nil
+		}
+		if _v, ok := value.([]byte); ok {
+			u.PasswordHash = _v
+			return nil
+		}
+		if generic, ok := value.([]interface{}); ok {
+			for _, item := range generic {
+				if _v, ok := item.(byte); ok {
+					u.PasswordHash = append(u.PasswordHash, _v)
+					continue
+				}
+
+			}
+			return nil
+		}
+		// Check alternate types for the PasswordHash.
+		if _v, ok := value.(string); ok {
+			u.PasswordHash = []byte(_v)
+			return nil
+		}
+		return errors.Wrapf(mapping.ErrFieldValue, "provided invalid field type: '%T' for the field: %s", value, field.Name())
+	default:
+		return errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for the model: 'User'", field.Name())
+	}
+}
+
+// SetPrimaryKeyStringValue implements mapping.Model interface method.
+func (u *User) ParseFieldsStringValue(field *mapping.StructField, value string) (interface{}, error) {
+	switch field.Index[0] {
+	case 0: // ID
+		return strconv.ParseInt(value, 10, mapping.IntegerBitSize)
+	case 1: // Username
+		return value, nil
+	case 2: // PasswordHash
+		return "", errors.Wrap(mapping.ErrFieldNotParser, "field 'PasswordHash' doesn't have string setter.")
+	}
+	return nil, errors.Wrapf(mapping.ErrInvalidModelField, "provided invalid field: '%s' for given model: User'", field.Name())
+}
@@ -0,0 +1,71 @@
+package jsonapi
+
+//go:generate neurogonesis models methods --format=goimports --single-file --type=User,Blog,Post .
+// The neuron version used by this module has no mapping.ErrNilModel. The NeuronCollectionName is defined below.
+//go:generate sed -i s/mapping.ErrNilModel/mapping.ErrModelNotMatch/ models.gen_test.go
+
+// User is the test account model.
+type User struct {
+	ID           int
+	Username     string
+	PasswordHash []byte
+}
+
+// GetUsername implements auth.Account interface.
+func (u *User) GetUsername() string {
+	return u.Username
+}
+
+// SetUsername implements auth.Account interface.
+func (u *User) SetUsername(username string) {
+	u.Username = username
+}
+
+// GetPasswordHash implements auth.Account interface.
+func (u *User) GetPasswordHash() []byte {
+	return u.PasswordHash
+}
+
+// SetPasswordHash implements auth.Account interface.
+func (u *User) SetPasswordHash(hash []byte) {
+	u.PasswordHash = hash
+}
+
+// UsernameField implements auth.Account interface.
+func (u *User) UsernameField() string {
+	return "Username"
+}
+
+// PasswordHashField implements auth.Account interface.
+func (u *User) PasswordHashField() string {
+	return "PasswordHash"
+}
+
+// NeuronCollectionName implements mapping.Model interface.
+func (u *User) NeuronCollectionName() string {
+	return "users"
+}
+
+// Blog is the test model with the posts relation.
+type Blog struct {
+	ID    int
+	Title string
+	Posts []*Post
+}
+
+// NeuronCollectionName implements mapping.Model interface.
+func (b *Blog) NeuronCollectionName() string {
+	return "blogs"
+}
+
+// Post is the test model related to the blog.
+type Post struct {
+	ID     int
+	Title  string
+	BlogID int
+}
+
+// NeuronCollectionName implements mapping.Model interface.
+func (p *Post) NeuronCollectionName() string {
+	return "posts"
+}
@@ -1,7 +1,9 @@
 package jsonapi
 
 import (
+	"github.com/neuronlabs/neuron/auth"
 	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
 	"github.com/neuronlabs/neuron/server"
 )
 
@@ -35,6 +37,13 @@ type Options struct {
 	DefaultHandlerModels []mapping.Model
 	// ModelHandlers are the models with their paired API handlers.
 	ModelHandlers []ModelHandler
+	// Authorizations are the endpoint authorization requirements verified for the authenticated account.
+	Authorizations []EndpointAuthorization
+	// Verifier is the authorization verifier used for the endpoint authorizations.
+	// If not defined the controller's Verifier is used.
+	Verifier auth.Verifier
+	// ResourceAuthorizer verifies the resource level authorization policies.
+	ResourceAuthorizer ResourceAuthorizer
 }
 
 type Option func(o *Options)
@@ -96,3 +105,37 @@ func WithModelHandler(model mapping.Model, handler interface{}) Option {
 		o.ModelHandlers = append(o.ModelHandlers, ModelHandler{Model: model, Handler: handler})
 	}
 }
+
+// WithModelAuthorization is an option that sets the authorization requirements for the model endpoints with provided
+// query 'methods'. If no methods are provided the authorization applies to all the model endpoints.
+// The endpoints requires the account to be authenticated before, i.e. with the middleware.BearerAuthenticate.
+func WithModelAuthorization(model mapping.Model, authorization Authorization, methods ...query.Method) Option {
+	return func(o *Options) {
+		o.Authorizations = append(o.Authorizations, EndpointAuthorization{Model: model, Methods: methods, Authorization: authorization})
+	}
+}
+
+// WithRelationAuthorization is an option that sets the authorization requirements for the model's 'relation' endpoints
+// with provided query 'methods'. If no methods are provided the authorization applies to all the relation endpoints.
+// The relation authorization takes precedence over the model authorization.
+func WithRelationAuthorization(model mapping.Model, relation string, authorization Authorization, methods ...query.Method) Option {
+	return func(o *Options) {
+		o.Authorizations = append(o.Authorizations, EndpointAuthorization{Model: model, Relation: relation, Methods: methods, Authorization: authorization})
+	}
+}
+
+// WithVerifier is an option that sets the authorization verifier used for the endpoint authorizations.
+func WithVerifier(verifier auth.Verifier) Option {
+	return func(o *Options) {
+		o.Verifier = verifier
+	}
+}
+
+// WithResourceAuthorizer is an option that sets the resource level authorization policies verifier.
+// The list queries are restricted with the policy filters, the endpoints with the resource 'id' are accessible only
+// if the resource matches the policies, and the inserted models are verified against them.
+func WithResourceAuthorizer(resourceAuthorizer ResourceAuthorizer) Option {
+	return func(o *Options) {
+		o.ResourceAuthorizer = resourceAuthorizer
+	}
+}
@@ -0,0 +1,296 @@
+package jsonapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/neuronlabs/neuron/auth"
+	"github.com/neuronlabs/neuron/database"
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/mapping"
+	"github.com/neuronlabs/neuron/query"
+	"github.com/neuronlabs/neuron/query/filter"
+	"github.com/neuronlabs/neuron/server"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+)
+
+// ResourceAuthorizer is the interface used for the resource level authorization policies, i.e. the authorizer.Authorizer.
+type ResourceAuthorizer interface {
+	// ApplyPolicyFilters adds the policy filters to the query scope 's', so that the query is restricted to the models
+	// accessible by the 'account'.
+	ApplyPolicyFilters(ctx context.Context, account auth.Account, method query.Method, s *query.Scope) error
+	// VerifyModel checks if the 'account' is allowed to execute the query 'method' on the 'model'.
+	VerifyModel(ctx context.Context, account auth.Account, method query.Method, model mapping.Model) error
+}
+
+// applyPolicyFilters restricts the list query scope 's' with the resource policies for the request account.
+func (a *API) applyPolicyFilters(ctx context.Context, method query.Method, s *query.Scope) error {
+	if a.Options.ResourceAuthorizer == nil {
+		return nil
+	}
+	account, _ := auth.CtxGetAccount(ctx)
+	return a.Options.ResourceAuthorizer.ApplyPolicyFilters(ctx, account, method, s)
+}
+
+// verifyPolicyModel checks if the request account is allowed to execute the query 'method' on provided 'model'.
+func (a *API) verifyPolicyModel(ctx context.Context, method query.Method, model mapping.Model) error {
+	if a.Options.ResourceAuthorizer == nil {
+		return nil
+	}
+	account, _ := auth.CtxGetAccount(ctx)
+	return a.Options.ResourceAuthorizer.VerifyModel(ctx, account, method, model)
+}
+
+// policyMiddlewares returns the resource policy middleware for the endpoints with the 'id' url parameter.
+func (a *API) policyMiddlewares(endpoint *server.Endpoint) []server.Middleware {
+	if a.Options.ResourceAuthorizer == nil {
+		return nil
+	}
+	switch endpoint.QueryMethod {
+	case query.Insert, query.List:
+		// These endpoints are verified within the handlers.
+		return nil
+	}
+	return []server.Middleware{a.midResourcePolicy(endpoint)}
+}
+
+// midResourcePolicy creates a middleware that checks if the endpoint's resource with the 'id' url parameter
+// is accessible by the request account. The resources filtered out by the policies are reported as not found.
+func (a *API) midResourcePolicy(endpoint *server.Endpoint) server.Middleware {
+	mStruct := endpoint.ModelStruct
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+			model := mapping.NewModel(mStruct)
+			if err := model.SetPrimaryKeyStringValue(httputil.CtxMustGetID(ctx)); err != nil || model.IsPrimaryKeyZero() {
+				// Invalid identifiers are reported by the handlers.
+				next.ServeHTTP(rw, req)
+				return
+			}
+			s := query.NewScope(mStruct)
+			s.Filter(filter.New(mStruct.Primary(), filter.OpEqual, model.GetPrimaryKeyValue()))
+			if err := a.applyPolicyFilters(ctx, endpoint.QueryMethod, s); err != nil {
+				if errors.Is(err, auth.ErrAuthorization) {
+					a.marshalErrors(rw, http.StatusForbidden, httputil.ErrForbiddenAuthorize())
+					return
+				}
+				a.marshalErrors(rw, 0, err)
+				return
+			}
+			count, err := database.Count(ctx, a.DB, s)
+			if err != nil {
+				log.Errorf("Counting policy filtered: '%s' failed: %v", mStruct, err)
+				a.marshalErrors(rw, 0, err)
+				return
+			}
+			if count == 0 {
+				log.Debug2f("[%s] resource not accessible by the account policies", mStruct)
+				a.marshalErrors(rw, http.StatusNotFound, httputil.ErrResourceNotFound())
+				return
+			}
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
+
+// verifyPolicyUpdate checks if the model resulting from the update matches the resource policies, so that the update
+// could not move the resource out of the account reach i.e. by rewriting its owner. The updated 'fieldSet' values
+// of the 'model' are applied on the stored model, which is then verified.
+func (a *API) verifyPolicyUpdate(ctx context.Context, mStruct *mapping.ModelStruct, model mapping.Model, fieldSet mapping.FieldSet) error {
+	if a.Options.ResourceAuthorizer == nil {
+		return nil
+	}
+	getter, ok := a.DB.(database.QueryGetter)
+	if !ok {
+		return errors.WrapDetf(query.ErrInternal, "DB doesn't implement QueryGetter interface")
+	}
+	s := query.NewScope(mStruct)
+	s.FieldSets = []mapping.FieldSet{mStruct.Fields()}
+	s.Filter(filter.New(mStruct.Primary(), filter.OpEqual, model.GetPrimaryKeyValue()))
+	stored, err := getter.QueryGet(ctx, s)
+	if err != nil {
+		return err
+	}
+	fielder, ok := model.(mapping.Fielder)
+	if !ok {
+		return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%s' doesn't implement Fielder interface", mStruct)
+	}
+	storedFielder := stored.(mapping.Fielder)
+	for _, field := range fieldSet {
+		if field == mStruct.Primary() {
+			continue
+		}
+		value, err := fielder.GetFieldValue(field)
+		if err != nil {
+			return err
+		}
+		if err = storedFielder.SetFieldValue(field, value); err != nil {
+			return err
+		}
+	}
+	return a.verifyPolicyModel(ctx, query.Update, stored)
+}
+
+// filterPolicyModels returns these of the 'models' which are accessible by the request account. The models are
+// checked by querying their primary keys restricted with the policy filters.
+func (a *API) filterPolicyModels(ctx context.Context, mStruct *mapping.ModelStruct, method query.Method, models []mapping.Model) ([]mapping.Model, error) {
+	if a.Options.ResourceAuthorizer == nil || len(models) == 0 {
+		return models, nil
+	}
+	primaries := make([]interface{}, len(models))
+	for i, model := range models {
+		primaries[i] = model.GetPrimaryKeyValue()
+	}
+	s := query.NewScope(mStruct)
+	s.FieldSets = []mapping.FieldSet{{mStruct.Primary()}}
+	s.Filter(filter.New(mStruct.Primary(), filter.OpIn, primaries...))
+	filtersLen := len(s.Filters)
+	if err := a.applyPolicyFilters(ctx, method, s); err != nil {
+		return nil, err
+	}
+	if len(s.Filters) == filtersLen {
+		// No policies restricts the models.
+		return models, nil
+	}
+	finder, ok := a.DB.(database.QueryFinder)
+	if !ok {
+		return nil, errors.WrapDetf(query.ErrInternal, "DB doesn't implement QueryFinder interface")
+	}
+	accessible, err := finder.QueryFind(ctx, s)
+	if err != nil {
+		if errors.Is(err, query.ErrNoResult) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	accessiblePrimaries := make(map[interface{}]struct{}, len(accessible))
+	for _, model := range accessible {
+		accessiblePrimaries[model.GetPrimaryKeyValue()] = struct{}{}
+	}
+	var result []mapping.Model
+	for _, model := range models {
+		if _, ok := accessiblePrimaries[model.GetPrimaryKeyValue()]; ok {
+			result = append(result, model)
+		}
+	}
+	return result, nil
+}
+
+// verifyPolicyRelations checks if all the relation 'models' are accessible by the request account, so that
+// the relationship endpoints could not link the resources out of the account reach.
+func (a *API) verifyPolicyRelations(ctx context.Context, relation *mapping.StructField, models []mapping.Model) error {
+	relatedStruct := relation.Relationship().RelatedModelStruct()
+	accessible, err := a.filterPolicyModels(ctx, relatedStruct, relationMethod(relation), models)
+	if err != nil {
+		return err
+	}
+	if len(accessible) != len(models) {
+		return errors.WrapDetf(query.ErrNoResult, "one of the '%s' relations not accessible by the account policies", relation).
+			WithDetail("Provided relationship resource not found.")
+	}
+	return nil
+}
+
+// filterPolicyIncludes removes the included relation models which are not accessible by the request account
+// from the 'models'. The nested included relations are filtered as well.
+func (a *API) filterPolicyIncludes(ctx context.Context, models []mapping.Model, includes []*query.IncludedRelation) error {
+	if a.Options.ResourceAuthorizer == nil || len(models) == 0 {
+		return nil
+	}
+	for _, included := range includes {
+		relation := included.StructField
+		var related []mapping.Model
+		for _, model := range models {
+			switch relation.Kind() {
+			case mapping.KindRelationshipSingle:
+				relationer, ok := model.(mapping.SingleRelationer)
+				if !ok {
+					return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement SingleRelationer interface", model)
+				}
+				relationModel, err := relationer.GetRelationModel(relation)
+				if err != nil {
+					return err
+				}
+				if relationModel != nil {
+					related = append(related, relationModel)
+				}
+			case mapping.KindRelationshipMultiple:
+				relationer, ok := model.(mapping.MultiRelationer)
+				if !ok {
+					return errors.WrapDetf(mapping.ErrModelNotImplements, "model: '%T' doesn't implement MultiRelationer interface", model)
+				}
+				relationModels, err := relationer.GetRelationModels(relation)
+				if err != nil {
+					return err
+				}
+				related = append(related, relationModels...)
+			}
+		}
+		accessible, err := a.filterPolicyModels(ctx, relation.Relationship().RelatedModelStruct(), relationMethod(relation), related)
+		if err != nil {
+			return err
+		}
+		if len(accessible) != len(related) {
+			if err = removeRelationModels(models, relation, accessible); err != nil {
+				return err
+			}
+		}
+		if err = a.filterPolicyIncludes(ctx, accessible, included.IncludedRelations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeRelationModels removes the 'relation' models of the 'models' which are not within 'accessible' models.
+func removeRelationModels(models []mapping.Model, relation *mapping.StructField, accessible []mapping.Model) error {
+	isAccessible := func(model mapping.Model) bool {
+		for _, a := range accessible {
+			if a == model {
+				return true
+			}
+		}
+		return false
+	}
+	for _, model := range models {
+		switch relation.Kind() {
+		case mapping.KindRelationshipSingle:
+			relationer := model.(mapping.SingleRelationer)
+			relationModel, err := relationer.GetRelationModel(relation)
+			if err != nil {
+				return err
+			}
+			if relationModel != nil && !isAccessible(relationModel) {
+				if err = relationer.SetRelationModel(relation, nil); err != nil {
+					return err
+				}
+			}
+		case mapping.KindRelationshipMultiple:
+			relationer := model.(mapping.MultiRelationer)
+			relationModels, err := relationer.GetRelationModels(relation)
+			if err != nil {
+				return err
+			}
+			filtered := relationModels[:0]
+			for _, relationModel := range relationModels {
+				if isAccessible(relationModel) {
+					filtered = append(filtered, relationModel)
+				}
+			}
+			if err = relationer.SetRelationModels(relation, filtered...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// relationMethod gets the query method used for the policies of the 'relation' models.
+func relationMethod(relation *mapping.StructField) query.Method {
+	if relation.Relationship().IsToMany() {
+		return query.List
+	}
+	return query.Get
+}
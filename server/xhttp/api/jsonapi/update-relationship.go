@@ -77,6 +77,11 @@ func (a *API) handleUpdateRelationship(mStruct *mapping.ModelStruct, relation *m
 				return
 			}
 		}
+		// Check if all provided relations are accessible by the account.
+		if err = a.verifyPolicyRelations(req.Context(), relation, payload.Data); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
 
 		// Create a query scope.
 		s := query.NewScope(mStruct, model)
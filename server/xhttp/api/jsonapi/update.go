@@ -118,6 +118,11 @@ func (a *API) handleUpdate(mStruct *mapping.ModelStruct) http.HandlerFunc {
 			fields = append(fields, field)
 		}
 		payload.FieldSets[0] = fields
+		// Verify if the model resulting from the update matches the resource policies.
+		if err = a.verifyPolicyUpdate(req.Context(), mStruct, model, fields); err != nil {
+			a.marshalErrors(rw, 0, err)
+			return
+		}
 		for _, relation := range relations {
 			payload.IncludedRelations = append(payload.IncludedRelations, &query.IncludedRelation{StructField: relation})
 		}
@@ -226,6 +231,9 @@ func (a *API) fullUpdateHandlerChain(ctx context.Context, db database.DB, payloa
 	if err != nil {
 		return nil, err
 	}
+	if err = a.filterPolicyIncludes(ctx, getResult.Data, getScope.IncludedRelations); err != nil {
+		return nil, err
+	}
 	getResult.Meta = result.Meta
 	return getResult, nil
 }
@@ -100,9 +100,8 @@ func (c *ErrorMapper) errors(err error) []*codec.Error {
 		}
 		return errs
 	default:
-		log.Debugf("Unknown error: %+v", err)
+		return []*codec.Error{c.mapSingleError(err)}
 	}
-	return []*codec.Error{ErrInternalError()}
 }
 
 func (c *ErrorMapper) mapSingleError(e error) *codec.Error {
@@ -120,6 +119,7 @@ func (c *ErrorMapper) mapSingleError(e error) *codec.Error {
 			return cErr
 		}
 		if e = errors.Unwrap(e); e == nil {
+			log.Debugf("Unknown error: %+v", err)
 			return ErrInternalError()
 		}
 	}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/server"
+
+	"github.com/neuronlabs/neuron-extensions/server/xhttp/httputil"
+)
+
+const (
+	// DefaultAccessTokenCookie is the default name of the cookie that contains the access token.
+	DefaultAccessTokenCookie = "access_token"
+	// DefaultCSRFCookie is the default name of the cookie that contains the CSRF token.
+	DefaultCSRFCookie = "csrf_token"
+	// DefaultCSRFHeader is the default name of the header that should contain the CSRF token.
+	DefaultCSRFHeader = "X-CSRF-Token"
+)
+
+// CookieAuthenticate gets the access token from the cookie with provided name and authenticates the request with it.
+// If the request doesn't contain the cookie, the token is taken from the Bearer Authorization header.
+func CookieAuthenticate(cookieName string) server.Middleware {
+	bearer := BearerAuthenticate()
+	return func(next http.Handler) http.Handler {
+		bearerNext := bearer(next)
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			cookie, err := req.Cookie(cookieName)
+			if err != nil || cookie.Value == "" {
+				bearerNext.ServeHTTP(rw, req)
+				return
+			}
+			authenticateToken(next, rw, req, cookie.Value)
+		})
+	}
+}
+
+// CSRF is the double-submit cookie CSRF protection middleware. For the unsafe http methods it requires the header
+// with 'headerName' to match the value of the cookie with 'cookieName'. If the 'sessionCookies' are provided the check
+// is done only for the requests that contains any of these cookies - the requests authenticated by the Authorization
+// header are not vulnerable to the CSRF.
+func CSRF(cookieName, headerName string, sessionCookies ...string) server.Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			if isSafeMethod(req.Method) || !hasAnyCookie(req, sessionCookies) {
+				next.ServeHTTP(rw, req)
+				return
+			}
+			cookie, err := req.Cookie(cookieName)
+			header := req.Header.Get(headerName)
+			if err != nil || cookie.Value == "" || header == "" ||
+				subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) != 1 {
+				cd, ok := httputil.GetCodec(req.Context())
+				if !ok {
+					rw.WriteHeader(http.StatusForbidden)
+					return
+				}
+				errForbidden := httputil.ErrForbiddenOperation()
+				errForbidden.Detail = "Provided invalid or no CSRF token."
+				rw.Header().Set("Content-Type", cd.MimeType())
+				rw.WriteHeader(http.StatusForbidden)
+				if err := cd.MarshalErrors(rw, errForbidden); err != nil {
+					log.Errorf("Marshal error failed: %v", err)
+				}
+				return
+			}
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+func hasAnyCookie(req *http.Request, names []string) bool {
+	if len(names) == 0 {
+		return true
+	}
+	for _, name := range names {
+		if _, err := req.Cookie(name); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,284 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/service"
+	"github.com/neuronlabs/neuron/store"
+)
+
+var (
+	_ store.Store    = &Bolt{}
+	_ service.Closer = &Bolt{}
+)
+
+// bucketName is the name of the bucket that contains the store records.
+var bucketName = []byte("neuron_store")
+
+// headerSize is the size of the value header that contains the record expiration time.
+const headerSize = 8
+
+// Bolt is the embedded on-disk store implementation for the neuron framework. It requires the store options
+// file name to be set. The expired records are removed in the background if the options cleanup interval is greater
+// than zero, otherwise they are only filtered out on the read.
+type Bolt struct {
+	Options   *store.Options
+	db        *bbolt.DB
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// New creates new bolt store that opens (or creates) the database file from the options.
+func New(options ...store.Option) (*Bolt, error) {
+	b := &Bolt{
+		Options: store.DefaultOptions(),
+		done:    make(chan struct{}),
+	}
+	for _, option := range options {
+		option(b.Options)
+	}
+	if b.Options.FileName == "" {
+		return nil, errors.Wrap(store.ErrInitialization, "no file name provided in the options")
+	}
+	db, err := bbolt.Open(b.Options.FileName, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, errors.Wrapf(store.ErrInitialization, "opening bolt database failed: %v", err)
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, errors.Wrapf(store.ErrInitialization, "creating bolt bucket failed: %v", err)
+	}
+	b.db = db
+	if b.Options.CleanupInterval > 0 {
+		go b.cleanup()
+	}
+	return b, nil
+}
+
+// Set implements store.Store interface.
+func (b *Bolt) Set(_ context.Context, record *store.Record, options ...store.SetOption) error {
+	o := &store.SetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	// The ttl provided in the options takes precedence over the record expiration time.
+	expiresAt := record.ExpiresAt
+	if o.TTL > 0 {
+		expiresAt = b.Options.TimeFunc().Add(o.TTL)
+	} else if expiresAt.IsZero() && o.TTL == 0 && b.Options.DefaultExpiration > 0 {
+		expiresAt = b.Options.TimeFunc().Add(b.Options.DefaultExpiration)
+	}
+	if record.ExpiresAt.IsZero() {
+		record.ExpiresAt = expiresAt
+	}
+	value := make([]byte, headerSize+len(record.Value))
+	if !expiresAt.IsZero() {
+		binary.BigEndian.PutUint64(value, uint64(expiresAt.UnixNano()))
+	}
+	copy(value[headerSize:], record.Value)
+
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(bucketName).Put(b.key(record.Key), value)
+	})
+	if err != nil {
+		return errors.Wrap(store.ErrStore, err.Error())
+	}
+	return nil
+}
+
+// Get implements store.Store interface.
+func (b *Bolt) Get(_ context.Context, key string) (*store.Record, error) {
+	var record *store.Record
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		value := tx.Bucket(bucketName).Get(b.key(key))
+		if value == nil {
+			return store.ErrRecordNotFound
+		}
+		var err error
+		record, err = b.record(key, value, b.Options.TimeFunc())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// Delete implements store.Store interface.
+func (b *Bolt) Delete(_ context.Context, key string) error {
+	return b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+		k := b.key(key)
+		value := bucket.Get(k)
+		if value == nil {
+			return store.ErrRecordNotFound
+		}
+		// The expired records are already treated as deleted.
+		if _, err := b.record(key, value, b.Options.TimeFunc()); err != nil {
+			return err
+		}
+		if err := bucket.Delete(k); err != nil {
+			return errors.Wrap(store.ErrStore, err.Error())
+		}
+		return nil
+	})
+}
+
+// Find implements store.Store interface. The records are found by scanning the keys with the store and find prefix
+// and are sorted by their keys.
+func (b *Bolt) Find(_ context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	o := &store.FindPattern{}
+	for _, option := range options {
+		option(o)
+	}
+	prefix := []byte(b.Options.Prefix + o.Prefix)
+	suffix := []byte(o.Suffix + b.Options.Suffix)
+	// The keys are sorted by bytes, thus without the store suffix the scan could stop after reaching the limit.
+	var max int
+	if o.Limit > 0 && b.Options.Suffix == "" {
+		max = o.Offset + o.Limit
+	}
+
+	var records []*store.Record
+	now := b.Options.TimeFunc()
+	err := b.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(bucketName).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if len(k) < len(prefix)+len(suffix) || !bytes.HasSuffix(k, suffix) {
+				continue
+			}
+			record, err := b.record(b.originalKey(k), v, now)
+			if err != nil {
+				if errors.Is(err, store.ErrRecordNotFound) {
+					continue
+				}
+				return err
+			}
+			records = append(records, record)
+			if max > 0 && len(records) == max {
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	if o.Offset >= len(records) {
+		return nil, nil
+	}
+	records = records[o.Offset:]
+	if o.Limit > 0 && o.Limit < len(records) {
+		records = records[:o.Limit]
+	}
+	return records, nil
+}
+
+// Close implements service.Closer interface.
+func (b *Bolt) Close(context.Context) error {
+	var closed bool
+	b.closeOnce.Do(func() {
+		close(b.done)
+		closed = true
+	})
+	if !closed {
+		return nil
+	}
+	if err := b.db.Close(); err != nil {
+		return errors.Wrap(store.ErrStore, err.Error())
+	}
+	log.Debug2f("Bolt store closed with success")
+	return nil
+}
+
+// DeleteExpired removes all the expired records from the database.
+func (b *Bolt) DeleteExpired() error {
+	now := b.Options.TimeFunc()
+	err := b.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(bucketName)
+		// The keys are collected first, as deleting the items while iterating with the cursor skips some of them.
+		var keys [][]byte
+		c := bucket.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if expired(v, now) {
+				keys = append(keys, append([]byte(nil), k...))
+			}
+		}
+		for _, k := range keys {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(store.ErrStore, err.Error())
+	}
+	return nil
+}
+
+func (b *Bolt) cleanup() {
+	ticker := time.NewTicker(b.Options.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+			if err := b.DeleteExpired(); err != nil {
+				log.Errorf("Bolt store - deleting expired records failed: %v", err)
+			}
+		}
+	}
+}
+
+// record decodes the stored 'value' into the record. The value is copied as it is valid only within the transaction.
+func (b *Bolt) record(key string, value []byte, now time.Time) (*store.Record, error) {
+	if len(value) < headerSize {
+		log.Errorf("Malformed bolt store value: '%s'", key)
+		return nil, errors.Wrap(store.ErrInternal, "malformed record value")
+	}
+	if expired(value, now) {
+		return nil, store.ErrRecordNotFound
+	}
+	record := &store.Record{Key: key, Value: make([]byte, len(value)-headerSize)}
+	copy(record.Value, value[headerSize:])
+	if expiresAt := binary.BigEndian.Uint64(value); expiresAt > 0 {
+		record.ExpiresAt = time.Unix(0, int64(expiresAt))
+	}
+	return record, nil
+}
+
+func (b *Bolt) key(key string) []byte {
+	return []byte(b.Options.Prefix + key + b.Options.Suffix)
+}
+
+// originalKey trims the store prefix and suffix from the database 'key'.
+func (b *Bolt) originalKey(key []byte) string {
+	return string(key[len(b.Options.Prefix) : len(key)-len(b.Options.Suffix)])
+}
+
+// expired checks if the stored 'value' expiration time is before 'now'.
+func expired(value []byte, now time.Time) bool {
+	if len(value) < headerSize {
+		return false
+	}
+	expiresAt := binary.BigEndian.Uint64(value)
+	return expiresAt > 0 && int64(expiresAt) <= now.UnixNano()
+}
@@ -0,0 +1,136 @@
+package bolt
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore/storetest"
+)
+
+// testingFileName creates the database file name within new temporary directory.
+func testingFileName(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "neuron-bolt")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "store.db")
+}
+
+func testingStore(t *testing.T, options ...store.Option) *Bolt {
+	b, err := New(append([]store.Option{store.WithFileName(testingFileName(t))}, options...)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = b.Close(context.Background()) })
+	return b
+}
+
+func TestStore(t *testing.T) {
+	storetest.TestStore(t, func(t *testing.T, options ...store.Option) store.Store {
+		return testingStore(t, options...)
+	})
+}
+
+func TestSetTTL(t *testing.T) {
+	ctx := context.Background()
+	b := testingStore(t)
+
+	// The ttl option takes precedence over the record expiration time.
+	require.NoError(t, b.Set(ctx, &store.Record{Key: "key", Value: []byte("value"), ExpiresAt: time.Now().Add(time.Hour)}, store.SetWithTTL(time.Minute)))
+	record, err := b.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), record.ExpiresAt, time.Second)
+
+	// Without the option the record expiration time is kept.
+	require.NoError(t, b.Set(ctx, &store.Record{Key: "key", Value: []byte("value"), ExpiresAt: time.Now().Add(time.Hour)}))
+	record, err = b.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), record.ExpiresAt, time.Second)
+}
+
+func TestPersistence(t *testing.T) {
+	ctx := context.Background()
+	fileName := testingFileName(t)
+
+	b, err := New(store.WithFileName(fileName))
+	require.NoError(t, err)
+	require.NoError(t, b.Set(ctx, &store.Record{Key: "persistent", Value: []byte("first")}))
+	require.NoError(t, b.Set(ctx, &store.Record{Key: "expiring", Value: []byte("second")}, store.SetWithTTL(time.Minute)))
+	require.NoError(t, b.Set(ctx, &store.Record{Key: "expired", Value: []byte("third")}, store.SetWithTTL(time.Millisecond)))
+	require.NoError(t, b.Close(ctx))
+
+	time.Sleep(10 * time.Millisecond)
+	b, err = New(store.WithFileName(fileName))
+	require.NoError(t, err)
+	defer b.Close(ctx)
+
+	record, err := b.Get(ctx, "persistent")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), record.Value)
+	assert.True(t, record.ExpiresAt.IsZero())
+
+	record, err = b.Get(ctx, "expiring")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), record.Value)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), record.ExpiresAt, time.Second)
+
+	_, err = b.Get(ctx, "expired")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+}
+
+func TestDeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	b := testingStore(t, store.WithTimeFunc(func() time.Time { return now }))
+
+	require.NoError(t, b.Set(ctx, &store.Record{Key: "persistent", Value: []byte("first")}))
+	require.NoError(t, b.Set(ctx, &store.Record{Key: "expiring", Value: []byte("second")}, store.SetWithTTL(time.Hour)))
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, b.Set(ctx, &store.Record{Key: key, Value: []byte(key)}, store.SetWithTTL(time.Minute)))
+	}
+	assert.Equal(t, 5, b.count(t))
+
+	now = now.Add(2 * time.Minute)
+	err := b.Delete(ctx, "a")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+
+	require.NoError(t, b.DeleteExpired())
+	assert.Equal(t, 2, b.count(t))
+
+	records, err := b.Find(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, "expiring", records[0].Key)
+	assert.Equal(t, "persistent", records[1].Key)
+}
+
+func TestCleanup(t *testing.T) {
+	ctx := context.Background()
+	b := testingStore(t, func(o *store.Options) { o.CleanupInterval = 10 * time.Millisecond })
+
+	require.NoError(t, b.Set(ctx, &store.Record{Key: "expired", Value: []byte("value")}, store.SetWithTTL(time.Millisecond)))
+	assert.Eventually(t, func() bool { return b.count(t) == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New()
+	assert.True(t, errors.Is(err, store.ErrInitialization))
+}
+
+// count gets the number of all the records stored in the database - including the expired ones.
+func (b *Bolt) count(t *testing.T) int {
+	var count int
+	require.NoError(t, b.db.View(func(tx *bbolt.Tx) error {
+		count = tx.Bucket(bucketName).Stats().KeyN
+		return nil
+	}))
+	return count
+}
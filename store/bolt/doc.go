@@ -0,0 +1,4 @@
+// Package bolt contains the embedded on-disk key-value store implementation based on the bbolt database.
+// Each write is committed in a separate transaction synchronized to the disk, so that the records survives
+// the process crash.
+package bolt
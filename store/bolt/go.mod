@@ -0,0 +1,12 @@
+module github.com/neuronlabs/neuron-extensions/store/bolt
+
+go 1.13
+
+require (
+	github.com/neuronlabs/neuron v0.21.6
+	github.com/neuronlabs/neuron-extensions/store/xstore v0.0.0
+	github.com/stretchr/testify v1.4.0
+	go.etcd.io/bbolt v1.3.5
+)
+
+replace github.com/neuronlabs/neuron-extensions/store/xstore => ../xstore
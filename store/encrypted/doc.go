@@ -0,0 +1,5 @@
+// Package encrypted contains the store.Store decorator that encrypts the record values at rest.
+// The values are encrypted with the AES-GCM using the Keyring. Each ciphertext contains the identifier of the key
+// used for its encryption, so that the keys could be rotated without losing access to the already stored records.
+// Optionally the record keys are replaced with their HMAC-SHA256, so that the underlying store doesn't expose them.
+package encrypted
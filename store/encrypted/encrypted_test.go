@@ -0,0 +1,193 @@
+package encrypted
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/memory"
+	"github.com/neuronlabs/neuron-extensions/store/xstore/storetest"
+)
+
+var (
+	oldKey = Key{ID: "2020-01", Secret: bytes.Repeat([]byte{1}, 32)}
+	newKey = Key{ID: "2020-02", Secret: bytes.Repeat([]byte{2}, 32)}
+)
+
+func testingKeyring(t *testing.T, primary Key, previous ...Key) *Keyring {
+	k, err := NewKeyring(primary, previous...)
+	require.NoError(t, err)
+	return k
+}
+
+func testingMemory(t *testing.T, options ...store.Option) *memory.Memory {
+	m, err := memory.New(options...)
+	require.NoError(t, err)
+	return m
+}
+
+func TestStore(t *testing.T) {
+	t.Run("PlainKeys", func(t *testing.T) {
+		storetest.TestStore(t, func(t *testing.T, options ...store.Option) store.Store {
+			s, err := New(testingMemory(t, options...), testingKeyring(t, newKey))
+			require.NoError(t, err)
+			return s
+		})
+	})
+	t.Run("HashedKeys", func(t *testing.T) {
+		storetest.TestStore(t, func(t *testing.T, options ...store.Option) store.Store {
+			s, err := New(testingMemory(t, options...), testingKeyring(t, newKey), WithKeyHashing([]byte("secret")))
+			require.NoError(t, err)
+			return s
+		})
+	})
+}
+
+func TestEncryption(t *testing.T) {
+	ctx := context.Background()
+	m := testingMemory(t)
+	s, err := New(m, testingKeyring(t, newKey), WithKeyHashing([]byte("secret")))
+	require.NoError(t, err)
+
+	require.NoError(t, s.Set(ctx, &store.Record{Key: "token_first", Value: []byte("sensitive")}))
+	require.NoError(t, s.Set(ctx, &store.Record{Key: "token_second", Value: []byte("other")}))
+
+	raw, err := m.Find(ctx)
+	require.NoError(t, err)
+	require.Len(t, raw, 2)
+	for _, record := range raw {
+		assert.NotContains(t, record.Key, "token")
+		assert.False(t, bytes.Contains(record.Value, []byte("sensitive")))
+		assert.False(t, bytes.Contains(record.Value, []byte("token")))
+	}
+
+	t.Run("SwappedValues", func(t *testing.T) {
+		// The value moved to another key could not be authenticated.
+		require.NoError(t, m.Set(ctx, &store.Record{Key: raw[0].Key, Value: raw[1].Value}))
+		_, err := s.Find(ctx)
+		assert.True(t, errors.Is(err, ErrDecrypt))
+	})
+
+	t.Run("UnknownKey", func(t *testing.T) {
+		other, err := New(m, testingKeyring(t, oldKey), WithKeyHashing([]byte("secret")))
+		require.NoError(t, err)
+		_, err = other.Get(ctx, "token_second")
+		assert.True(t, errors.Is(err, ErrUnknownKey))
+	})
+}
+
+func TestRotate(t *testing.T) {
+	ctx := context.Background()
+	m := testingMemory(t)
+	old, err := New(m, testingKeyring(t, oldKey))
+	require.NoError(t, err)
+	require.NoError(t, old.Set(ctx, &store.Record{Key: "persistent", Value: []byte("first")}))
+	require.NoError(t, old.Set(ctx, &store.Record{Key: "expiring", Value: []byte("second")}, store.SetWithTTL(time.Minute)))
+
+	rotating, err := New(m, testingKeyring(t, newKey, oldKey))
+	require.NoError(t, err)
+	record, err := rotating.Get(ctx, "persistent")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), record.Value)
+
+	require.NoError(t, rotating.Set(ctx, &store.Record{Key: "new", Value: []byte("third")}))
+	rotated, err := rotating.Rotate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, rotated)
+
+	// After the rotation the old key is no longer needed.
+	s, err := New(m, testingKeyring(t, newKey))
+	require.NoError(t, err)
+	records, err := s.Find(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "expiring", records[0].Key)
+	assert.Equal(t, []byte("second"), records[0].Value)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), records[0].ExpiresAt, time.Second)
+	assert.True(t, records[1].ExpiresAt.IsZero())
+}
+
+// findHookStore calls the 'afterFind' function after the records are found.
+type findHookStore struct {
+	store.Store
+	afterFind func()
+}
+
+func (f *findHookStore) Find(ctx context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	records, err := f.Store.Find(ctx, options...)
+	if err == nil && f.afterFind != nil {
+		f.afterFind()
+	}
+	return records, err
+}
+
+// atomicFindHookStore is the findHookStore of the xstore.AtomicStore.
+type atomicFindHookStore struct {
+	*memory.Memory
+	afterFind func()
+}
+
+func (f *atomicFindHookStore) Find(ctx context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	return (&findHookStore{Store: f.Memory, afterFind: f.afterFind}).Find(ctx, options...)
+}
+
+func TestRotateConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	testRotate := func(t *testing.T, m *memory.Memory, underlying store.Store, afterFind *func()) {
+		old, err := New(m, testingKeyring(t, oldKey))
+		require.NoError(t, err)
+		require.NoError(t, old.Set(ctx, &store.Record{Key: "changed", Value: []byte("first")}))
+		require.NoError(t, old.Set(ctx, &store.Record{Key: "deleted", Value: []byte("second")}))
+		require.NoError(t, old.Set(ctx, &store.Record{Key: "unchanged", Value: []byte("third")}))
+
+		rotating, err := New(underlying, testingKeyring(t, newKey, oldKey))
+		require.NoError(t, err)
+		// The records are changed after the rotation have read them.
+		*afterFind = func() {
+			require.NoError(t, rotating.Set(ctx, &store.Record{Key: "changed", Value: []byte("updated")}))
+			require.NoError(t, rotating.Delete(ctx, "deleted"))
+		}
+		rotated, err := rotating.Rotate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rotated)
+
+		s, err := New(m, testingKeyring(t, newKey))
+		require.NoError(t, err)
+		records, err := s.Find(ctx)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, "changed", records[0].Key)
+		assert.Equal(t, []byte("updated"), records[0].Value)
+		assert.Equal(t, "unchanged", records[1].Key)
+	}
+
+	t.Run("Atomic", func(t *testing.T) {
+		m := testingMemory(t)
+		underlying := &atomicFindHookStore{Memory: m}
+		testRotate(t, m, underlying, &underlying.afterFind)
+	})
+
+	t.Run("NonAtomic", func(t *testing.T) {
+		m := testingMemory(t)
+		underlying := &findHookStore{Store: m}
+		testRotate(t, m, underlying, &underlying.afterFind)
+	})
+}
+
+func TestNewKeyring(t *testing.T) {
+	_, err := NewKeyring(Key{ID: "short", Secret: []byte("short")})
+	assert.True(t, errors.Is(err, ErrKeyring))
+
+	_, err = NewKeyring(Key{Secret: newKey.Secret})
+	assert.True(t, errors.Is(err, ErrKeyring))
+
+	_, err = NewKeyring(newKey, Key{ID: newKey.ID, Secret: oldKey.Secret})
+	assert.True(t, errors.Is(err, ErrKeyring))
+}
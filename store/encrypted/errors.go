@@ -0,0 +1,15 @@
+package encrypted
+
+import (
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+)
+
+var (
+	// ErrKeyring is the error returned when the keyring is not valid.
+	ErrKeyring = errors.Wrap(store.ErrInitialization, "invalid keyring")
+	// ErrUnknownKey is the error returned when the record is encrypted with the key not present in the keyring.
+	ErrUnknownKey = errors.Wrap(store.ErrStore, "unknown encryption key")
+	// ErrDecrypt is the error returned when the record value is malformed or could not be authenticated.
+	ErrDecrypt = errors.Wrap(store.ErrStore, "decrypting record failed")
+)
@@ -0,0 +1,114 @@
+package encrypted
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+)
+
+// formatVersion is the version of the encrypted value format:
+//
+//	version (1 byte) | key id length (1 byte) | key id | nonce | sealed (key length (uvarint) | key | value)
+const formatVersion byte = 1
+
+// Key is the AES encryption key with its identifier. The Secret must be 16, 24 or 32 bytes long
+// in order to select AES-128, AES-192 or AES-256.
+type Key struct {
+	ID     string
+	Secret []byte
+}
+
+// Keyring is the set of the encryption keys. The values are always encrypted with the primary key, whereas
+// the rest of the keys are used only for the decryption of the values encrypted before the key rotation.
+type Keyring struct {
+	primary string
+	keys    map[string]cipher.AEAD
+}
+
+// NewKeyring creates new keyring with the 'primary' key used for the encryption and the 'previous' keys
+// used only for the decryption.
+func NewKeyring(primary Key, previous ...Key) (*Keyring, error) {
+	k := &Keyring{primary: primary.ID, keys: map[string]cipher.AEAD{}}
+	for _, key := range append([]Key{primary}, previous...) {
+		if key.ID == "" || len(key.ID) > 255 {
+			return nil, errors.Wrapf(ErrKeyring, "key id: '%s' must be between 1 and 255 bytes long", key.ID)
+		}
+		if _, ok := k.keys[key.ID]; ok {
+			return nil, errors.Wrapf(ErrKeyring, "duplicated key id: '%s'", key.ID)
+		}
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, errors.Wrapf(ErrKeyring, "key: '%s' - %v", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, errors.Wrapf(ErrKeyring, "key: '%s' - %v", key.ID, err)
+		}
+		k.keys[key.ID] = aead
+	}
+	return k, nil
+}
+
+// PrimaryID gets the identifier of the primary key.
+func (k *Keyring) PrimaryID() string {
+	return k.primary
+}
+
+// KeyID gets the identifier of the key used to encrypt the 'ciphertext'.
+func (k *Keyring) KeyID(ciphertext []byte) (string, error) {
+	if len(ciphertext) < 2 || ciphertext[0] != formatVersion || len(ciphertext) < 2+int(ciphertext[1]) {
+		return "", errors.Wrap(ErrDecrypt, "malformed ciphertext")
+	}
+	return string(ciphertext[2 : 2+int(ciphertext[1])]), nil
+}
+
+// encrypt encrypts the record 'key' and 'value' with the primary key. The 'additionalData' is authenticated
+// but not encrypted.
+func (k *Keyring) encrypt(key string, value, additionalData []byte) ([]byte, error) {
+	aead := k.keys[k.primary]
+	plaintext := make([]byte, binary.MaxVarintLen64, binary.MaxVarintLen64+len(key)+len(value))
+	plaintext = plaintext[:binary.PutUvarint(plaintext, uint64(len(key)))]
+	plaintext = append(append(plaintext, key...), value...)
+
+	header := 2 + len(k.primary)
+	ciphertext := make([]byte, header+aead.NonceSize(), header+aead.NonceSize()+len(plaintext)+aead.Overhead())
+	ciphertext[0] = formatVersion
+	ciphertext[1] = byte(len(k.primary))
+	copy(ciphertext[2:], k.primary)
+	nonce := ciphertext[header:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrapf(store.ErrInternal, "generating nonce failed: %v", err)
+	}
+	return aead.Seal(ciphertext, nonce, plaintext, additionalData), nil
+}
+
+// decrypt decrypts the record key and value from the 'ciphertext' authenticating the 'additionalData'.
+func (k *Keyring) decrypt(ciphertext, additionalData []byte) (string, []byte, error) {
+	keyID, err := k.KeyID(ciphertext)
+	if err != nil {
+		return "", nil, err
+	}
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return "", nil, errors.Wrapf(ErrUnknownKey, "key id: '%s'", keyID)
+	}
+	ciphertext = ciphertext[2+len(keyID):]
+	if len(ciphertext) < aead.NonceSize() {
+		return "", nil, errors.Wrap(ErrDecrypt, "malformed ciphertext")
+	}
+	plaintext, err := aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+	if err != nil {
+		return "", nil, errors.Wrap(ErrDecrypt, err.Error())
+	}
+	keyLength, n := binary.Uvarint(plaintext)
+	if n <= 0 || uint64(len(plaintext)-n) < keyLength {
+		return "", nil, errors.Wrap(ErrDecrypt, "malformed plaintext")
+	}
+	plaintext = plaintext[n:]
+	return string(plaintext[:keyLength]), plaintext[keyLength:], nil
+}
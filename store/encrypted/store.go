@@ -0,0 +1,276 @@
+package encrypted
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/service"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+var (
+	_ store.Store    = &Store{}
+	_ service.Dialer = &Store{}
+	_ service.Closer = &Store{}
+)
+
+// Options are the encrypted store options.
+type Options struct {
+	// KeyHashSecret is the HMAC secret used for hashing the record keys. If it is empty the keys are stored
+	// in the plaintext.
+	KeyHashSecret []byte
+}
+
+// Option is the function that changes the encrypted store options.
+type Option func(o *Options)
+
+// WithKeyHashing sets the HMAC 'secret' used for hashing the record keys. With the key hashing enabled
+// the Find method needs to load and decrypt all the records of the underlying store in order to match their keys.
+func WithKeyHashing(secret []byte) Option {
+	return func(o *Options) {
+		o.KeyHashSecret = secret
+	}
+}
+
+// Store is the store.Store decorator that encrypts the record values with the AES-GCM before storing them in the
+// underlying store. The encrypted value contains also the original record key, and is authenticated with the key
+// used in the underlying store, so that the values could not be swapped between the records. The underlying store
+// should be dedicated to the encrypted records - i.e. by using a store prefix.
+type Store struct {
+	Options *Options
+	store   store.Store
+	keyring *Keyring
+}
+
+// New creates new encrypted store that wraps the store 's' and encrypts its values using the 'keyring'.
+func New(s store.Store, keyring *Keyring, options ...Option) (*Store, error) {
+	if s == nil {
+		return nil, errors.Wrap(store.ErrInitialization, "no underlying store provided")
+	}
+	if keyring == nil {
+		return nil, errors.Wrap(ErrKeyring, "no keyring provided")
+	}
+	e := &Store{Options: &Options{}, store: s, keyring: keyring}
+	for _, option := range options {
+		option(e.Options)
+	}
+	return e, nil
+}
+
+// Dial implements service.Dialer interface. It dials the underlying store if it implements the service.Dialer.
+func (e *Store) Dial(ctx context.Context) error {
+	if dialer, ok := e.store.(service.Dialer); ok {
+		return dialer.Dial(ctx)
+	}
+	return nil
+}
+
+// Close implements service.Closer interface. It closes the underlying store if it implements the service.Closer.
+func (e *Store) Close(ctx context.Context) error {
+	if closer, ok := e.store.(service.Closer); ok {
+		return closer.Close(ctx)
+	}
+	return nil
+}
+
+// Set implements store.Store interface.
+func (e *Store) Set(ctx context.Context, record *store.Record, options ...store.SetOption) error {
+	encrypted, err := e.encrypt(record)
+	if err != nil {
+		return err
+	}
+	if err = e.store.Set(ctx, encrypted, options...); err != nil {
+		return err
+	}
+	record.ExpiresAt = encrypted.ExpiresAt
+	return nil
+}
+
+// Get implements store.Store interface.
+func (e *Store) Get(ctx context.Context, key string) (*store.Record, error) {
+	encrypted, err := e.store.Get(ctx, e.storeKey(key))
+	if err != nil {
+		return nil, err
+	}
+	record, err := e.decrypt(encrypted)
+	if err != nil {
+		return nil, err
+	}
+	if record.Key != key {
+		return nil, errors.Wrapf(ErrDecrypt, "record key doesn't match: '%s'", key)
+	}
+	return record, nil
+}
+
+// Delete implements store.Store interface.
+func (e *Store) Delete(ctx context.Context, key string) error {
+	return e.store.Delete(ctx, e.storeKey(key))
+}
+
+// Find implements store.Store interface. If the key hashing is enabled, all the records of the underlying store
+// are loaded and decrypted in order to match their keys.
+func (e *Store) Find(ctx context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	if len(e.Options.KeyHashSecret) == 0 {
+		records, err := e.store.Find(ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+		for i, record := range records {
+			if records[i], err = e.decrypt(record); err != nil {
+				return nil, err
+			}
+		}
+		return records, nil
+	}
+
+	o := &store.FindPattern{}
+	for _, option := range options {
+		option(o)
+	}
+	all, err := e.store.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var records []*store.Record
+	for _, encrypted := range all {
+		record, err := e.decrypt(encrypted)
+		if err != nil {
+			return nil, err
+		}
+		key := record.Key
+		if len(key) < len(o.Prefix)+len(o.Suffix) || !strings.HasPrefix(key, o.Prefix) || !strings.HasSuffix(key, o.Suffix) {
+			continue
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Key < records[j].Key
+	})
+	if o.Offset >= len(records) {
+		return nil, nil
+	}
+	records = records[o.Offset:]
+	if o.Limit > 0 && o.Limit < len(records) {
+		records = records[:o.Limit]
+	}
+	return records, nil
+}
+
+// Rotate re-encrypts all the records that are not encrypted with the primary key of the keyring. The records
+// keeps their expiration time. Returns the number of the re-encrypted records. After the rotation the previous
+// keys could be removed from the keyring.
+//
+// If the underlying store implements xstore.AtomicStore, each record is replaced using the compare and swap with
+// the version of the ciphertext read by the rotation, so that the records changed or deleted in the meantime are
+// not overwritten. Otherwise, the records changed since they were read are skipped, but a write between that check
+// and the re-encrypted value write would still be lost - the writes to the store must be stopped during the rotation.
+func (e *Store) Rotate(ctx context.Context) (int, error) {
+	all, err := e.store.Find(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var rotated int
+	for _, encrypted := range all {
+		keyID, err := e.keyring.KeyID(encrypted.Value)
+		if err != nil {
+			return rotated, err
+		}
+		if keyID == e.keyring.PrimaryID() {
+			continue
+		}
+		record, err := e.decrypt(encrypted)
+		if err != nil {
+			return rotated, err
+		}
+		var options []store.SetOption
+		if !record.ExpiresAt.IsZero() {
+			ttl := time.Until(record.ExpiresAt)
+			if ttl <= 0 {
+				continue
+			}
+			options = append(options, store.SetWithTTL(ttl))
+		}
+		swapped, err := e.replace(ctx, encrypted, record, options)
+		if err != nil {
+			return rotated, err
+		}
+		if !swapped {
+			log.Debug2f("Encrypted store - record changed during the rotation, skipping")
+			continue
+		}
+		rotated++
+	}
+	log.Debug2f("Encrypted store - rotated: %d records", rotated)
+	return rotated, nil
+}
+
+// replace re-encrypts the 'record' and stores it in place of the 'encrypted' record, only if the stored ciphertext
+// was not changed. Returns false if the record was changed or deleted.
+func (e *Store) replace(ctx context.Context, encrypted, record *store.Record, options []store.SetOption) (bool, error) {
+	reEncrypted, err := e.encrypt(record)
+	if err != nil {
+		return false, err
+	}
+	if atomic, ok := e.store.(xstore.AtomicStore); ok {
+		swapped, err := atomic.CompareAndSwap(ctx, reEncrypted, xstore.RecordVersion(encrypted), options...)
+		if err != nil {
+			if errors.Is(err, store.ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return swapped, nil
+	}
+	current, err := e.store.Get(ctx, encrypted.Key)
+	if err != nil {
+		if errors.Is(err, store.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !bytes.Equal(current.Value, encrypted.Value) {
+		return false, nil
+	}
+	if err = e.store.Set(ctx, reEncrypted, options...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// encrypt creates the record of the underlying store with the encrypted 'record' value.
+func (e *Store) encrypt(record *store.Record) (*store.Record, error) {
+	storeKey := e.storeKey(record.Key)
+	value, err := e.keyring.encrypt(record.Key, record.Value, []byte(storeKey))
+	if err != nil {
+		return nil, err
+	}
+	return &store.Record{Key: storeKey, Value: value, ExpiresAt: record.ExpiresAt}, nil
+}
+
+func (e *Store) decrypt(encrypted *store.Record) (*store.Record, error) {
+	key, value, err := e.keyring.decrypt(encrypted.Value, []byte(encrypted.Key))
+	if err != nil {
+		return nil, err
+	}
+	return &store.Record{Key: key, Value: value, ExpiresAt: encrypted.ExpiresAt}, nil
+}
+
+// storeKey gets the key of the record in the underlying store.
+func (e *Store) storeKey(key string) string {
+	if len(e.Options.KeyHashSecret) == 0 {
+		return key
+	}
+	mac := hmac.New(sha256.New, e.Options.KeyHashSecret)
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
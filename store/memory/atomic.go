@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if memory implements xstore.AtomicStore interface.
+var _ xstore.AtomicStore = &Memory{}
+
+// SetIfNotExists implements xstore.AtomicStore interface.
+func (m *Memory) SetIfNotExists(_ context.Context, record *store.Record, options ...store.SetOption) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, found := m.cache.Get(m.key(record.Key)); found {
+		return false, nil
+	}
+	m.set(record, m.ttl(options))
+	return true, nil
+}
+
+// CompareAndSwap implements xstore.AtomicStore interface.
+func (m *Memory) CompareAndSwap(ctx context.Context, record *store.Record, version xstore.Version, options ...store.SetOption) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, err := m.Get(ctx, record.Key)
+	if err != nil {
+		return false, err
+	}
+	if xstore.RecordVersion(current) != version {
+		return false, nil
+	}
+	m.set(record, m.ttl(options))
+	return true, nil
+}
+
+// Increment implements xstore.AtomicStore interface.
+func (m *Memory) Increment(_ context.Context, key string, delta int64, options ...store.SetOption) (int64, error) {
+	// The negative ttl is the already passed expiration time i.e. provided with time.Until.
+	o := &store.SetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.TTL < 0 {
+		return 0, errors.Wrapf(store.ErrInternal, "counter: '%s' ttl: '%s' is negative", key, o.TTL)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.increment(key, delta, m.ttl(options))
+}
+
+// increment increments the counter stored with the 'key'. The 'ttl' is used only when the counter is created.
+// The counter which has reached its expiration time is recreated with the 'delta' value. Requires the lock to be held.
+func (m *Memory) increment(key string, delta int64, ttl time.Duration) (int64, error) {
+	v, expiration, found := m.cache.GetWithExpiration(m.key(key))
+	if found && !expiration.IsZero() && !expiration.After(m.Options.TimeFunc()) {
+		found = false
+	}
+	if !found {
+		m.set(&store.Record{Key: key, Value: []byte(strconv.FormatInt(delta, 10))}, ttl)
+		return delta, nil
+	}
+	current, ok := v.([]byte)
+	if !ok {
+		return 0, errors.Wrap(store.ErrInternal, "malformed record type")
+	}
+	value, err := strconv.ParseInt(string(current), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(xstore.ErrNotCounter, "key: '%s'", key)
+	}
+	value += delta
+	// Keep the expiration time of the counter.
+	ttl = cache.NoExpiration
+	if !expiration.IsZero() {
+		ttl = expiration.Sub(m.Options.TimeFunc())
+	}
+	m.cache.Set(m.key(key), []byte(strconv.FormatInt(value, 10)), ttl)
+	return value, nil
+}
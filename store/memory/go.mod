@@ -4,5 +4,9 @@ go 1.13
 
 require (
 	github.com/neuronlabs/neuron v0.21.6
+	github.com/neuronlabs/neuron-extensions/store/xstore v0.0.0
 	github.com/patrickmn/go-cache v2.1.0+incompatible
+	github.com/stretchr/testify v1.4.0
 )
+
+replace github.com/neuronlabs/neuron-extensions/store/xstore => ../xstore
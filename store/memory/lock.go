@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+
+	"github.com/neuronlabs/neuron/errors"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if Locker implements xstore.Locker interface.
+var _ xstore.Locker = &Locker{}
+
+const (
+	lockKeyPrefix  = "nrn_lock_"
+	fenceKeyPrefix = "nrn_fence_"
+)
+
+// Locker is the xstore.Locker implementation that keeps the locks in the memory store. It is intended
+// for the tests and the single instance services.
+type Locker struct {
+	m *Memory
+}
+
+// NewLocker creates new locker that uses provided memory store.
+func NewLocker(m *Memory) *Locker {
+	return &Locker{m: m}
+}
+
+// Acquire implements xstore.Locker interface.
+func (l *Locker) Acquire(_ context.Context, name string, ttl time.Duration) (xstore.Lock, error) {
+	token, err := xstore.NewLockToken()
+	if err != nil {
+		return nil, err
+	}
+	l.m.mu.Lock()
+	defer l.m.mu.Unlock()
+
+	key := l.m.key(lockKeyPrefix + name)
+	if _, found := l.m.cache.Get(key); found {
+		return nil, errors.Wrapf(xstore.ErrLockHeld, "lock: '%s'", name)
+	}
+	fence, err := l.m.increment(fenceKeyPrefix+name, 1, cache.NoExpiration)
+	if err != nil {
+		return nil, err
+	}
+	l.m.cache.Set(key, []byte(token), ttl)
+	return &lock{l: l, name: name, token: token, fence: fence}, nil
+}
+
+type lock struct {
+	l     *Locker
+	name  string
+	token string
+	fence int64
+}
+
+// Name implements xstore.Lock interface.
+func (l *lock) Name() string {
+	return l.name
+}
+
+// Token implements xstore.Lock interface.
+func (l *lock) Token() string {
+	return l.token
+}
+
+// Fence implements xstore.Lock interface.
+func (l *lock) Fence() int64 {
+	return l.fence
+}
+
+// Refresh implements xstore.Lock interface.
+func (l *lock) Refresh(_ context.Context, ttl time.Duration) error {
+	m := l.l.m
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, err := l.ownedKey()
+	if err != nil {
+		return err
+	}
+	m.cache.Set(key, []byte(l.token), ttl)
+	return nil
+}
+
+// Release implements xstore.Lock interface.
+func (l *lock) Release(context.Context) error {
+	m := l.l.m
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, err := l.ownedKey()
+	if err != nil {
+		return err
+	}
+	m.cache.Delete(key)
+	return nil
+}
+
+// ownedKey gets the lock key if it is still owned by the lock. Requires the lock to be held.
+func (l *lock) ownedKey() (string, error) {
+	key := l.l.m.key(lockKeyPrefix + l.name)
+	v, found := l.l.m.cache.Get(key)
+	if !found {
+		return "", errors.Wrapf(xstore.ErrLockNotHeld, "lock: '%s' expired", l.name)
+	}
+	if token, ok := v.([]byte); !ok || string(token) != l.token {
+		return "", errors.Wrapf(xstore.ErrLockNotHeld, "lock: '%s' is held by another owner", l.name)
+	}
+	return key, nil
+}
@@ -3,7 +3,10 @@ package memory
 import (
 	"context"
 	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/patrickmn/go-cache"
 
@@ -19,6 +22,8 @@ var _ store.Store = &Memory{}
 type Memory struct {
 	cache   *cache.Cache
 	Options *store.Options
+	// mu guards the write operations so that the atomic extensions are consistent with the Set and Delete.
+	mu sync.Mutex
 }
 
 // New creates new in-memory store.
@@ -42,22 +47,10 @@ func New(options ...store.Option) (*Memory, error) {
 
 // Set implements store.Store interface.
 func (m *Memory) Set(_ context.Context, record *store.Record, options ...store.SetOption) error {
-	o := &store.SetOptions{}
-	for _, option := range options {
-		option(o)
-	}
-	ttl := m.Options.DefaultExpiration
-	if o.TTL != 0 {
-		ttl = o.TTL
-	}
-	key := m.key(record.Key)
-	if record.ExpiresAt.IsZero() {
-		record.ExpiresAt = m.Options.TimeFunc().Add(ttl)
-	}
-	cp := make([]byte, len(record.Value))
-	copy(cp, record.Value)
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	m.cache.Set(key, cp, ttl)
+	m.set(record, m.ttl(options))
 	return nil
 }
 
@@ -81,6 +74,9 @@ func (m *Memory) Get(_ context.Context, key string) (*store.Record, error) {
 
 // Delete implements store.Store interface.
 func (m *Memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	_, found := m.cache.Get(m.key(key))
 	if !found {
 		return store.ErrRecordNotFound
@@ -89,36 +85,49 @@ func (m *Memory) Delete(_ context.Context, key string) error {
 	return nil
 }
 
-// Find implements store.Store.
+// Find implements store.Store interface. The records are sorted by their keys.
 func (m *Memory) Find(_ context.Context, options ...store.FindOption) ([]*store.Record, error) {
 	findOptions := &store.FindPattern{}
 	for _, option := range options {
 		option(findOptions)
 	}
-	items := m.cache.Items()
-	var records []*store.Record
 	prefix := m.Options.Prefix + findOptions.Prefix
-	suffix := m.Options.Suffix + findOptions.Suffix
-	for k, v := range items {
-		if prefix != "" && !strings.HasPrefix(k, prefix) {
-			continue
-		}
-		if suffix != "" && !strings.HasSuffix(k, suffix) {
-			continue
-		}
-		if findOptions.Offset != 0 {
-			findOptions.Offset--
+	suffix := findOptions.Suffix + m.Options.Suffix
+
+	// Items returns only not expired items.
+	items := m.cache.Items()
+	keys := make([]string, 0, len(items))
+	for k := range items {
+		if len(k) < len(prefix)+len(suffix) || !strings.HasPrefix(k, prefix) || !strings.HasSuffix(k, suffix) {
 			continue
 		}
-		rec, ok := v.Object.(*store.Record)
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return m.originalKey(keys[i]) < m.originalKey(keys[j])
+	})
+	if findOptions.Offset >= len(keys) {
+		return nil, nil
+	}
+	keys = keys[findOptions.Offset:]
+	if findOptions.Limit > 0 && findOptions.Limit < len(keys) {
+		keys = keys[:findOptions.Limit]
+	}
+
+	records := make([]*store.Record, len(keys))
+	for i, k := range keys {
+		item := items[k]
+		value, ok := item.Object.([]byte)
 		if !ok {
-			return nil, errors.Wrap(store.ErrInternal, "a record is not store.Record")
+			log.Errorf("Malformed store value type: %T", item.Object)
+			return nil, errors.Wrap(store.ErrInternal, "malformed record type")
 		}
-		records = append(records, rec.Copy())
-		findOptions.Limit--
-		if findOptions.Limit == 0 {
-			break
+		record := &store.Record{Key: m.originalKey(k), Value: make([]byte, len(value))}
+		copy(record.Value, value)
+		if item.Expiration > 0 {
+			record.ExpiresAt = time.Unix(0, item.Expiration)
 		}
+		records[i] = record
 	}
 	return records, nil
 }
@@ -136,6 +145,32 @@ func (m *Memory) Close(context.Context) error {
 	return nil
 }
 
+func (m *Memory) set(record *store.Record, ttl time.Duration) {
+	if record.ExpiresAt.IsZero() && ttl > 0 {
+		record.ExpiresAt = m.Options.TimeFunc().Add(ttl)
+	}
+	cp := make([]byte, len(record.Value))
+	copy(cp, record.Value)
+
+	m.cache.Set(m.key(record.Key), cp, ttl)
+}
+
+func (m *Memory) ttl(options []store.SetOption) time.Duration {
+	o := &store.SetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	if o.TTL != 0 {
+		return o.TTL
+	}
+	return m.Options.DefaultExpiration
+}
+
 func (m *Memory) key(key string) string {
 	return m.Options.Prefix + key + m.Options.Suffix
 }
+
+// originalKey trims the store prefix and suffix from the cache 'key'.
+func (m *Memory) originalKey(key string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(key, m.Options.Prefix), m.Options.Suffix)
+}
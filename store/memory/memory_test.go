@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+	"github.com/neuronlabs/neuron-extensions/store/xstore/storetest"
+)
+
+func testingStore(t *testing.T, options ...store.Option) *Memory {
+	m, err := New(options...)
+	require.NoError(t, err)
+	return m
+}
+
+func TestStore(t *testing.T) {
+	storetest.TestStore(t, func(t *testing.T, options ...store.Option) store.Store {
+		return testingStore(t, options...)
+	})
+}
+
+func TestAtomic(t *testing.T) {
+	storetest.TestAtomic(t, func(t *testing.T) xstore.AtomicStore {
+		return testingStore(t)
+	})
+}
+
+func TestIncrementExpiration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	m := testingStore(t, store.WithTimeFunc(func() time.Time { return now }))
+
+	value, err := m.Increment(ctx, "counter", 1, store.SetWithTTL(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+
+	// The expired counter is recreated with the requested ttl.
+	now = now.Add(2 * time.Minute)
+	value, err = m.Increment(ctx, "counter", 1, store.SetWithTTL(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), value)
+	_, expiration, found := m.cache.GetWithExpiration(m.key("counter"))
+	require.True(t, found)
+	assert.True(t, expiration.After(time.Now().Add(time.Minute)))
+
+	// The counter could not be incremented with already passed expiration time.
+	_, err = m.Increment(ctx, "expired", 1, store.SetWithTTL(-time.Second))
+	assert.Error(t, err)
+	_, err = m.Get(ctx, "expired")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+}
+
+func TestPubSub(t *testing.T) {
+	factory := func(t *testing.T) xstore.PubSub {
+		ps := NewPubSub()
+		t.Cleanup(func() { _ = ps.Close(context.Background()) })
+		return ps
+	}
+	storetest.TestPubSub(t, factory)
+	storetest.TestConsumerGroups(t, factory)
+}
+
+func TestLocker(t *testing.T) {
+	storetest.TestLocker(t, func(t *testing.T) xstore.Locker {
+		return NewLocker(testingStore(t))
+	})
+}
+
+func TestLockerKeys(t *testing.T) {
+	ctx := context.Background()
+	l := NewLocker(testingStore(t))
+
+	// The lock named with the fence prefix doesn't collide with the fence counter of other lock.
+	first, err := l.Acquire(ctx, "x", time.Minute)
+	require.NoError(t, err)
+	second, err := l.Acquire(ctx, "fence_x", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.Fence())
+	assert.Equal(t, int64(1), second.Fence())
+
+	require.NoError(t, first.Release(ctx))
+	first, err = l.Acquire(ctx, "x", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), first.Fence())
+}
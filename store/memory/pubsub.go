@@ -0,0 +1,291 @@
+package memory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if PubSub implements xstore.PubSub interface.
+var _ xstore.PubSub = &PubSub{}
+
+// PubSub is the in-memory xstore.PubSub implementation. It supports the consumer groups - the group messages
+// are delivered to the group consumers in the round robin manner and redelivered if not acknowledged within
+// the acknowledge timeout. The group is created on the first subscription, so that the messages published
+// before are not delivered to it.
+type PubSub struct {
+	mu     sync.Mutex
+	topics map[string]*topic
+	seq    uint64
+	closed bool
+}
+
+// NewPubSub creates new in-memory PubSub.
+func NewPubSub() *PubSub {
+	return &PubSub{topics: map[string]*topic{}}
+}
+
+type topic struct {
+	subscriptions map[*subscription]struct{}
+	groups        map[string]*group
+}
+
+type group struct {
+	name      string
+	consumers []*subscription
+	next      int
+	// backlog are the messages waiting for the consumers.
+	backlog []*pendingMessage
+	pending map[string]*pendingMessage
+}
+
+type pendingMessage struct {
+	message    *xstore.Message
+	deliveries int
+	ackTimeout time.Duration
+	timer      *time.Timer
+}
+
+// Publish implements xstore.Publisher interface.
+func (p *PubSub) Publish(_ context.Context, topicName string, payload []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return xstore.ErrSubscriptionClosed
+	}
+	t, ok := p.topics[topicName]
+	if !ok {
+		return nil
+	}
+	p.seq++
+	msg := &xstore.Message{
+		ID:          strconv.FormatUint(p.seq, 10),
+		Topic:       topicName,
+		Payload:     append([]byte(nil), payload...),
+		PublishedAt: time.Now(),
+		Deliveries:  1,
+	}
+	for s := range t.subscriptions {
+		cp := *msg
+		s.push(&cp)
+	}
+	for _, g := range t.groups {
+		p.deliver(g, &pendingMessage{message: msg})
+	}
+	return nil
+}
+
+// Subscribe implements xstore.Subscriber interface.
+func (p *PubSub) Subscribe(_ context.Context, topicName string, options ...xstore.SubscribeOption) (xstore.Subscription, error) {
+	o := xstore.NewSubscribeOptions(options...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return nil, xstore.ErrSubscriptionClosed
+	}
+	t, ok := p.topics[topicName]
+	if !ok {
+		t = &topic{subscriptions: map[*subscription]struct{}{}, groups: map[string]*group{}}
+		p.topics[topicName] = t
+	}
+	s := newSubscription(p, t, o)
+	if o.Group == "" {
+		t.subscriptions[s] = struct{}{}
+		return s, nil
+	}
+	g, ok := t.groups[o.Group]
+	if !ok {
+		g = &group{name: o.Group, pending: map[string]*pendingMessage{}}
+		t.groups[o.Group] = g
+	}
+	s.group = g
+	g.consumers = append(g.consumers, s)
+	backlog := g.backlog
+	g.backlog = nil
+	for _, pm := range backlog {
+		p.deliver(g, pm)
+	}
+	return s, nil
+}
+
+// Close closes all the subscriptions and stops accepting new messages.
+func (p *PubSub) Close(context.Context) error {
+	p.mu.Lock()
+	var subscriptions []*subscription
+	for _, t := range p.topics {
+		for s := range t.subscriptions {
+			subscriptions = append(subscriptions, s)
+		}
+		for _, g := range t.groups {
+			subscriptions = append(subscriptions, g.consumers...)
+		}
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	for _, s := range subscriptions {
+		_ = s.Close()
+	}
+	return nil
+}
+
+// deliver delivers the pending message to the next group consumer. Requires the lock to be held.
+func (p *PubSub) deliver(g *group, pm *pendingMessage) {
+	if len(g.consumers) == 0 {
+		g.backlog = append(g.backlog, pm)
+		return
+	}
+	s := g.consumers[g.next%len(g.consumers)]
+	g.next++
+
+	pm.deliveries++
+	pm.ackTimeout = s.options.AckTimeout
+	g.pending[pm.message.ID] = pm
+
+	msg := *pm.message
+	msg.Deliveries = pm.deliveries
+	msg.AckFunc = func(context.Context) error {
+		return p.ack(g, pm)
+	}
+	pm.timer = time.AfterFunc(pm.ackTimeout, func() {
+		p.redeliver(g, pm)
+	})
+	s.push(&msg)
+}
+
+func (p *PubSub) ack(g *group, pm *pendingMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if current, ok := g.pending[pm.message.ID]; ok && current == pm {
+		pm.timer.Stop()
+		delete(g.pending, pm.message.ID)
+	}
+	return nil
+}
+
+func (p *PubSub) redeliver(g *group, pm *pendingMessage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if current, ok := g.pending[pm.message.ID]; !ok || current != pm || p.closed {
+		return
+	}
+	delete(g.pending, pm.message.ID)
+	p.deliver(g, pm)
+}
+
+func (p *PubSub) unsubscribe(t *topic, s *subscription) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if s.group == nil {
+		delete(t.subscriptions, s)
+		return
+	}
+	for i, consumer := range s.group.consumers {
+		if consumer == s {
+			s.group.consumers = append(s.group.consumers[:i], s.group.consumers[i+1:]...)
+			break
+		}
+	}
+}
+
+// subscription is the in-memory xstore.Subscription. The messages are queued without the limit, so that
+// the publishers are never blocked by the slow subscribers.
+type subscription struct {
+	p       *PubSub
+	topic   *topic
+	group   *group
+	options *xstore.SubscribeOptions
+
+	mu     sync.Mutex
+	queue  []*xstore.Message
+	signal chan struct{}
+	out    chan *xstore.Message
+	done   chan struct{}
+	closed bool
+}
+
+func newSubscription(p *PubSub, t *topic, o *xstore.SubscribeOptions) *subscription {
+	s := &subscription{
+		p:       p,
+		topic:   t,
+		options: o,
+		signal:  make(chan struct{}, 1),
+		out:     make(chan *xstore.Message, o.BufferSize),
+		done:    make(chan struct{}),
+	}
+	go s.pump()
+	return s
+}
+
+// Messages implements xstore.Subscription interface.
+func (s *subscription) Messages() <-chan *xstore.Message {
+	return s.out
+}
+
+// Err implements xstore.Subscription interface.
+func (s *subscription) Err() error {
+	return nil
+}
+
+// Close implements xstore.Subscription interface.
+func (s *subscription) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return errors.Wrap(xstore.ErrSubscriptionClosed, "subscription already closed")
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	s.p.unsubscribe(s.topic, s)
+	close(s.done)
+	return nil
+}
+
+func (s *subscription) push(msg *xstore.Message) {
+	s.mu.Lock()
+	s.queue = append(s.queue, msg)
+	s.mu.Unlock()
+
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pump moves the queued messages to the output channel.
+func (s *subscription) pump() {
+	defer close(s.out)
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			select {
+			case <-s.signal:
+				continue
+			case <-s.done:
+				return
+			}
+		}
+		msg := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		select {
+		case s.out <- msg:
+		case <-s.done:
+			return
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if redis implements xstore.AtomicStore interface.
+var _ xstore.AtomicStore = &Redis{}
+
+var (
+	compareAndSwapScript = redis.NewScript(compareAndSwapSource)
+	incrementScript      = redis.NewScript(incrementSource)
+)
+
+// compareAndSwapSource replaces the value of the key only if the SHA-1 of its current value matches provided version.
+// Returns -1 if the key doesn't exists, 0 if the versions doesn't match and 1 if the value was swapped.
+const compareAndSwapSource = `
+local current = redis.call('GET', KEYS[1])
+if not current then
+	return -1
+end
+if redis.sha1hex(current) ~= ARGV[1] then
+	return 0
+end
+local ttl = tonumber(ARGV[3])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[2], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[2])
+end
+return 1
+`
+
+// incrementSource increments the counter and sets its expiration only if it was created by the increment.
+const incrementSource = `
+local exists = redis.call('EXISTS', KEYS[1])
+local value = redis.call('INCRBY', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if exists == 0 and ttl > 0 then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return value
+`
+
+// SetIfNotExists implements xstore.AtomicStore interface.
+func (r *Redis) SetIfNotExists(ctx context.Context, record *store.Record, options ...store.SetOption) (bool, error) {
+	if err := r.checkInitialization(); err != nil {
+		return false, err
+	}
+	set, err := r.r.SetNX(ctx, r.getKey(record.Key), record.Value, r.ttl(options)).Result()
+	if err != nil {
+		return false, errors.Wrap(store.ErrStore, err.Error())
+	}
+	return set, nil
+}
+
+// CompareAndSwap implements xstore.AtomicStore interface.
+func (r *Redis) CompareAndSwap(ctx context.Context, record *store.Record, version xstore.Version, options ...store.SetOption) (bool, error) {
+	if err := r.checkInitialization(); err != nil {
+		return false, err
+	}
+	ttl := r.ttl(options) / time.Millisecond
+	result, err := compareAndSwapScript.Run(ctx, r.r, []string{r.getKey(record.Key)}, string(version), record.Value, int64(ttl)).Int()
+	if err != nil {
+		return false, errors.Wrap(store.ErrStore, err.Error())
+	}
+	switch result {
+	case -1:
+		return false, store.ErrRecordNotFound
+	case 0:
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// Increment implements xstore.AtomicStore interface.
+func (r *Redis) Increment(ctx context.Context, key string, delta int64, options ...store.SetOption) (int64, error) {
+	if err := r.checkInitialization(); err != nil {
+		return 0, err
+	}
+	ttl := r.ttl(options) / time.Millisecond
+	value, err := incrementScript.Run(ctx, r.r, []string{r.getKey(key)}, delta, int64(ttl)).Int64()
+	if err != nil {
+		if strings.Contains(err.Error(), "not an integer") {
+			return 0, errors.Wrapf(xstore.ErrNotCounter, "key: '%s'", key)
+		}
+		return 0, errors.Wrap(store.ErrStore, err.Error())
+	}
+	return value, nil
+}
+
+// ttl gets the record time to live from the 'options'. Non positive values means no expiration.
+func (r *Redis) ttl(options []store.SetOption) time.Duration {
+	o := &store.SetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	ttl := r.Options.DefaultExpiration
+	if o.TTL != 0 {
+		ttl = o.TTL
+	}
+	if ttl < 0 {
+		return 0
+	}
+	return ttl
+}
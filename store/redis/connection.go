@@ -0,0 +1,125 @@
+package redis
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+const (
+	sentinelScheme = "redis-sentinel"
+	clusterScheme  = "redis-cluster"
+)
+
+// HashTag prepends the redis cluster hash tag to provided 'key'. The keys with the same 'tag' are stored in the same
+// cluster hash slot, which allows to use them together within the scripts and transactions. The store prefix
+// must not contain the '{' character, as only the first hash tag within the key is used by the redis cluster.
+func HashTag(tag, key string) string {
+	return xstore.HashTag(tag, key)
+}
+
+// parseConnectionURL parses the connection url and returns the function that creates related redis client.
+func parseConnectionURL(connectionURL string) (func() redis.UniversalClient, error) {
+	i := strings.Index(connectionURL, "://")
+	if i == -1 {
+		return nil, fmt.Errorf("no scheme defined")
+	}
+	switch scheme := connectionURL[:i]; scheme {
+	case sentinelScheme, clusterScheme:
+		u, err := parseMultiHostURL(connectionURL[i+3:])
+		if err != nil {
+			return nil, err
+		}
+		if scheme == clusterScheme {
+			if u.db != 0 {
+				return nil, fmt.Errorf("redis cluster doesn't support database selection")
+			}
+			clusterOptions := &redis.ClusterOptions{Addrs: u.addrs, Username: u.username, Password: u.password}
+			return func() redis.UniversalClient { return redis.NewClusterClient(clusterOptions) }, nil
+		}
+		masterName := u.query.Get("master")
+		if masterName == "" {
+			return nil, fmt.Errorf("no 'master' query parameter defined for the sentinel")
+		}
+		failoverOptions := &redis.FailoverOptions{
+			MasterName:       masterName,
+			SentinelAddrs:    u.addrs,
+			SentinelPassword: u.query.Get("sentinel_password"),
+			Username:         u.username,
+			Password:         u.password,
+			DB:               u.db,
+		}
+		return func() redis.UniversalClient { return redis.NewFailoverClient(failoverOptions) }, nil
+	default:
+		redisOptions, err := redis.ParseURL(connectionURL)
+		if err != nil {
+			return nil, err
+		}
+		return func() redis.UniversalClient { return redis.NewClient(redisOptions) }, nil
+	}
+}
+
+type multiHostURL struct {
+	username, password string
+	addrs              []string
+	db                 int
+	query              url.Values
+}
+
+// parseMultiHostURL parses the url without the scheme in the form of: '[[user]:password@]host1:port1,host2:port2[/db][?query]'.
+func parseMultiHostURL(s string) (*multiHostURL, error) {
+	u := &multiHostURL{}
+	if i := strings.IndexByte(s, '?'); i != -1 {
+		query, err := url.ParseQuery(s[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		u.query = query
+		s = s[:i]
+	}
+	if i := strings.LastIndexByte(s, '@'); i != -1 {
+		userInfo := s[:i]
+		s = s[i+1:]
+		username, password := userInfo, ""
+		if j := strings.IndexByte(userInfo, ':'); j != -1 {
+			username, password = userInfo[:j], userInfo[j+1:]
+		}
+		var err error
+		if u.username, err = url.PathUnescape(username); err != nil {
+			return nil, err
+		}
+		if u.password, err = url.PathUnescape(password); err != nil {
+			return nil, err
+		}
+	}
+	if i := strings.IndexByte(s, '/'); i != -1 {
+		if db := strings.Trim(s[i+1:], "/"); db != "" {
+			var err error
+			if u.db, err = strconv.Atoi(db); err != nil {
+				return nil, fmt.Errorf("invalid redis database number: %q", db)
+			}
+		}
+		s = s[:i]
+	}
+	for _, addr := range strings.Split(s, ",") {
+		if addr = strings.TrimSpace(addr); addr == "" {
+			continue
+		}
+		if !strings.Contains(addr, ":") {
+			addr += ":6379"
+		}
+		u.addrs = append(u.addrs, addr)
+	}
+	if len(u.addrs) == 0 {
+		return nil, fmt.Errorf("no hosts defined")
+	}
+	if u.query == nil {
+		u.query = url.Values{}
+	}
+	return u, nil
+}
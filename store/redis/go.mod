@@ -5,4 +5,8 @@ go 1.13
 require (
 	github.com/go-redis/redis/v8 v8.0.0-beta.7
 	github.com/neuronlabs/neuron v0.21.6
+	github.com/neuronlabs/neuron-extensions/store/xstore v0.0.0
+	github.com/stretchr/testify v1.6.1
 )
+
+replace github.com/neuronlabs/neuron-extensions/store/xstore => ../xstore
@@ -0,0 +1,214 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if redis implements xstore.IterableStore interface.
+var _ xstore.IterableStore = &Redis{}
+
+// ScanCount is the default number of keys requested by a single SCAN command.
+const ScanCount = 500
+
+// Iterate implements xstore.IterableStore interface. The keys are found using SCAN command, so that the redis
+// server is not blocked even for the large keyspaces. The values are fetched in the pipelines for each scanned batch.
+// As the SCAN command guarantees, the records stored during whole iteration are returned, however a record
+// might be returned more than once. The records are not sorted. For the redis cluster all the master nodes
+// are scanned one after another.
+func (r *Redis) Iterate(ctx context.Context, options ...store.FindOption) xstore.Iterator {
+	o := &store.FindPattern{}
+	for _, option := range options {
+		option(o)
+	}
+	it := &scanIterator{
+		r:       r,
+		pattern: r.findPattern(o),
+		offset:  o.Offset,
+		limit:   o.Limit,
+	}
+	if it.err = r.checkInitialization(); it.err != nil {
+		return it
+	}
+	it.scanners, it.err = r.scanners(ctx)
+	return it
+}
+
+// scanners gets the clients that should be scanned for the keys. For the cluster these are all its master nodes.
+func (r *Redis) scanners(ctx context.Context) ([]redis.Cmdable, error) {
+	cluster, ok := r.r.(*redis.ClusterClient)
+	if !ok {
+		return []redis.Cmdable{r.r}, nil
+	}
+	var (
+		mu      sync.Mutex
+		masters []redis.Cmdable
+	)
+	err := cluster.ForEachMaster(ctx, func(_ context.Context, client *redis.Client) error {
+		mu.Lock()
+		defer mu.Unlock()
+		masters = append(masters, client)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(store.ErrStore, err.Error())
+	}
+	return masters, nil
+}
+
+// scanIterator is the SCAN based xstore.Iterator implementation.
+type scanIterator struct {
+	r        *Redis
+	pattern  string
+	scanners []redis.Cmdable
+	cursor   uint64
+
+	offset, limit, count int
+
+	batch   []*store.Record
+	current *store.Record
+	err     error
+	closed  bool
+}
+
+// Next implements xstore.Iterator interface.
+func (it *scanIterator) Next(ctx context.Context) bool {
+	for {
+		if it.err != nil || it.closed {
+			return false
+		}
+		if it.limit > 0 && it.count >= it.limit {
+			return false
+		}
+		if len(it.batch) > 0 {
+			it.current, it.batch = it.batch[0], it.batch[1:]
+			if it.offset > 0 {
+				it.offset--
+				continue
+			}
+			it.count++
+			return true
+		}
+		if len(it.scanners) == 0 {
+			it.current = nil
+			return false
+		}
+		it.fetch(ctx)
+	}
+}
+
+// Record implements xstore.Iterator interface.
+func (it *scanIterator) Record() *store.Record {
+	return it.current
+}
+
+// Err implements xstore.Iterator interface.
+func (it *scanIterator) Err() error {
+	return it.err
+}
+
+// Close implements xstore.Iterator interface.
+func (it *scanIterator) Close() error {
+	it.closed = true
+	it.batch, it.current = nil, nil
+	return nil
+}
+
+// fetch scans next batch of the keys and gets its records.
+func (it *scanIterator) fetch(ctx context.Context) {
+	keys, cursor, err := it.scanners[0].Scan(ctx, it.cursor, it.pattern, ScanCount).Result()
+	if err != nil {
+		it.err = errors.Wrap(store.ErrStore, err.Error())
+		return
+	}
+	it.cursor = cursor
+	if cursor == 0 {
+		// Current scanner is done.
+		it.scanners = it.scanners[1:]
+	}
+	if len(keys) == 0 {
+		return
+	}
+	it.batch, it.err = it.r.getRecords(ctx, keys)
+}
+
+// findPattern gets the SCAN match pattern for provided find options.
+func (r *Redis) findPattern(o *store.FindPattern) string {
+	return escapePattern(r.Options.Prefix+o.Prefix) + "*" + escapePattern(o.Suffix+r.Options.Suffix)
+}
+
+// getRecords gets the records for provided redis 'keys' within a single pipeline.
+// The keys that expired or were deleted in the meantime are omitted.
+func (r *Redis) getRecords(ctx context.Context, keys []string) ([]*store.Record, error) {
+	pipe := r.r.Pipeline()
+	values := make([]*redis.StringCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		values[i] = pipe.Get(ctx, key)
+		ttls[i] = pipe.PTTL(ctx, key)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, errors.Wrap(store.ErrStore, err.Error())
+	}
+	now := time.Now()
+	records := make([]*store.Record, 0, len(keys))
+	for i, key := range keys {
+		value, err := values[i].Bytes()
+		if err == redis.Nil {
+			continue
+		} else if err != nil {
+			return nil, errors.Wrap(store.ErrStore, err.Error())
+		}
+		records = append(records, &store.Record{
+			Key:       r.trimKey(key),
+			Value:     value,
+			ExpiresAt: expiresAt(now, ttls[i].Val()),
+		})
+	}
+	return records, nil
+}
+
+// trimKey removes the store prefix and suffix from the redis 'key'.
+func (r *Redis) trimKey(key string) string {
+	if r.Options.Prefix != "" {
+		key = strings.TrimPrefix(key, r.Options.Prefix)
+	}
+	if r.Options.Suffix != "" {
+		key = strings.TrimSuffix(key, r.Options.Suffix)
+	}
+	return key
+}
+
+// expiresAt gets the record expiration time for the 'ttl' returned by redis. The keys without expiration
+// returns zero time.
+func expiresAt(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+// escapePattern escapes the glob-style pattern special characters.
+func escapePattern(s string) string {
+	if !strings.ContainsAny(s, `*?[]\`) {
+		return s
+	}
+	sb := strings.Builder{}
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
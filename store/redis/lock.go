@@ -0,0 +1,235 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if Locker implements xstore.Locker interface.
+var _ xstore.Locker = &Locker{}
+
+// DefaultDriftFactor is the default clock drift factor used by the Redlock.
+const DefaultDriftFactor = 0.01
+
+var (
+	acquireLockScript   = redis.NewScript(acquireLockSource)
+	refreshLockScript   = redis.NewScript(refreshLockSource)
+	releaseLockScript   = redis.NewScript(releaseLockSource)
+	syncLockFenceScript = redis.NewScript(syncLockFenceSource)
+)
+
+// acquireLockSource sets the lock token if the lock is not held and increments the lock fencing counter.
+// Returns the fence value or 0 if the lock is held.
+const acquireLockSource = `
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return redis.call('INCR', KEYS[2])
+end
+return 0
+`
+
+// refreshLockSource extends the lock expiration if it is held with given token.
+const refreshLockSource = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+return 0
+`
+
+// releaseLockSource deletes the lock if it is held with given token.
+const releaseLockSource = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+
+// syncLockFenceSource raises the lock fencing counter to given value if the lock is held with given token.
+const syncLockFenceSource = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	local fence = tonumber(redis.call('GET', KEYS[2]) or '0')
+	if fence < tonumber(ARGV[2]) then
+		redis.call('SET', KEYS[2], ARGV[2])
+	end
+	return 1
+end
+return 0
+`
+
+// Locker is the redis xstore.Locker implementation. Created with the NewLocker function it uses a single redis
+// store - which might be the sentinel or the cluster client. Created with the NewRedlock function it implements
+// the Redlock algorithm - the lock is acquired on the majority of the independent redis instances.
+// The lock and its fencing counter keys share the hash tag, so that they are stored in the same cluster slot.
+type Locker struct {
+	instances []*Redis
+	// DriftFactor is the clock drift factor used for computing the Redlock validity time.
+	DriftFactor float64
+}
+
+// NewLocker creates new locker that uses a single redis store.
+func NewLocker(r *Redis) *Locker {
+	return &Locker{instances: []*Redis{r}}
+}
+
+// NewRedlock creates new Redlock locker for provided independent redis 'instances'. The number of the instances
+// should be odd - usually five. The fence of the lock is synchronized between the instances on each acquisition,
+// so that it increases as long as the instances doesn't lose their data.
+func NewRedlock(instances ...*Redis) (*Locker, error) {
+	if len(instances) == 0 {
+		return nil, errors.Wrap(store.ErrInitialization, "no redis instances provided for the redlock")
+	}
+	return &Locker{instances: instances, DriftFactor: DefaultDriftFactor}, nil
+}
+
+func (l *Locker) quorum() int {
+	return len(l.instances)/2 + 1
+}
+
+// Acquire implements xstore.Locker interface.
+func (l *Locker) Acquire(ctx context.Context, name string, ttl time.Duration) (xstore.Lock, error) {
+	for _, r := range l.instances {
+		if err := r.checkInitialization(); err != nil {
+			return nil, err
+		}
+	}
+	token, err := xstore.NewLockToken()
+	if err != nil {
+		return nil, err
+	}
+	lk := &lock{l: l, name: name, token: token}
+
+	start := time.Now()
+	var (
+		acquired []*Redis
+		held     int
+		firstErr error
+	)
+	for _, r := range l.instances {
+		lockKey, fenceKey := r.lockKeys(name)
+		fence, err := acquireLockScript.Run(ctx, r.r, []string{lockKey, fenceKey}, token, ttl.Milliseconds()).Int64()
+		switch {
+		case err != nil:
+			log.Debugf("Acquiring lock: '%s' failed: %v", name, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		case fence == 0:
+			held++
+		default:
+			acquired = append(acquired, r)
+			if fence > lk.fence {
+				lk.fence = fence
+			}
+		}
+	}
+	drift := time.Duration(float64(ttl)*l.DriftFactor) + 2*time.Millisecond
+	if len(l.instances) == 1 {
+		drift = 0
+	}
+	if len(acquired) >= l.quorum() && ttl-time.Since(start)-drift > 0 {
+		if len(l.instances) == 1 {
+			return lk, nil
+		}
+		synced := 0
+		for _, r := range acquired {
+			lockKey, fenceKey := r.lockKeys(name)
+			ok, err := syncLockFenceScript.Run(ctx, r.r, []string{lockKey, fenceKey}, token, lk.fence).Int()
+			if err != nil {
+				log.Debugf("Synchronizing lock: '%s' fence failed: %v", name, err)
+				continue
+			}
+			synced += ok
+		}
+		if synced >= l.quorum() {
+			return lk, nil
+		}
+	}
+	// Release the lock on all the instances, as some of them might have it set, but failed to reply.
+	lk.release(context.Background())
+	if held == 0 && firstErr != nil {
+		return nil, errors.Wrapf(store.ErrStore, "acquiring lock: '%s' failed: %v", name, firstErr)
+	}
+	return nil, errors.Wrapf(xstore.ErrLockHeld, "lock: '%s'", name)
+}
+
+type lock struct {
+	l     *Locker
+	name  string
+	token string
+	fence int64
+}
+
+// Name implements xstore.Lock interface.
+func (l *lock) Name() string {
+	return l.name
+}
+
+// Token implements xstore.Lock interface.
+func (l *lock) Token() string {
+	return l.token
+}
+
+// Fence implements xstore.Lock interface.
+func (l *lock) Fence() int64 {
+	return l.fence
+}
+
+// Refresh implements xstore.Lock interface.
+func (l *lock) Refresh(ctx context.Context, ttl time.Duration) error {
+	return l.run(ctx, refreshLockScript, ttl.Milliseconds())
+}
+
+// Release implements xstore.Lock interface.
+func (l *lock) Release(ctx context.Context) error {
+	return l.run(ctx, releaseLockScript)
+}
+
+// run runs the lock 'script' on all the instances. Returns an error if the script didn't succeed
+// on the quorum of the instances.
+func (l *lock) run(ctx context.Context, script *redis.Script, args ...interface{}) error {
+	var (
+		succeeded int
+		firstErr  error
+	)
+	for _, r := range l.l.instances {
+		lockKey, _ := r.lockKeys(l.name)
+		result, err := script.Run(ctx, r.r, []string{lockKey}, append([]interface{}{l.token}, args...)...).Int()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if result == 1 {
+			succeeded++
+		}
+	}
+	if succeeded >= l.l.quorum() {
+		return nil
+	}
+	if firstErr != nil && len(l.l.instances) == 1 {
+		return errors.Wrapf(store.ErrStore, "lock: '%s' failed: %v", l.name, firstErr)
+	}
+	return errors.Wrapf(xstore.ErrLockNotHeld, "lock: '%s'", l.name)
+}
+
+// release releases the lock on all the instances ignoring the errors.
+func (l *lock) release(ctx context.Context) {
+	for _, r := range l.l.instances {
+		lockKey, _ := r.lockKeys(l.name)
+		_ = releaseLockScript.Run(ctx, r.r, []string{lockKey}, l.token).Err()
+	}
+}
+
+// lockKeys gets the keys of the lock with given 'name' and its fencing counter.
+func (r *Redis) lockKeys(name string) (lockKey, fenceKey string) {
+	tag := "nrn_lock:" + name
+	return r.getKey(HashTag(tag, "")), r.getKey(HashTag(tag, ":fence"))
+}
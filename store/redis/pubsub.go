@@ -0,0 +1,167 @@
+package redis
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if PubSub implements xstore.PubSub interface.
+var _ xstore.PubSub = &PubSub{}
+
+// PubSub is the redis PUBLISH/SUBSCRIBE based xstore.PubSub implementation. The messages are delivered only to
+// currently connected subscribers and are not persisted. The consumer groups are not supported - the Streams should
+// be used for the reliable delivery. The topics are prefixed and suffixed the same as the store keys.
+type PubSub struct {
+	r *Redis
+}
+
+// NewPubSub creates new redis pub/sub that uses the connection of provided redis store.
+func NewPubSub(r *Redis) *PubSub {
+	return &PubSub{r: r}
+}
+
+// Publish implements xstore.Publisher interface.
+func (p *PubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := p.r.checkInitialization(); err != nil {
+		return err
+	}
+	if err := p.r.r.Publish(ctx, p.r.getKey(topic), payload).Err(); err != nil {
+		return errors.Wrap(store.ErrStore, err.Error())
+	}
+	return nil
+}
+
+// Subscribe implements xstore.Subscriber interface.
+func (p *PubSub) Subscribe(ctx context.Context, topic string, options ...xstore.SubscribeOption) (xstore.Subscription, error) {
+	o := xstore.NewSubscribeOptions(options...)
+	if o.Group != "" {
+		return nil, errors.Wrap(xstore.ErrGroupsNotSupported, "redis pub/sub doesn't support consumer groups - use the redis streams")
+	}
+	if err := p.r.checkInitialization(); err != nil {
+		return nil, err
+	}
+	ps := p.r.r.Subscribe(ctx, p.r.getKey(topic))
+	// Wait for the subscription confirmation.
+	if _, err := ps.Receive(ctx); err != nil {
+		_ = ps.Close()
+		return nil, errors.Wrap(store.ErrStore, err.Error())
+	}
+	s := &pubSubSubscription{
+		ps:     ps,
+		topic:  topic,
+		out:    make(chan *xstore.Message, o.BufferSize),
+		done:   make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+// pubSubHealthCheckInterval is the interval after which the idle subscription connection is checked with the ping.
+// If no reply is received within the next interval the connection is considered lost.
+const pubSubHealthCheckInterval = 30 * time.Second
+
+// pubSubSubscription is the redis pub/sub subscription. The redis client reconnects the lost pub/sub connection
+// silently, dropping all the messages published in the meantime. Thus, instead, the subscription fails on the first
+// connection error and exposes it with the Err method, so that its consumers could recover the missed state.
+type pubSubSubscription struct {
+	ps        *redis.PubSub
+	topic     string
+	out       chan *xstore.Message
+	done      chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+	err       error
+	errMu     sync.Mutex
+}
+
+// Messages implements xstore.Subscription interface.
+func (s *pubSubSubscription) Messages() <-chan *xstore.Message {
+	return s.out
+}
+
+// Err implements xstore.Subscription interface. It returns the error of the lost subscription connection.
+func (s *pubSubSubscription) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	return s.err
+}
+
+// Close implements xstore.Subscription interface.
+func (s *pubSubSubscription) Close() error {
+	err := errors.Wrap(xstore.ErrSubscriptionClosed, "subscription already closed")
+	s.closeOnce.Do(func() {
+		close(s.done)
+		err = s.ps.Close()
+		<-s.closed
+	})
+	return err
+}
+
+func (s *pubSubSubscription) isClosed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *pubSubSubscription) run() {
+	defer close(s.closed)
+	defer close(s.out)
+
+	ctx := context.Background()
+	var pinged bool
+	for {
+		received, err := s.ps.ReceiveTimeout(ctx, pubSubHealthCheckInterval)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() && !pinged && !s.isClosed() {
+				// Check if the idle connection is still alive.
+				pinged = true
+				if err = s.ps.Ping(ctx); err == nil {
+					continue
+				}
+			}
+			s.fail(err)
+			return
+		}
+		pinged = false
+		msg, ok := received.(*redis.Message)
+		if !ok {
+			// Ignore the subscription confirmations and pongs.
+			continue
+		}
+		message := &xstore.Message{
+			Topic:       s.topic,
+			Payload:     []byte(msg.Payload),
+			PublishedAt: time.Now(),
+			Deliveries:  1,
+		}
+		select {
+		case s.out <- message:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *pubSubSubscription) fail(err error) {
+	if s.isClosed() {
+		return
+	}
+	log.Errorf("Redis pub/sub: '%s' subscription connection lost: %v", s.topic, err)
+	s.errMu.Lock()
+	s.err = errors.Wrap(store.ErrStore, err.Error())
+	s.errMu.Unlock()
+}
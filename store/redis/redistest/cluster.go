@@ -0,0 +1,120 @@
+package redistest
+
+import (
+	"net"
+	"strconv"
+	"strings"
+)
+
+// SlotsNumber is the number of the redis cluster hash slots.
+const SlotsNumber = 16384
+
+// Cluster is the fake redis cluster. The hash slots are split evenly between its master nodes.
+// The nodes redirects the commands for the keys from other nodes slots with the MOVED error.
+type Cluster struct {
+	Nodes []*Server
+}
+
+// NewCluster creates and starts new fake redis cluster with 'nodes' master nodes.
+func NewCluster(nodes int) (*Cluster, error) {
+	c := &Cluster{}
+	size := SlotsNumber / nodes
+	for i := 0; i < nodes; i++ {
+		s, err := NewServer()
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		s.cluster = c
+		s.slotFrom, s.slotTo = i*size, (i+1)*size-1
+		if i == nodes-1 {
+			s.slotTo = SlotsNumber - 1
+		}
+		c.Nodes = append(c.Nodes, s)
+	}
+	return c, nil
+}
+
+// Addrs gets the addresses of the cluster nodes.
+func (c *Cluster) Addrs() []string {
+	addrs := make([]string, len(c.Nodes))
+	for i, node := range c.Nodes {
+		addrs[i] = node.Addr()
+	}
+	return addrs
+}
+
+// URL gets the cluster connection url.
+func (c *Cluster) URL() string {
+	return "redis-cluster://" + strings.Join(c.Addrs(), ",")
+}
+
+// RegisterScript registers the Go implementation 'fn' of the lua 'script' on all the cluster nodes.
+func (c *Cluster) RegisterScript(script string, fn ScriptFunc) {
+	for _, node := range c.Nodes {
+		node.RegisterScript(script, fn)
+	}
+}
+
+// NodeForSlot gets the node serving the hash 'slot'.
+func (c *Cluster) NodeForSlot(slot int) *Server {
+	for _, node := range c.Nodes {
+		if slot >= node.slotFrom && slot <= node.slotTo {
+			return node
+		}
+	}
+	return nil
+}
+
+// NodeForKey gets the node that stores provided 'key'.
+func (c *Cluster) NodeForKey(key string) *Server {
+	return c.NodeForSlot(Slot(key))
+}
+
+// Close stops all the cluster nodes.
+func (c *Cluster) Close() error {
+	var err error
+	for _, node := range c.Nodes {
+		if closeErr := node.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (c *Cluster) slotsReply() interface{} {
+	reply := make([]interface{}, len(c.Nodes))
+	for i, node := range c.Nodes {
+		host, port, _ := net.SplitHostPort(node.Addr())
+		portNumber, _ := strconv.Atoi(port)
+		reply[i] = []interface{}{node.slotFrom, node.slotTo, []interface{}{host, portNumber, "node-" + strconv.Itoa(i)}}
+	}
+	return reply
+}
+
+// Slot gets the redis cluster hash slot for provided 'key'. If the key contains a hash tag - non empty substring
+// between the first '{' and following '}' - only the tag is hashed.
+func Slot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key) % SlotsNumber)
+}
+
+// crc16 computes the CRC16-CCITT (XMODEM) checksum used by the redis cluster.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
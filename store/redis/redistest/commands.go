@@ -0,0 +1,354 @@
+package redistest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commandInfo is the command definition returned by the COMMAND command. It is used by the cluster clients
+// to find the command keys.
+type commandInfo struct {
+	arity    int
+	readOnly bool
+	firstKey int
+	lastKey  int
+}
+
+var commands = map[string]commandInfo{
+	"get":        {arity: 2, readOnly: true, firstKey: 1, lastKey: 1},
+	"mget":       {arity: -2, readOnly: true, firstKey: 1, lastKey: -1},
+	"set":        {arity: -3, firstKey: 1, lastKey: 1},
+	"setnx":      {arity: 3, firstKey: 1, lastKey: 1},
+	"del":        {arity: -2, firstKey: 1, lastKey: -1},
+	"exists":     {arity: -2, readOnly: true, firstKey: 1, lastKey: -1},
+	"ttl":        {arity: 2, readOnly: true, firstKey: 1, lastKey: 1},
+	"pttl":       {arity: 2, readOnly: true, firstKey: 1, lastKey: 1},
+	"expire":     {arity: 3, firstKey: 1, lastKey: 1},
+	"pexpire":    {arity: 3, firstKey: 1, lastKey: 1},
+	"incr":       {arity: 2, firstKey: 1, lastKey: 1},
+	"incrby":     {arity: 3, firstKey: 1, lastKey: 1},
+	"decrby":     {arity: 3, firstKey: 1, lastKey: 1},
+	"scan":       {arity: -2, readOnly: true},
+	"eval":       {arity: -3},
+	"evalsha":    {arity: -3},
+	"script":     {arity: -2},
+	"publish":    {arity: 3},
+	"ping":       {arity: -1},
+	"flushdb":    {arity: -1},
+	"dbsize":     {arity: 1, readOnly: true},
+	"command":    {arity: -1},
+	"cluster":    {arity: -2},
+	"sentinel":   {arity: -2},
+	"xadd":       {arity: -5, firstKey: 1, lastKey: 1},
+	"xrange":     {arity: -4, readOnly: true, firstKey: 1, lastKey: 1},
+	"xrevrange":  {arity: -4, readOnly: true, firstKey: 1, lastKey: 1},
+	"xread":      {arity: -4, readOnly: true},
+	"xreadgroup": {arity: -7},
+	"xgroup":     {arity: -2, firstKey: 2, lastKey: 2},
+	"xack":       {arity: -4, firstKey: 1, lastKey: 1},
+	"xpending":   {arity: -3, readOnly: true, firstKey: 1, lastKey: 1},
+	"xclaim":     {arity: -6, firstKey: 1, lastKey: 1},
+}
+
+func (s *Server) execute(name string, args []string) interface{} {
+	info, ok := commands[name]
+	switch name {
+	case "auth", "select", "client", "readonly", "readwrite":
+		return okReply
+	}
+	if !ok {
+		return errorReply("ERR unknown command '" + name + "'")
+	}
+	if (info.arity > 0 && len(args)+1 != info.arity) || (info.arity < 0 && len(args)+1 < -info.arity) {
+		return errWrongArgs(name)
+	}
+	if s.sentinel != nil {
+		switch name {
+		case "ping", "command", "sentinel", "publish":
+		default:
+			return errorReply("ERR unknown command '" + name + "' for the sentinel")
+		}
+	}
+	if key, ok := commandKey(name, info, args); ok && s.cluster != nil {
+		if slot := Slot(key); slot < s.slotFrom || slot > s.slotTo {
+			return errorReply("MOVED " + strconv.Itoa(slot) + " " + s.cluster.NodeForSlot(slot).Addr())
+		}
+	}
+
+	db := s.db
+	switch name {
+	case "ping":
+		if len(args) > 0 {
+			return args[0]
+		}
+		return statusReply("PONG")
+	case "get":
+		value, ok := db.Get(args[0])
+		if !ok {
+			return nil
+		}
+		return value
+	case "mget":
+		values := make([]interface{}, len(args))
+		for i, key := range args {
+			if value, ok := db.Get(key); ok {
+				values[i] = value
+			}
+		}
+		return values
+	case "set":
+		return s.set(args)
+	case "setnx":
+		db.mu.Lock()
+		defer db.mu.Unlock()
+		if _, ok := db.get(args[0]); ok {
+			return false
+		}
+		db.set(args[0], []byte(args[1]), 0)
+		return true
+	case "del":
+		var count int
+		for _, key := range args {
+			if db.Delete(key) {
+				count++
+			}
+		}
+		return count
+	case "exists":
+		var count int
+		for _, key := range args {
+			if db.Exists(key) {
+				count++
+			}
+		}
+		return count
+	case "ttl", "pttl":
+		ttl := db.TTL(args[0])
+		if ttl < 0 {
+			return int64(ttl)
+		}
+		if name == "ttl" {
+			return int64(ttl.Round(time.Second) / time.Second)
+		}
+		return int64(ttl.Round(time.Millisecond) / time.Millisecond)
+	case "expire", "pexpire":
+		n, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return errNotInteger
+		}
+		unit := time.Millisecond
+		if name == "expire" {
+			unit = time.Second
+		}
+		return db.Expire(args[0], time.Duration(n)*unit)
+	case "incr", "incrby", "decrby":
+		delta := int64(1)
+		if len(args) > 1 {
+			var err error
+			if delta, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+				return errNotInteger
+			}
+		}
+		if name == "decrby" {
+			delta = -delta
+		}
+		value, err := db.IncrBy(args[0], delta)
+		if err != nil {
+			return err
+		}
+		return value
+	case "scan":
+		return s.scan(args)
+	case "eval", "evalsha":
+		return s.eval(name, args)
+	case "script":
+		if strings.ToLower(args[0]) == "load" && len(args) == 2 {
+			return scriptSHA(args[1])
+		}
+		return errSyntax
+	case "publish":
+		return s.publish(args[0], args[1])
+	case "flushdb":
+		db.Flush()
+		return okReply
+	case "dbsize":
+		return len(db.Keys())
+	case "command":
+		return commandsReply()
+	case "xadd":
+		return s.xadd(args)
+	case "xrange", "xrevrange":
+		return s.xrange(name == "xrevrange", args)
+	case "xread", "xreadgroup":
+		return s.xread(name == "xreadgroup", args)
+	case "xgroup":
+		return s.xgroup(args)
+	case "xack":
+		return s.xack(args)
+	case "xpending":
+		return s.xpending(args)
+	case "xclaim":
+		return s.xclaim(args)
+	case "cluster":
+		if s.cluster == nil {
+			return errorReply("ERR This instance has cluster support disabled")
+		}
+		if strings.ToLower(args[0]) == "slots" {
+			return s.cluster.slotsReply()
+		}
+		return errSyntax
+	case "sentinel":
+		if s.sentinel == nil {
+			return errorReply("ERR unknown command 'sentinel'")
+		}
+		return s.sentinel.execute(args)
+	}
+	return errSyntax
+}
+
+func (s *Server) set(args []string) interface{} {
+	key, value := args[0], args[1]
+	var (
+		ttl          time.Duration
+		nx, xx, keep bool
+	)
+	for i := 2; i < len(args); i++ {
+		switch strings.ToLower(args[i]) {
+		case "nx":
+			nx = true
+		case "xx":
+			xx = true
+		case "keepttl":
+			keep = true
+		case "ex", "px":
+			if i+1 == len(args) {
+				return errSyntax
+			}
+			n, err := strconv.ParseInt(args[i+1], 10, 64)
+			if err != nil || n <= 0 {
+				return errorReply("ERR invalid expire time in set")
+			}
+			unit := time.Millisecond
+			if strings.ToLower(args[i]) == "ex" {
+				unit = time.Second
+			}
+			ttl = time.Duration(n) * unit
+			i++
+		default:
+			return errSyntax
+		}
+	}
+	db := s.db
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	current, exists := db.get(key)
+	if (nx && exists) || (xx && !exists) {
+		return nil
+	}
+	if keep && exists && !current.expiresAt.IsZero() {
+		ttl = time.Until(current.expiresAt)
+	}
+	db.set(key, []byte(value), ttl)
+	return okReply
+}
+
+// scan implements the SCAN command. The cursor is the position within the sorted database keys.
+func (s *Server) scan(args []string) interface{} {
+	cursor, err := strconv.Atoi(args[0])
+	if err != nil || cursor < 0 {
+		return errorReply("ERR invalid cursor")
+	}
+	count, pattern := 10, "*"
+	for i := 1; i+1 < len(args); i += 2 {
+		switch strings.ToLower(args[i]) {
+		case "match":
+			pattern = args[i+1]
+		case "count":
+			if count, err = strconv.Atoi(args[i+1]); err != nil || count <= 0 {
+				return errSyntax
+			}
+		default:
+			return errSyntax
+		}
+	}
+	keys := s.db.Keys()
+	end := cursor + count
+	next := strconv.Itoa(end)
+	if end >= len(keys) {
+		end, next = len(keys), "0"
+	}
+	matched := []string{}
+	for i := cursor; i < end; i++ {
+		if matchPattern(pattern, keys[i]) {
+			matched = append(matched, keys[i])
+		}
+	}
+	return []interface{}{next, matched}
+}
+
+func (s *Server) eval(name string, args []string) interface{} {
+	sha := args[0]
+	if name == "eval" {
+		sha = scriptSHA(args[0])
+	}
+	s.mu.Lock()
+	fn, ok := s.scripts[sha]
+	s.mu.Unlock()
+	if !ok {
+		return errorReply("NOSCRIPT No matching script. Please use EVAL.")
+	}
+	numKeys, err := strconv.Atoi(args[1])
+	if err != nil || numKeys < 0 || numKeys > len(args)-2 {
+		return errorReply("ERR Number of keys can't be greater than number of args")
+	}
+	var reply interface{}
+	s.db.atomically(func(view *DB) {
+		reply, err = fn(view, args[2:2+numKeys], args[2+numKeys:])
+	})
+	if err != nil {
+		if e, ok := err.(errorReply); ok {
+			return e
+		}
+		return errorReply("ERR " + err.Error())
+	}
+	return reply
+}
+
+// commandKey gets the first key of the command.
+func commandKey(name string, info commandInfo, args []string) (string, bool) {
+	switch name {
+	case "eval", "evalsha":
+		if len(args) > 2 && args[1] != "0" {
+			return args[2], true
+		}
+		return "", false
+	case "xread", "xreadgroup":
+		for i, arg := range args {
+			if strings.ToLower(arg) == "streams" && i+1 < len(args) {
+				return args[i+1], true
+			}
+		}
+		return "", false
+	}
+	if info.firstKey == 0 || len(args) < info.firstKey {
+		return "", false
+	}
+	return args[info.firstKey-1], true
+}
+
+func commandsReply() interface{} {
+	reply := make([]interface{}, 0, len(commands))
+	for name, info := range commands {
+		flag := statusReply("write")
+		if info.readOnly {
+			flag = "readonly"
+		}
+		step := 0
+		if info.firstKey != 0 {
+			step = 1
+		}
+		reply = append(reply, []interface{}{name, info.arity, []interface{}{flag}, info.firstKey, info.lastKey, step})
+	}
+	return reply
+}
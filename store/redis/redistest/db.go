@@ -0,0 +1,188 @@
+package redistest
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DB is the fake redis key-value database.
+type DB struct {
+	mu      sync.Mutex
+	entries map[string]*entry
+	// streams are stored separately and are not returned by the Keys method.
+	streams map[string]*stream
+	// script is set for the database view used by the scripts, which are executed under the database lock.
+	script bool
+}
+
+type entry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e *entry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+func newDB() *DB {
+	return &DB{entries: map[string]*entry{}, streams: map[string]*stream{}}
+}
+
+// Get gets the value stored with the 'key'.
+func (d *DB) Get(key string) ([]byte, bool) {
+	d.lock()
+	defer d.unlock()
+
+	e, ok := d.get(key)
+	if !ok {
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set stores the 'value' with the 'key'. Non positive 'ttl' means no expiration.
+func (d *DB) Set(key string, value []byte, ttl time.Duration) {
+	d.lock()
+	defer d.unlock()
+
+	d.set(key, value, ttl)
+}
+
+// Exists checks if the 'key' exists.
+func (d *DB) Exists(key string) bool {
+	d.lock()
+	defer d.unlock()
+
+	if _, ok := d.streams[key]; ok {
+		return true
+	}
+	_, ok := d.get(key)
+	return ok
+}
+
+// Delete deletes the 'key'. Returns true if the key existed.
+func (d *DB) Delete(key string) bool {
+	d.lock()
+	defer d.unlock()
+
+	_, ok := d.get(key)
+	_, isStream := d.streams[key]
+	delete(d.entries, key)
+	delete(d.streams, key)
+	return ok || isStream
+}
+
+// TTL gets the time to live of the 'key'. Returns -2 if the key doesn't exists and -1 if it has no expiration.
+func (d *DB) TTL(key string) time.Duration {
+	d.lock()
+	defer d.unlock()
+
+	e, ok := d.get(key)
+	if !ok {
+		return -2
+	}
+	if e.expiresAt.IsZero() {
+		return -1
+	}
+	return time.Until(e.expiresAt)
+}
+
+// Expire sets the time to live of the 'key'. Returns false if the key doesn't exists.
+func (d *DB) Expire(key string, ttl time.Duration) bool {
+	d.lock()
+	defer d.unlock()
+
+	e, ok := d.get(key)
+	if !ok {
+		return false
+	}
+	e.expiresAt = time.Now().Add(ttl)
+	return true
+}
+
+// IncrBy increments the integer value of the 'key' by the 'delta'.
+func (d *DB) IncrBy(key string, delta int64) (int64, error) {
+	d.lock()
+	defer d.unlock()
+
+	e, ok := d.get(key)
+	if !ok {
+		d.set(key, []byte(strconv.FormatInt(delta, 10)), 0)
+		return delta, nil
+	}
+	value, err := strconv.ParseInt(string(e.value), 10, 64)
+	if err != nil {
+		return 0, errNotInteger
+	}
+	value += delta
+	e.value = []byte(strconv.FormatInt(value, 10))
+	return value, nil
+}
+
+// Keys gets the sorted keys of the database.
+func (d *DB) Keys() []string {
+	d.lock()
+	defer d.unlock()
+
+	now := time.Now()
+	keys := make([]string, 0, len(d.entries))
+	for key, e := range d.entries {
+		if e.expired(now) {
+			delete(d.entries, key)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Flush removes all the keys from the database.
+func (d *DB) Flush() {
+	d.lock()
+	defer d.unlock()
+
+	d.entries = map[string]*entry{}
+	d.streams = map[string]*stream{}
+}
+
+// atomically executes the 'fn' with the database view that could be used within the lock.
+func (d *DB) atomically(fn func(view *DB)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fn(&DB{entries: d.entries, streams: d.streams, script: true})
+}
+
+func (d *DB) lock() {
+	if !d.script {
+		d.mu.Lock()
+	}
+}
+
+func (d *DB) unlock() {
+	if !d.script {
+		d.mu.Unlock()
+	}
+}
+
+func (d *DB) get(key string) (*entry, bool) {
+	e, ok := d.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if e.expired(time.Now()) {
+		delete(d.entries, key)
+		return nil, false
+	}
+	return e, true
+}
+
+func (d *DB) set(key string, value []byte, ttl time.Duration) {
+	e := &entry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		e.expiresAt = time.Now().Add(ttl)
+	}
+	d.entries[key] = e
+}
@@ -0,0 +1,5 @@
+// Package redistest provides in-process fake redis servers for the tests. It supports the subset of redis commands
+// used by the neuron redis extensions, the redis cluster slots redirection and the redis sentinel master discovery.
+// The lua scripts are not interpreted - the tests should register the Go implementation of the used scripts
+// with the RegisterScript method.
+package redistest
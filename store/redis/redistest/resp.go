@@ -0,0 +1,119 @@
+package redistest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// statusReply is the redis simple string reply.
+type statusReply string
+
+// errorReply is the redis error reply.
+type errorReply string
+
+var (
+	okReply       = statusReply("OK")
+	errNotInteger = errorReply("ERR value is not an integer or out of range")
+	errSyntax     = errorReply("ERR syntax error")
+)
+
+func (e errorReply) Error() string {
+	return string(e)
+}
+
+func errWrongArgs(command string) errorReply {
+	return errorReply(fmt.Sprintf("ERR wrong number of arguments for '%s' command", command))
+}
+
+// readCommand reads the command sent by the client.
+func readCommand(rd *bufio.Reader) ([]string, error) {
+	line, err := readLine(rd)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 || line[0] != '*' {
+		// Inline command.
+		return strings.Fields(line), nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid multibulk length: %q", line)
+	}
+	args := make([]string, n)
+	for i := range args {
+		if line, err = readLine(rd); err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '$' {
+			return nil, fmt.Errorf("expected bulk string: %q", line)
+		}
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid bulk length: %q", line)
+		}
+		buf := make([]byte, size+2)
+		if _, err = io.ReadFull(rd, buf); err != nil {
+			return nil, err
+		}
+		args[i] = string(buf[:size])
+	}
+	return args, nil
+}
+
+func readLine(rd *bufio.Reader) (string, error) {
+	line, err := rd.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// writeReply encodes the 'reply' in the redis protocol.
+func writeReply(buf *bytes.Buffer, reply interface{}) {
+	switch r := reply.(type) {
+	case nil:
+		buf.WriteString("$-1\r\n")
+	case statusReply:
+		buf.WriteString("+" + string(r) + "\r\n")
+	case errorReply:
+		buf.WriteString("-" + string(r) + "\r\n")
+	case int:
+		buf.WriteString(":" + strconv.Itoa(r) + "\r\n")
+	case int64:
+		buf.WriteString(":" + strconv.FormatInt(r, 10) + "\r\n")
+	case bool:
+		if r {
+			buf.WriteString(":1\r\n")
+		} else {
+			buf.WriteString(":0\r\n")
+		}
+	case string:
+		buf.WriteString("$" + strconv.Itoa(len(r)) + "\r\n" + r + "\r\n")
+	case []byte:
+		buf.WriteString("$" + strconv.Itoa(len(r)) + "\r\n")
+		buf.Write(r)
+		buf.WriteString("\r\n")
+	case []string:
+		buf.WriteString("*" + strconv.Itoa(len(r)) + "\r\n")
+		for _, s := range r {
+			writeReply(buf, s)
+		}
+	case []interface{}:
+		if r == nil {
+			buf.WriteString("*-1\r\n")
+			return
+		}
+		buf.WriteString("*" + strconv.Itoa(len(r)) + "\r\n")
+		for _, v := range r {
+			writeReply(buf, v)
+		}
+	case error:
+		buf.WriteString("-ERR " + r.Error() + "\r\n")
+	default:
+		buf.WriteString(fmt.Sprintf("-ERR unsupported reply type: %T\r\n", reply))
+	}
+}
@@ -0,0 +1,61 @@
+package redistest
+
+import (
+	"net"
+	"strings"
+)
+
+// Sentinel is the fake redis sentinel server monitoring a single master.
+type Sentinel struct {
+	*Server
+	MasterName string
+	master     *Server
+}
+
+// NewSentinel creates and starts new fake redis sentinel that serves the 'master' address for the 'masterName'.
+func NewSentinel(masterName string, master *Server) (*Sentinel, error) {
+	s, err := NewServer()
+	if err != nil {
+		return nil, err
+	}
+	sentinel := &Sentinel{Server: s, MasterName: masterName, master: master}
+	s.sentinel = sentinel
+	return sentinel, nil
+}
+
+// URL gets the sentinel connection url.
+func (s *Sentinel) URL() string {
+	return "redis-sentinel://" + s.Addr() + "?master=" + s.MasterName
+}
+
+// Failover switches the sentinel master to the 'master' and notifies the subscribers.
+func (s *Sentinel) Failover(master *Server) {
+	old := s.master
+	s.Server.mu.Lock()
+	s.master = master
+	s.Server.mu.Unlock()
+
+	oldHost, oldPort, _ := net.SplitHostPort(old.Addr())
+	host, port, _ := net.SplitHostPort(master.Addr())
+	s.publish("+switch-master", strings.Join([]string{s.MasterName, oldHost, oldPort, host, port}, " "))
+}
+
+func (s *Sentinel) execute(args []string) interface{} {
+	switch strings.ToLower(args[0]) {
+	case "get-master-addr-by-name":
+		if len(args) != 2 {
+			return errWrongArgs("sentinel get-master-addr-by-name")
+		}
+		if args[1] != s.MasterName {
+			return []interface{}(nil)
+		}
+		s.Server.mu.Lock()
+		addr := s.master.Addr()
+		s.Server.mu.Unlock()
+		host, port, _ := net.SplitHostPort(addr)
+		return []interface{}{host, port}
+	case "sentinels", "slaves", "replicas":
+		return []interface{}{}
+	}
+	return errSyntax
+}
@@ -0,0 +1,216 @@
+package redistest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"net"
+	"strings"
+	"sync"
+)
+
+// ScriptFunc is the Go implementation of the lua script executed on the fake server database.
+// The returned value is encoded as the script reply. Returned errorReply is sent as the redis error reply.
+type ScriptFunc func(db *DB, keys, args []string) (interface{}, error)
+
+// Server is the in-process fake redis server.
+type Server struct {
+	ln        net.Listener
+	db        *DB
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	mu          sync.Mutex
+	scripts     map[string]ScriptFunc
+	conns       map[*conn]struct{}
+	subscribers map[string]map[*conn]struct{}
+	wg          sync.WaitGroup
+
+	// cluster is set for the cluster nodes.
+	cluster          *Cluster
+	slotFrom, slotTo int
+	// sentinel is set for the sentinel servers.
+	sentinel *Sentinel
+}
+
+// NewServer creates and starts new fake redis server listening on the random local port.
+func NewServer() (*Server, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		ln:          ln,
+		db:          newDB(),
+		closed:      make(chan struct{}),
+		scripts:     map[string]ScriptFunc{},
+		conns:       map[*conn]struct{}{},
+		subscribers: map[string]map[*conn]struct{}{},
+	}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr gets the server 'host:port' address.
+func (s *Server) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// URL gets the server connection url.
+func (s *Server) URL() string {
+	return "redis://" + s.Addr()
+}
+
+// DB gets the server database.
+func (s *Server) DB() *DB {
+	return s.db
+}
+
+// RegisterScript registers the Go implementation 'fn' of the lua 'script'.
+func (s *Server) RegisterScript(script string, fn ScriptFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripts[scriptSHA(script)] = fn
+}
+
+// Close stops the server and closes all its connections.
+func (s *Server) Close() error {
+	err := s.ln.Close()
+	s.closeOnce.Do(func() { close(s.closed) })
+	s.mu.Lock()
+	for c := range s.conns {
+		c.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		netConn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		c := &conn{Conn: netConn, subscriptions: map[string]struct{}{}}
+		s.mu.Lock()
+		s.conns[c] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go s.handle(c)
+	}
+}
+
+func (s *Server) handle(c *conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		for channel := range c.subscriptions {
+			delete(s.subscribers[channel], c)
+		}
+		s.mu.Unlock()
+		c.Close()
+	}()
+	rd := bufio.NewReader(c)
+	for {
+		args, err := readCommand(rd)
+		if err != nil {
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		name := strings.ToLower(args[0])
+		if name == "quit" {
+			c.write(okReply)
+			return
+		}
+		if name == "subscribe" || name == "unsubscribe" || (name == "ping" && c.subscribed()) {
+			s.pubsub(c, name, args[1:])
+			continue
+		}
+		c.write(s.execute(name, args[1:]))
+	}
+}
+
+// conn is the client connection.
+type conn struct {
+	net.Conn
+	mu            sync.Mutex
+	subscriptions map[string]struct{}
+}
+
+func (c *conn) write(replies ...interface{}) {
+	buf := &bytes.Buffer{}
+	for _, reply := range replies {
+		writeReply(buf, reply)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, _ = c.Write(buf.Bytes())
+}
+
+func (c *conn) subscribed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.subscriptions) > 0
+}
+
+func (s *Server) pubsub(c *conn, name string, channels []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch name {
+	case "ping":
+		c.write([]interface{}{"pong", ""})
+	case "subscribe":
+		for _, channel := range channels {
+			if s.subscribers[channel] == nil {
+				s.subscribers[channel] = map[*conn]struct{}{}
+			}
+			s.subscribers[channel][c] = struct{}{}
+			c.mu.Lock()
+			c.subscriptions[channel] = struct{}{}
+			count := len(c.subscriptions)
+			c.mu.Unlock()
+			c.write([]interface{}{"subscribe", channel, count})
+		}
+	case "unsubscribe":
+		if len(channels) == 0 {
+			c.mu.Lock()
+			for channel := range c.subscriptions {
+				channels = append(channels, channel)
+			}
+			c.mu.Unlock()
+		}
+		for _, channel := range channels {
+			delete(s.subscribers[channel], c)
+			c.mu.Lock()
+			delete(c.subscriptions, channel)
+			count := len(c.subscriptions)
+			c.mu.Unlock()
+			c.write([]interface{}{"unsubscribe", channel, count})
+		}
+	}
+}
+
+// publish sends the 'message' to the 'channel' subscribers. Returns the number of the receivers.
+func (s *Server) publish(channel, message string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for c := range s.subscribers[channel] {
+		c.write([]interface{}{"message", channel, message})
+	}
+	return len(s.subscribers[channel])
+}
+
+func scriptSHA(script string) string {
+	sum := sha1.Sum([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
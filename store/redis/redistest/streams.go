@@ -0,0 +1,495 @@
+package redistest
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streamID is the redis stream entry identifier.
+type streamID struct {
+	ms, seq uint64
+}
+
+func (id streamID) String() string {
+	return strconv.FormatUint(id.ms, 10) + "-" + strconv.FormatUint(id.seq, 10)
+}
+
+func (id streamID) less(other streamID) bool {
+	return id.ms < other.ms || (id.ms == other.ms && id.seq < other.seq)
+}
+
+func parseStreamID(s string) (streamID, bool) {
+	parts := strings.SplitN(s, "-", 2)
+	ms, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return streamID{}, false
+	}
+	id := streamID{ms: ms}
+	if len(parts) == 2 {
+		if id.seq, err = strconv.ParseUint(parts[1], 10, 64); err != nil {
+			return streamID{}, false
+		}
+	}
+	return id, true
+}
+
+type stream struct {
+	entries []*streamEntry
+	lastID  streamID
+	groups  map[string]*streamGroup
+}
+
+type streamEntry struct {
+	id     streamID
+	fields []string
+}
+
+func (e *streamEntry) reply() interface{} {
+	fields := make([]interface{}, len(e.fields))
+	for i, f := range e.fields {
+		fields[i] = f
+	}
+	return []interface{}{e.id.String(), fields}
+}
+
+type streamGroup struct {
+	lastDelivered streamID
+	pending       map[streamID]*pendingEntry
+}
+
+type pendingEntry struct {
+	consumer    string
+	deliveredAt time.Time
+	deliveries  int64
+}
+
+func (s *stream) entry(id streamID) *streamEntry {
+	i := sort.Search(len(s.entries), func(i int) bool { return !s.entries[i].id.less(id) })
+	if i < len(s.entries) && s.entries[i].id == id {
+		return s.entries[i]
+	}
+	return nil
+}
+
+// after gets at most 'count' entries with the identifier greater than 'id'. Non positive count means no limit.
+func (s *stream) after(id streamID, count int) []*streamEntry {
+	i := sort.Search(len(s.entries), func(i int) bool { return id.less(s.entries[i].id) })
+	entries := s.entries[i:]
+	if count > 0 && len(entries) > count {
+		entries = entries[:count]
+	}
+	return entries
+}
+
+// xadd implements XADD command.
+func (s *Server) xadd(args []string) interface{} {
+	key, args := args[0], args[1:]
+	maxLen := -1
+	if len(args) > 0 && strings.ToLower(args[0]) == "maxlen" {
+		args = args[1:]
+		if len(args) > 0 && (args[0] == "~" || args[0] == "=") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			return errSyntax
+		}
+		var err error
+		if maxLen, err = strconv.Atoi(args[0]); err != nil {
+			return errNotInteger
+		}
+		args = args[1:]
+	}
+	if len(args) < 3 || len(args[1:])%2 != 0 {
+		return errWrongArgs("xadd")
+	}
+	db := s.db
+	db.lock()
+	defer db.unlock()
+	st := db.stream(key, true)
+
+	id := streamID{ms: uint64(time.Now().UnixNano() / int64(time.Millisecond))}
+	if args[0] == "*" {
+		if !st.lastID.less(id) {
+			id = streamID{ms: st.lastID.ms, seq: st.lastID.seq + 1}
+		}
+	} else {
+		var ok bool
+		if id, ok = parseStreamID(args[0]); !ok {
+			return errorReply("ERR Invalid stream ID specified as stream command argument")
+		}
+		if !st.lastID.less(id) {
+			return errorReply("ERR The ID specified in XADD is equal or smaller than the target stream top item")
+		}
+	}
+	st.lastID = id
+	st.entries = append(st.entries, &streamEntry{id: id, fields: append([]string(nil), args[1:]...)})
+	if maxLen >= 0 && len(st.entries) > maxLen {
+		st.entries = st.entries[len(st.entries)-maxLen:]
+	}
+	return id.String()
+}
+
+// xrange implements XRANGE and XREVRANGE commands.
+func (s *Server) xrange(reverse bool, args []string) interface{} {
+	key, start, end := args[0], args[1], args[2]
+	if reverse {
+		start, end = end, start
+	}
+	count := -1
+	if len(args) == 5 && strings.ToLower(args[3]) == "count" {
+		var err error
+		if count, err = strconv.Atoi(args[4]); err != nil {
+			return errNotInteger
+		}
+	} else if len(args) != 3 {
+		return errSyntax
+	}
+	db := s.db
+	db.lock()
+	defer db.unlock()
+
+	reply := []interface{}{}
+	st := db.stream(key, false)
+	if st == nil {
+		return reply
+	}
+	inRange := func(id streamID) bool {
+		if start != "-" {
+			if from, ok := parseStreamID(start); !ok || id.less(from) {
+				return false
+			}
+		}
+		if end != "+" {
+			if to, ok := parseStreamID(end); !ok || to.less(id) {
+				return false
+			}
+		}
+		return true
+	}
+	for i := range st.entries {
+		e := st.entries[i]
+		if reverse {
+			e = st.entries[len(st.entries)-1-i]
+		}
+		if count >= 0 && len(reply) >= count {
+			break
+		}
+		if inRange(e.id) {
+			reply = append(reply, e.reply())
+		}
+	}
+	return reply
+}
+
+// xgroup implements XGROUP CREATE and DESTROY commands.
+func (s *Server) xgroup(args []string) interface{} {
+	db := s.db
+	db.lock()
+	defer db.unlock()
+
+	switch strings.ToLower(args[0]) {
+	case "create":
+		if len(args) < 4 {
+			return errWrongArgs("xgroup")
+		}
+		mkStream := len(args) == 5 && strings.ToLower(args[4]) == "mkstream"
+		st := db.stream(args[1], mkStream)
+		if st == nil {
+			return errorReply("ERR The XGROUP subcommand requires the key to exist")
+		}
+		if _, ok := st.groups[args[2]]; ok {
+			return errorReply("BUSYGROUP Consumer Group name already exists")
+		}
+		g := &streamGroup{pending: map[streamID]*pendingEntry{}}
+		if args[3] == "$" {
+			g.lastDelivered = st.lastID
+		} else {
+			id, ok := parseStreamID(args[3])
+			if !ok {
+				return errorReply("ERR Invalid stream ID specified as stream command argument")
+			}
+			g.lastDelivered = id
+		}
+		st.groups[args[2]] = g
+		return okReply
+	case "destroy":
+		if len(args) != 3 {
+			return errWrongArgs("xgroup")
+		}
+		st := db.stream(args[1], false)
+		if st == nil {
+			return 0
+		}
+		if _, ok := st.groups[args[2]]; !ok {
+			return 0
+		}
+		delete(st.groups, args[2])
+		return 1
+	}
+	return errSyntax
+}
+
+// xread implements XREAD and XREADGROUP commands for a single stream.
+func (s *Server) xread(group bool, args []string) interface{} {
+	var groupName, consumer string
+	if group {
+		if len(args) < 3 || strings.ToLower(args[0]) != "group" {
+			return errSyntax
+		}
+		groupName, consumer, args = args[1], args[2], args[3:]
+	}
+	count, block := 0, time.Duration(-1)
+	var noAck bool
+	for len(args) > 0 && strings.ToLower(args[0]) != "streams" {
+		switch strings.ToLower(args[0]) {
+		case "count", "block":
+			if len(args) < 2 {
+				return errSyntax
+			}
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				return errNotInteger
+			}
+			if strings.ToLower(args[0]) == "count" {
+				count = n
+			} else {
+				block = time.Duration(n) * time.Millisecond
+			}
+			args = args[2:]
+		case "noack":
+			noAck = true
+			args = args[1:]
+		default:
+			return errSyntax
+		}
+	}
+	if len(args) != 3 {
+		return errorReply("ERR fake server supports reading a single stream")
+	}
+	key, start := args[1], args[2]
+
+	db := s.db
+	if start == "$" && !group {
+		db.lock()
+		if st := db.stream(key, false); st != nil {
+			start = st.lastID.String()
+		} else {
+			start = "0-0"
+		}
+		db.unlock()
+	}
+	var deadline time.Time
+	if block > 0 {
+		deadline = time.Now().Add(block)
+	}
+	for {
+		db.lock()
+		var reply interface{}
+		if group {
+			reply = db.readGroup(key, groupName, consumer, start, count, noAck)
+		} else {
+			reply = db.read(key, start, count)
+		}
+		db.unlock()
+
+		if _, isErr := reply.(errorReply); isErr || reply != nil || block < 0 || (block > 0 && time.Now().After(deadline)) {
+			if reply == nil {
+				return []interface{}(nil)
+			}
+			return reply
+		}
+		select {
+		case <-s.closed:
+			return []interface{}(nil)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+}
+
+func (d *DB) read(key, start string, count int) interface{} {
+	st := d.stream(key, false)
+	if st == nil {
+		return nil
+	}
+	id, ok := parseStreamID(start)
+	if !ok {
+		return errorReply("ERR Invalid stream ID specified as stream command argument")
+	}
+	entries := st.after(id, count)
+	if len(entries) == 0 {
+		return nil
+	}
+	return streamReply(key, entries)
+}
+
+func (d *DB) readGroup(key, groupName, consumer, start string, count int, noAck bool) interface{} {
+	st := d.stream(key, false)
+	var g *streamGroup
+	if st != nil {
+		g = st.groups[groupName]
+	}
+	if g == nil {
+		return errorReply("NOGROUP No such key '" + key + "' or consumer group '" + groupName + "' in XREADGROUP with GROUP option")
+	}
+	if start != ">" {
+		// Read the consumer pending entries history.
+		id, ok := parseStreamID(start)
+		if !ok {
+			return errorReply("ERR Invalid stream ID specified as stream command argument")
+		}
+		var entries []*streamEntry
+		for _, e := range st.after(id, 0) {
+			if p, ok := g.pending[e.id]; ok && p.consumer == consumer {
+				entries = append(entries, e)
+				if count > 0 && len(entries) == count {
+					break
+				}
+			}
+		}
+		return streamReply(key, entries)
+	}
+	entries := st.after(g.lastDelivered, count)
+	if len(entries) == 0 {
+		return nil
+	}
+	now := time.Now()
+	for _, e := range entries {
+		g.lastDelivered = e.id
+		if !noAck {
+			g.pending[e.id] = &pendingEntry{consumer: consumer, deliveredAt: now, deliveries: 1}
+		}
+	}
+	return streamReply(key, entries)
+}
+
+// xack implements XACK command.
+func (s *Server) xack(args []string) interface{} {
+	db := s.db
+	db.lock()
+	defer db.unlock()
+
+	g := db.streamGroup(args[0], args[1])
+	if g == nil {
+		return 0
+	}
+	var acknowledged int
+	for _, arg := range args[2:] {
+		id, ok := parseStreamID(arg)
+		if !ok {
+			return errorReply("ERR Invalid stream ID specified as stream command argument")
+		}
+		if _, ok = g.pending[id]; ok {
+			delete(g.pending, id)
+			acknowledged++
+		}
+	}
+	return acknowledged
+}
+
+// xpending implements the extended form of the XPENDING command.
+func (s *Server) xpending(args []string) interface{} {
+	if len(args) < 5 {
+		return errorReply("ERR fake server supports only extended XPENDING form")
+	}
+	count, err := strconv.Atoi(args[4])
+	if err != nil {
+		return errNotInteger
+	}
+	var consumer string
+	if len(args) == 6 {
+		consumer = args[5]
+	}
+	db := s.db
+	db.lock()
+	defer db.unlock()
+
+	g := db.streamGroup(args[0], args[1])
+	if g == nil {
+		return errorReply("NOGROUP No such key '" + args[0] + "' or consumer group '" + args[1] + "'")
+	}
+	ids := make([]streamID, 0, len(g.pending))
+	for id, p := range g.pending {
+		if consumer == "" || p.consumer == consumer {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].less(ids[j]) })
+	reply := []interface{}{}
+	now := time.Now()
+	for _, id := range ids {
+		if len(reply) == count {
+			break
+		}
+		p := g.pending[id]
+		reply = append(reply, []interface{}{id.String(), p.consumer, int64(now.Sub(p.deliveredAt) / time.Millisecond), p.deliveries})
+	}
+	return reply
+}
+
+// xclaim implements XCLAIM command.
+func (s *Server) xclaim(args []string) interface{} {
+	key, groupName, consumer := args[0], args[1], args[2]
+	minIdle, err := strconv.ParseInt(args[3], 10, 64)
+	if err != nil {
+		return errNotInteger
+	}
+	db := s.db
+	db.lock()
+	defer db.unlock()
+
+	g := db.streamGroup(key, groupName)
+	if g == nil {
+		return errorReply("NOGROUP No such key '" + key + "' or consumer group '" + groupName + "'")
+	}
+	st := db.stream(key, false)
+	now := time.Now()
+	reply := []interface{}{}
+	for _, arg := range args[4:] {
+		id, ok := parseStreamID(arg)
+		if !ok {
+			return errorReply("ERR Invalid stream ID specified as stream command argument")
+		}
+		p, ok := g.pending[id]
+		if !ok || now.Sub(p.deliveredAt) < time.Duration(minIdle)*time.Millisecond {
+			continue
+		}
+		e := st.entry(id)
+		if e == nil {
+			delete(g.pending, id)
+			continue
+		}
+		p.consumer, p.deliveredAt = consumer, now
+		p.deliveries++
+		reply = append(reply, e.reply())
+	}
+	return reply
+}
+
+func streamReply(key string, entries []*streamEntry) interface{} {
+	messages := make([]interface{}, len(entries))
+	for i, e := range entries {
+		messages[i] = e.reply()
+	}
+	return []interface{}{[]interface{}{key, messages}}
+}
+
+// stream gets the stream stored with the 'key'. If 'create' is true the stream is created if not exists.
+// Requires the database lock.
+func (d *DB) stream(key string, create bool) *stream {
+	st, ok := d.streams[key]
+	if !ok && create {
+		st = &stream{groups: map[string]*streamGroup{}}
+		d.streams[key] = st
+	}
+	return st
+}
+
+func (d *DB) streamGroup(key, group string) *streamGroup {
+	st := d.stream(key, false)
+	if st == nil {
+		return nil
+	}
+	return st.groups[group]
+}
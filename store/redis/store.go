@@ -2,7 +2,7 @@ package redis
 
 import (
 	"context"
-	"strings"
+	"sort"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -20,17 +20,18 @@ var (
 
 // Redis is the key-value store implementation for the neuron framework.
 // It implements store.Store, core.Dialer and core.Closer interfaces.
-// This implementation requires store options connection url to be set.
+// This implementation requires store options connection url to be set, unless it is created with the NewFailover
+// or NewCluster functions.
 type Redis struct {
-	Options  *store.Options
-	r        *redis.Client
-	rOptions *redis.Options
+	Options   *store.Options
+	r         redis.UniversalClient
+	newClient func() redis.UniversalClient
 }
 
 // Dial implements core.Dialer interface.
 func (r *Redis) Dial(ctx context.Context) error {
 	// Create new client.
-	r.r = redis.NewClient(r.rOptions)
+	r.r = r.newClient()
 	//
 	_, err := r.r.Ping(ctx).Result()
 	if err != nil {
@@ -44,26 +45,56 @@ func (r *Redis) Close(_ context.Context) error {
 	return r.r.Close()
 }
 
-// New creates new options.
+// New creates new redis store. The connection url defines the type of the redis client:
+//   - 'redis://' or 'rediss://' - single redis server,
+//   - 'redis-sentinel://' - redis sentinel failover client i.e.: 'redis-sentinel://:password@host1:26379,host2:26379/0?master=mymaster',
+//   - 'redis-cluster://' - redis cluster client i.e.: 'redis-cluster://:password@host1:7000,host2:7001'.
 func New(options ...store.Option) (*Redis, error) {
-	o := &store.Options{}
-	for _, option := range options {
-		option(o)
-	}
+	o := newOptions(options)
 	if o.ConnectionURL == "" {
 		return nil, errors.Wrap(store.ErrInitialization, "no connection url provided in the options")
 	}
-	redisOptions, err := redis.ParseURL(o.ConnectionURL)
+	newClient, err := parseConnectionURL(o.ConnectionURL)
 	if err != nil {
 		return nil, errors.Wrapf(store.ErrInitialization, "connection url is not valid: %v", err.Error())
 	}
 	r := &Redis{
-		rOptions: redisOptions,
-		Options:  o,
+		newClient: newClient,
+		Options:   o,
 	}
 	return r, nil
 }
 
+// NewFailover creates new redis store that uses the redis sentinel failover client.
+func NewFailover(failoverOptions *redis.FailoverOptions, options ...store.Option) (*Redis, error) {
+	if failoverOptions == nil || failoverOptions.MasterName == "" || len(failoverOptions.SentinelAddrs) == 0 {
+		return nil, errors.Wrap(store.ErrInitialization, "no sentinel master name or addresses provided")
+	}
+	return &Redis{
+		newClient: func() redis.UniversalClient { return redis.NewFailoverClient(failoverOptions) },
+		Options:   newOptions(options),
+	}, nil
+}
+
+// NewCluster creates new redis store that uses the redis cluster client.
+func NewCluster(clusterOptions *redis.ClusterOptions, options ...store.Option) (*Redis, error) {
+	if clusterOptions == nil || len(clusterOptions.Addrs) == 0 {
+		return nil, errors.Wrap(store.ErrInitialization, "no cluster addresses provided")
+	}
+	return &Redis{
+		newClient: func() redis.UniversalClient { return redis.NewClusterClient(clusterOptions) },
+		Options:   newOptions(options),
+	}, nil
+}
+
+func newOptions(options []store.Option) *store.Options {
+	o := &store.Options{}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
+
 // Set implements store.Store interface.
 func (r *Redis) Set(ctx context.Context, record *store.Record, options ...store.SetOption) error {
 	o := &store.SetOptions{}
@@ -96,15 +127,18 @@ func (r *Redis) Delete(ctx context.Context, key string) error {
 	if err := r.checkInitialization(); err != nil {
 		return err
 	}
-	if err := r.r.Del(ctx, r.getKey(key)).Err(); err != nil {
-		if err == redis.Nil {
-			return store.ErrRecordNotFound
-		}
+	deleted, err := r.r.Del(ctx, r.getKey(key)).Result()
+	if err != nil {
 		return errors.Wrap(store.ErrInternal, err.Error())
 	}
+	if deleted == 0 {
+		return store.ErrRecordNotFound
+	}
 	return nil
 }
 
+// Find implements store.Store interface. The keys are found using SCAN command and the records are sorted
+// by their keys. For large keyspaces the Iterate method should be used, which streams not sorted records.
 func (r *Redis) Find(ctx context.Context, options ...store.FindOption) ([]*store.Record, error) {
 	if err := r.checkInitialization(); err != nil {
 		return nil, err
@@ -113,46 +147,56 @@ func (r *Redis) Find(ctx context.Context, options ...store.FindOption) ([]*store
 	for _, option := range options {
 		option(o)
 	}
-	prefix := r.Options.Prefix + o.Prefix
-	suffix := o.Suffix + r.Options.Suffix
-	var pattern string
-	if prefix != "" || suffix != "" {
-		pattern = prefix + "*" + suffix
-	}
-	keys, err := r.r.Keys(ctx, pattern).Result()
+	scanners, err := r.scanners(ctx)
 	if err != nil {
-		if err == redis.Nil {
-			return nil, nil
-		}
-		return nil, errors.Wrap(store.ErrStore, err.Error())
-	}
-	var (
-		records []*store.Record
-		j       int
-	)
-	for i := range keys {
-		if o.Offset > 0 {
-			o.Offset--
-			continue
-		}
-		key := keys[i]
-		if r.Options.Prefix != "" {
-			key = strings.TrimPrefix(key, r.Options.Prefix)
+		return nil, err
+	}
+	pattern := r.findPattern(o)
+	// The SCAN command might return some keys more than once.
+	unique := map[string]struct{}{}
+	for _, scanner := range scanners {
+		var cursor uint64
+		for {
+			var keys []string
+			keys, cursor, err = scanner.Scan(ctx, cursor, pattern, ScanCount).Result()
+			if err != nil {
+				return nil, errors.Wrap(store.ErrStore, err.Error())
+			}
+			for _, key := range keys {
+				unique[key] = struct{}{}
+			}
+			if cursor == 0 {
+				break
+			}
 		}
-		if r.Options.Suffix != "" {
-			key = strings.TrimSuffix(key, r.Options.Suffix)
+	}
+	keys := make([]string, 0, len(unique))
+	for key := range unique {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return r.trimKey(keys[i]) < r.trimKey(keys[j])
+	})
+	if o.Offset >= len(keys) {
+		return nil, nil
+	}
+	keys = keys[o.Offset:]
+	if o.Limit > 0 && o.Limit < len(keys) {
+		keys = keys[:o.Limit]
+	}
+
+	var records []*store.Record
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > ScanCount {
+			batch = batch[:ScanCount]
 		}
-		record, err := r.getRecord(ctx, key)
-		if err != nil && err == redis.Nil {
-			continue
-		} else if err != nil {
+		keys = keys[len(batch):]
+		batchRecords, err := r.getRecords(ctx, batch)
+		if err != nil {
 			return nil, err
 		}
-		records = append(records, record)
-		j++
-		if j == o.Limit {
-			break
-		}
+		records = append(records, batchRecords...)
 	}
 	return records, nil
 }
@@ -169,14 +213,14 @@ func (r *Redis) getRecord(ctx context.Context, key string) (*store.Record, error
 	} else if err != nil {
 		return nil, errors.Wrap(store.ErrInternal, err.Error())
 	}
-	ttl, err := r.r.TTL(ctx, thisKey).Result()
+	ttl, err := r.r.PTTL(ctx, thisKey).Result()
 	if err != nil {
 		return nil, errors.Wrap(store.ErrInternal, err.Error())
 	}
 	return &store.Record{
 		Key:       key,
 		Value:     value,
-		ExpiresAt: time.Now().Add(ttl),
+		ExpiresAt: expiresAt(time.Now(), ttl),
 	}, nil
 }
 
@@ -0,0 +1,433 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/redis/redistest"
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+	"github.com/neuronlabs/neuron-extensions/store/xstore/storetest"
+)
+
+// testingStore creates new redis store for the tests. The connection url is taken from the 'REDIS_TESTING'
+// environment variable. If it is not set the test is skipped. Each store uses unique prefix, unless provided
+// in the 'options'.
+func testingStore(t *testing.T, options ...store.Option) *Redis {
+	url, ok := os.LookupEnv("REDIS_TESTING")
+	if !ok {
+		t.Skip("REDIS_TESTING environment variable not defined")
+	}
+	prefix := store.WithPrefix("nrn_test_" + strconv.FormatInt(time.Now().UnixNano(), 36) + "_")
+	return dialStore(t, url, append([]store.Option{prefix}, options...)...)
+}
+
+func dialStore(t *testing.T, url string, options ...store.Option) *Redis {
+	r, err := New(append(options, store.WithConnectionURL(url))...)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, r.Dial(ctx))
+	t.Cleanup(func() {
+		records, err := r.Find(ctx)
+		if err == nil {
+			for _, record := range records {
+				_ = r.Delete(ctx, record.Key)
+			}
+		}
+		_ = r.Close(ctx)
+	})
+	return r
+}
+
+// fakeServer starts new fake redis server with registered store scripts.
+func fakeServer(t *testing.T) *redistest.Server {
+	s, err := redistest.NewServer()
+	require.NoError(t, err)
+	registerScripts(s.RegisterScript)
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+// fakeCluster starts new fake redis cluster with registered store scripts.
+func fakeCluster(t *testing.T, nodes int) *redistest.Cluster {
+	c, err := redistest.NewCluster(nodes)
+	require.NoError(t, err)
+	registerScripts(c.RegisterScript)
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+// fakeSentinel starts new fake redis sentinel for the 'master'.
+func fakeSentinel(t *testing.T, master *redistest.Server) *redistest.Sentinel {
+	s, err := redistest.NewSentinel("master", master)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+// registerScripts registers the Go implementations of the store lua scripts.
+func registerScripts(register func(script string, fn redistest.ScriptFunc)) {
+	register(compareAndSwapSource, func(db *redistest.DB, keys, args []string) (interface{}, error) {
+		current, ok := db.Get(keys[0])
+		if !ok {
+			return int64(-1), nil
+		}
+		if string(xstore.ValueVersion(current)) != args[0] {
+			return int64(0), nil
+		}
+		ttl, err := strconv.ParseInt(args[2], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		db.Set(keys[0], []byte(args[1]), time.Duration(ttl)*time.Millisecond)
+		return int64(1), nil
+	})
+	register(incrementSource, func(db *redistest.DB, keys, args []string) (interface{}, error) {
+		exists := db.Exists(keys[0])
+		delta, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		value, err := db.IncrBy(keys[0], delta)
+		if err != nil {
+			return nil, err
+		}
+		ttl, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if !exists && ttl > 0 {
+			db.Expire(keys[0], time.Duration(ttl)*time.Millisecond)
+		}
+		return value, nil
+	})
+	register(acquireLockSource, func(db *redistest.DB, keys, args []string) (interface{}, error) {
+		if db.Exists(keys[0]) {
+			return int64(0), nil
+		}
+		ttl, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		db.Set(keys[0], []byte(args[0]), time.Duration(ttl)*time.Millisecond)
+		return db.IncrBy(keys[1], 1)
+	})
+	register(refreshLockSource, func(db *redistest.DB, keys, args []string) (interface{}, error) {
+		if token, ok := db.Get(keys[0]); !ok || string(token) != args[0] {
+			return int64(0), nil
+		}
+		ttl, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		db.Expire(keys[0], time.Duration(ttl)*time.Millisecond)
+		return int64(1), nil
+	})
+	register(releaseLockSource, func(db *redistest.DB, keys, args []string) (interface{}, error) {
+		if token, ok := db.Get(keys[0]); !ok || string(token) != args[0] {
+			return int64(0), nil
+		}
+		db.Delete(keys[0])
+		return int64(1), nil
+	})
+	register(syncLockFenceSource, func(db *redistest.DB, keys, args []string) (interface{}, error) {
+		if token, ok := db.Get(keys[0]); !ok || string(token) != args[0] {
+			return int64(0), nil
+		}
+		fence, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		var current int64
+		if value, ok := db.Get(keys[1]); ok {
+			if current, err = strconv.ParseInt(string(value), 10, 64); err != nil {
+				return nil, err
+			}
+		}
+		if current < fence {
+			db.Set(keys[1], []byte(args[1]), 0)
+		}
+		return int64(1), nil
+	})
+}
+
+func TestStore(t *testing.T) {
+	t.Run("Redis", func(t *testing.T) {
+		storetest.TestStore(t, func(t *testing.T, options ...store.Option) store.Store {
+			return testingStore(t, options...)
+		})
+	})
+	t.Run("Fake", func(t *testing.T) {
+		storetest.TestStore(t, func(t *testing.T, options ...store.Option) store.Store {
+			return dialStore(t, fakeServer(t).URL(), options...)
+		})
+	})
+	t.Run("FakeCluster", func(t *testing.T) {
+		storetest.TestStore(t, func(t *testing.T, options ...store.Option) store.Store {
+			return dialStore(t, fakeCluster(t, 3).URL(), options...)
+		})
+	})
+}
+
+func TestAtomic(t *testing.T) {
+	t.Run("Redis", func(t *testing.T) {
+		storetest.TestAtomic(t, func(t *testing.T) xstore.AtomicStore {
+			return testingStore(t)
+		})
+	})
+	t.Run("Fake", func(t *testing.T) {
+		storetest.TestAtomic(t, func(t *testing.T) xstore.AtomicStore {
+			return dialStore(t, fakeServer(t).URL())
+		})
+	})
+	t.Run("FakeCluster", func(t *testing.T) {
+		storetest.TestAtomic(t, func(t *testing.T) xstore.AtomicStore {
+			return dialStore(t, fakeCluster(t, 3).URL())
+		})
+	})
+	t.Run("FakeSentinel", func(t *testing.T) {
+		storetest.TestAtomic(t, func(t *testing.T) xstore.AtomicStore {
+			return dialStore(t, fakeSentinel(t, fakeServer(t)).URL())
+		})
+	})
+}
+
+func TestPubSub(t *testing.T) {
+	t.Run("Fake", func(t *testing.T) {
+		storetest.TestPubSub(t, func(t *testing.T) xstore.PubSub {
+			return NewPubSub(dialStore(t, fakeServer(t).URL()))
+		})
+	})
+	t.Run("FakeCluster", func(t *testing.T) {
+		storetest.TestPubSub(t, func(t *testing.T) xstore.PubSub {
+			return NewPubSub(dialStore(t, fakeCluster(t, 3).URL()))
+		})
+	})
+	t.Run("Redis", func(t *testing.T) {
+		storetest.TestPubSub(t, func(t *testing.T) xstore.PubSub {
+			return NewPubSub(testingStore(t))
+		})
+	})
+	t.Run("GroupsNotSupported", func(t *testing.T) {
+		ps := NewPubSub(dialStore(t, fakeServer(t).URL()))
+		_, err := ps.Subscribe(context.Background(), "events", xstore.SubscribeGroup("group", "consumer"))
+		assert.True(t, errors.Is(err, xstore.ErrGroupsNotSupported))
+	})
+	t.Run("ConnectionLost", func(t *testing.T) {
+		server := fakeServer(t)
+		ps := NewPubSub(dialStore(t, server.URL()))
+		sub, err := ps.Subscribe(context.Background(), "events")
+		require.NoError(t, err)
+		defer sub.Close()
+		assert.NoError(t, sub.Err())
+
+		// The lost connection stops the subscription instead of silently reconnecting.
+		require.NoError(t, server.Close())
+		select {
+		case _, ok := <-sub.Messages():
+			assert.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("subscription not stopped")
+		}
+		assert.True(t, errors.Is(sub.Err(), store.ErrStore))
+	})
+	t.Run("Closed", func(t *testing.T) {
+		ps := NewPubSub(dialStore(t, fakeServer(t).URL()))
+		sub, err := ps.Subscribe(context.Background(), "events")
+		require.NoError(t, err)
+		require.NoError(t, sub.Close())
+		_, ok := <-sub.Messages()
+		assert.False(t, ok)
+		assert.NoError(t, sub.Err())
+	})
+}
+
+func TestStreams(t *testing.T) {
+	streams := func(r *Redis) xstore.PubSub {
+		s := NewStreams(r)
+		s.BlockTimeout = 50 * time.Millisecond
+		return s
+	}
+	for name, dial := range map[string]func(t *testing.T) *Redis{
+		"Fake":        func(t *testing.T) *Redis { return dialStore(t, fakeServer(t).URL()) },
+		"FakeCluster": func(t *testing.T) *Redis { return dialStore(t, fakeCluster(t, 3).URL()) },
+		"Redis":       func(t *testing.T) *Redis { return testingStore(t) },
+	} {
+		dial := dial
+		t.Run(name, func(t *testing.T) {
+			factory := func(t *testing.T) xstore.PubSub { return streams(dial(t)) }
+			storetest.TestPubSub(t, factory)
+			storetest.TestConsumerGroups(t, factory)
+		})
+	}
+}
+
+func TestLocker(t *testing.T) {
+	t.Run("Fake", func(t *testing.T) {
+		storetest.TestLocker(t, func(t *testing.T) xstore.Locker {
+			return NewLocker(dialStore(t, fakeServer(t).URL()))
+		})
+	})
+	t.Run("FakeCluster", func(t *testing.T) {
+		storetest.TestLocker(t, func(t *testing.T) xstore.Locker {
+			return NewLocker(dialStore(t, fakeCluster(t, 3).URL()))
+		})
+	})
+	t.Run("FakeRedlock", func(t *testing.T) {
+		storetest.TestLocker(t, func(t *testing.T) xstore.Locker {
+			l, err := NewRedlock(dialStore(t, fakeServer(t).URL()), dialStore(t, fakeServer(t).URL()), dialStore(t, fakeServer(t).URL()))
+			require.NoError(t, err)
+			return l
+		})
+	})
+	t.Run("Redis", func(t *testing.T) {
+		storetest.TestLocker(t, func(t *testing.T) xstore.Locker {
+			return NewLocker(testingStore(t))
+		})
+	})
+}
+
+func TestRedlock(t *testing.T) {
+	ctx := context.Background()
+	servers := []*redistest.Server{fakeServer(t), fakeServer(t), fakeServer(t)}
+	var instances []*Redis
+	for _, s := range servers {
+		instances = append(instances, dialStore(t, s.URL()))
+	}
+	l, err := NewRedlock(instances...)
+	require.NoError(t, err)
+
+	t.Run("Quorum", func(t *testing.T) {
+		// Acquire the lock on the single instance, so that the fence of the others is lower.
+		single, err := NewLocker(instances[0]).Acquire(ctx, "quorum", time.Second)
+		require.NoError(t, err)
+		require.NoError(t, single.Release(ctx))
+
+		// The lock held on the majority of the instances could not be acquired.
+		held, err := NewRedlock(instances[1:]...)
+		require.NoError(t, err)
+		heldLock, err := held.Acquire(ctx, "quorum", time.Second)
+		require.NoError(t, err)
+
+		_, err = l.Acquire(ctx, "quorum", time.Second)
+		assert.True(t, errors.Is(err, xstore.ErrLockHeld))
+		require.NoError(t, heldLock.Release(ctx))
+
+		first, err := l.Acquire(ctx, "quorum", time.Second)
+		require.NoError(t, err)
+		require.NoError(t, first.Release(ctx))
+
+		// The fence is synchronized between the instances, thus next lock on any majority has higher fence.
+		second, err := held.Acquire(ctx, "quorum", time.Second)
+		require.NoError(t, err)
+		assert.Greater(t, second.Fence(), first.Fence())
+		require.NoError(t, second.Release(ctx))
+	})
+
+	t.Run("InstanceDown", func(t *testing.T) {
+		require.NoError(t, servers[2].Close())
+		lock, err := l.Acquire(ctx, "down", time.Second)
+		require.NoError(t, err)
+		require.NoError(t, lock.Refresh(ctx, time.Second))
+		require.NoError(t, lock.Release(ctx))
+
+		require.NoError(t, servers[1].Close())
+		_, err = l.Acquire(ctx, "down", time.Second)
+		assert.True(t, errors.Is(err, store.ErrStore))
+	})
+}
+
+func TestCluster(t *testing.T) {
+	c := fakeCluster(t, 3)
+	r := dialStore(t, c.URL(), store.WithPrefix("nrn_"))
+	ctx := context.Background()
+
+	const count = 50
+	for i := 0; i < count; i++ {
+		require.NoError(t, r.Set(ctx, &store.Record{Key: "key_" + strconv.Itoa(i), Value: []byte(strconv.Itoa(i))}))
+	}
+	for _, node := range c.Nodes {
+		assert.NotEmpty(t, node.DB().Keys(), "keys should be spread between all the cluster nodes")
+	}
+
+	t.Run("Get", func(t *testing.T) {
+		for i := 0; i < count; i++ {
+			record, err := r.Get(ctx, "key_"+strconv.Itoa(i))
+			require.NoError(t, err)
+			assert.Equal(t, []byte(strconv.Itoa(i)), record.Value)
+		}
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		records, err := r.Find(ctx, store.FindWithPrefix("key_"))
+		require.NoError(t, err)
+		assert.Len(t, records, count)
+
+		records, err = r.Find(ctx, store.FindWithPrefix("key_"), store.FindWithLimit(10), store.FindWithOffset(count-5))
+		require.NoError(t, err)
+		assert.Len(t, records, 5)
+	})
+
+	t.Run("HashTag", func(t *testing.T) {
+		token, mapped := HashTag("account-1", "token"), HashTag("account-1", "mapped")
+		require.NoError(t, r.Set(ctx, &store.Record{Key: token, Value: []byte("token")}))
+		require.NoError(t, r.Set(ctx, &store.Record{Key: mapped, Value: []byte("mapped")}))
+
+		node := c.NodeForKey(r.getKey(token))
+		assert.Equal(t, node, c.NodeForKey(r.getKey(mapped)))
+		assert.True(t, node.DB().Exists(r.getKey(token)))
+		assert.True(t, node.DB().Exists(r.getKey(mapped)))
+	})
+}
+
+func TestSentinelFailover(t *testing.T) {
+	master, replica := fakeServer(t), fakeServer(t)
+	sentinel := fakeSentinel(t, master)
+	r := dialStore(t, sentinel.URL())
+	ctx := context.Background()
+
+	require.NoError(t, r.Set(ctx, &store.Record{Key: "before", Value: []byte("value")}))
+	assert.True(t, master.DB().Exists("before"))
+
+	sentinel.Failover(replica)
+	assert.Eventually(t, func() bool {
+		if err := r.Set(ctx, &store.Record{Key: "after", Value: []byte("value")}); err != nil {
+			return false
+		}
+		return replica.DB().Exists("after")
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestParseConnectionURL(t *testing.T) {
+	t.Run("Sentinel", func(t *testing.T) {
+		u, err := parseMultiHostURL("user:p%40ss@host1:26379,host2/2?master=mymaster")
+		require.NoError(t, err)
+		assert.Equal(t, "user", u.username)
+		assert.Equal(t, "p@ss", u.password)
+		assert.Equal(t, []string{"host1:26379", "host2:6379"}, u.addrs)
+		assert.Equal(t, 2, u.db)
+		assert.Equal(t, "mymaster", u.query.Get("master"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, url := range []string{
+			"localhost:6379",
+			"redis-sentinel://host1:26379,host2:26379",
+			"redis-cluster://host1:7000/1",
+			"redis-cluster://",
+			"redis-cluster://host1:7000/db",
+		} {
+			_, err := parseConnectionURL(url)
+			assert.Error(t, err, url)
+		}
+	})
+}
@@ -0,0 +1,309 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// Compile time check if Streams implements xstore.PubSub interface.
+var _ xstore.PubSub = &Streams{}
+
+const (
+	// DefaultBlockTimeout is the default maximum duration of the blocking stream reads.
+	DefaultBlockTimeout = time.Second
+	// DefaultReadCount is the default maximum number of the stream messages read at once.
+	DefaultReadCount = 100
+
+	payloadField = "payload"
+)
+
+// Streams is the redis streams based xstore.PubSub implementation. The messages are persisted within the topic
+// streams, so that the consumer groups could process them reliably - the messages must be acknowledged by the group
+// consumers, otherwise they are claimed and redelivered after the acknowledge timeout. The topic stream keys are
+// prefixed and suffixed the same as the store keys.
+type Streams struct {
+	r *Redis
+	// MaxLen approximately limits the number of the messages kept in each topic stream. Zero means no limit.
+	MaxLen int64
+	// BlockTimeout is the maximum duration of the blocking stream reads. Closed subscription stops reading
+	// after at most this duration.
+	BlockTimeout time.Duration
+	// ReadCount is the maximum number of the messages read at once.
+	ReadCount int64
+}
+
+// NewStreams creates new redis streams pub/sub that uses the connection of provided redis store.
+func NewStreams(r *Redis) *Streams {
+	return &Streams{r: r, BlockTimeout: DefaultBlockTimeout, ReadCount: DefaultReadCount}
+}
+
+// Publish implements xstore.Publisher interface.
+func (s *Streams) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := s.r.checkInitialization(); err != nil {
+		return err
+	}
+	err := s.r.r.XAdd(ctx, &redis.XAddArgs{
+		Stream:       s.r.getKey(topic),
+		MaxLenApprox: s.MaxLen,
+		Values:       map[string]interface{}{payloadField: payload},
+	}).Err()
+	if err != nil {
+		return errors.Wrap(store.ErrStore, err.Error())
+	}
+	return nil
+}
+
+// Subscribe implements xstore.Subscriber interface. A subscription without the consumer group receives the messages
+// published after it was created. The consumer group is created at the first subscription if not exists.
+// If no consumer name is provided a random name is used.
+func (s *Streams) Subscribe(ctx context.Context, topic string, options ...xstore.SubscribeOption) (xstore.Subscription, error) {
+	o := xstore.NewSubscribeOptions(options...)
+	if err := s.r.checkInitialization(); err != nil {
+		return nil, err
+	}
+	sub := &streamSubscription{
+		s:       s,
+		topic:   topic,
+		key:     s.r.getKey(topic),
+		options: o,
+		block:   s.BlockTimeout,
+		out:     make(chan *xstore.Message, o.BufferSize),
+		done:    make(chan struct{}),
+	}
+	if o.Group == "" {
+		messages, err := s.r.r.XRevRangeN(ctx, sub.key, "+", "-", 1).Result()
+		if err != nil {
+			return nil, errors.Wrap(store.ErrStore, err.Error())
+		}
+		sub.lastID = "0-0"
+		if len(messages) == 1 {
+			sub.lastID = messages[0].ID
+		}
+	} else {
+		if o.Consumer == "" {
+			o.Consumer = "consumer-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+		}
+		if err := s.r.r.XGroupCreateMkStream(ctx, sub.key, o.Group, "$").Err(); err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return nil, errors.Wrap(store.ErrStore, err.Error())
+		}
+		// Check the pending messages often enough to redeliver them on time.
+		if o.AckTimeout/2 < sub.block {
+			sub.block = o.AckTimeout / 2
+		}
+	}
+	go sub.run()
+	return sub, nil
+}
+
+type streamSubscription struct {
+	s       *Streams
+	topic   string
+	key     string
+	options *xstore.SubscribeOptions
+	block   time.Duration
+	lastID  string
+
+	out       chan *xstore.Message
+	done      chan struct{}
+	closeOnce sync.Once
+	err       error
+	errMu     sync.Mutex
+}
+
+// Messages implements xstore.Subscription interface.
+func (s *streamSubscription) Messages() <-chan *xstore.Message {
+	return s.out
+}
+
+// Err implements xstore.Subscription interface.
+func (s *streamSubscription) Err() error {
+	s.errMu.Lock()
+	defer s.errMu.Unlock()
+	return s.err
+}
+
+// Close implements xstore.Subscription interface. The consumer group messages delivered, but not acknowledged
+// are redelivered to other group consumers.
+func (s *streamSubscription) Close() error {
+	err := errors.Wrap(xstore.ErrSubscriptionClosed, "subscription already closed")
+	s.closeOnce.Do(func() {
+		close(s.done)
+		err = nil
+	})
+	return err
+}
+
+func (s *streamSubscription) isClosed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *streamSubscription) run() {
+	defer close(s.out)
+
+	ctx := context.Background()
+	var lastClaim time.Time
+	for !s.isClosed() {
+		var (
+			messages []redis.XMessage
+			err      error
+		)
+		if s.options.Group != "" && time.Since(lastClaim) >= s.block {
+			lastClaim = time.Now()
+			if err = s.claim(ctx); err != nil {
+				s.fail(err)
+				return
+			}
+		}
+		if messages, err = s.read(ctx); err != nil {
+			s.fail(err)
+			return
+		}
+		for _, msg := range messages {
+			if !s.deliver(msg, 1) {
+				return
+			}
+		}
+	}
+}
+
+func (s *streamSubscription) read(ctx context.Context) ([]redis.XMessage, error) {
+	var (
+		streams []redis.XStream
+		err     error
+	)
+	if s.options.Group == "" {
+		streams, err = s.s.r.r.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{s.key, s.lastID},
+			Count:   s.s.ReadCount,
+			Block:   s.block,
+		}).Result()
+	} else {
+		streams, err = s.s.r.r.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    s.options.Group,
+			Consumer: s.options.Consumer,
+			Streams:  []string{s.key, ">"},
+			Count:    s.s.ReadCount,
+			Block:    s.block,
+		}).Result()
+	}
+	if err == redis.Nil {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var messages []redis.XMessage
+	for _, stream := range streams {
+		messages = append(messages, stream.Messages...)
+	}
+	if len(messages) > 0 && s.options.Group == "" {
+		s.lastID = messages[len(messages)-1].ID
+	}
+	return messages, nil
+}
+
+// claim claims the group messages that were not acknowledged within the timeout and delivers them again.
+func (s *streamSubscription) claim(ctx context.Context) error {
+	pending, err := s.s.r.r.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: s.key,
+		Group:  s.options.Group,
+		Start:  "-",
+		End:    "+",
+		Count:  s.s.ReadCount,
+	}).Result()
+	if err != nil {
+		return err
+	}
+	deliveries := map[string]int{}
+	var ids []string
+	for _, p := range pending {
+		if p.Idle >= s.options.AckTimeout {
+			ids = append(ids, p.ID)
+			deliveries[p.ID] = int(p.RetryCount) + 1
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	messages, err := s.s.r.r.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   s.key,
+		Group:    s.options.Group,
+		Consumer: s.options.Consumer,
+		MinIdle:  s.options.AckTimeout,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return err
+	}
+	for _, msg := range messages {
+		if !s.deliver(msg, deliveries[msg.ID]) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// deliver sends the message to the subscription channel. Returns false if the subscription was closed.
+func (s *streamSubscription) deliver(msg redis.XMessage, deliveries int) bool {
+	message := &xstore.Message{
+		ID:          msg.ID,
+		Topic:       s.topic,
+		PublishedAt: streamIDTime(msg.ID),
+		Deliveries:  deliveries,
+	}
+	if payload, ok := msg.Values[payloadField].(string); ok {
+		message.Payload = []byte(payload)
+	}
+	if s.options.Group != "" {
+		id := msg.ID
+		message.AckFunc = func(ctx context.Context) error {
+			if err := s.s.r.r.XAck(ctx, s.key, s.options.Group, id).Err(); err != nil {
+				return errors.Wrap(store.ErrStore, err.Error())
+			}
+			return nil
+		}
+	}
+	select {
+	case s.out <- message:
+		return true
+	case <-s.done:
+		return false
+	}
+}
+
+func (s *streamSubscription) fail(err error) {
+	if s.isClosed() {
+		return
+	}
+	log.Errorf("Redis stream: '%s' subscription failed: %v", s.topic, err)
+	s.errMu.Lock()
+	s.err = errors.Wrap(store.ErrStore, err.Error())
+	s.errMu.Unlock()
+}
+
+// streamIDTime gets the time encoded in the stream entry identifier.
+func streamIDTime(id string) time.Time {
+	if i := strings.IndexByte(id, '-'); i != -1 {
+		id = id[:i]
+	}
+	ms, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
@@ -0,0 +1,4 @@
+// Package tiered contains the two-tier store.Store implementation. It layers the near store i.e. the memory store,
+// in front of the far store i.e. the redis store. The near caches of multiple instances are invalidated using
+// the xstore.PubSub i.e. the redis pub/sub.
+package tiered
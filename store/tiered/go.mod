@@ -0,0 +1,17 @@
+module github.com/neuronlabs/neuron-extensions/store/tiered
+
+go 1.13
+
+require (
+	github.com/neuronlabs/neuron v0.21.6
+	github.com/neuronlabs/neuron-extensions/store/memory v0.0.0
+	github.com/neuronlabs/neuron-extensions/store/redis v0.0.0
+	github.com/neuronlabs/neuron-extensions/store/xstore v0.0.0
+	github.com/stretchr/testify v1.6.1
+)
+
+replace (
+	github.com/neuronlabs/neuron-extensions/store/memory => ../memory
+	github.com/neuronlabs/neuron-extensions/store/redis => ../redis
+	github.com/neuronlabs/neuron-extensions/store/xstore => ../xstore
+)
@@ -0,0 +1,411 @@
+package tiered
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/service"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+var (
+	_ store.Store    = &Store{}
+	_ service.Dialer = &Store{}
+	_ service.Closer = &Store{}
+)
+
+const (
+	// DefaultLocalTTL is the default maximum time to live of the near store records.
+	DefaultLocalTTL = time.Minute
+	// DefaultTopic is the default invalidation topic.
+	DefaultTopic = "nrn_store_invalidate"
+	// resubscribeInterval is the interval between the invalidation subscription retries.
+	resubscribeInterval = time.Second
+	// headerSize is the size of the near store value header that contains the far record expiration time.
+	headerSize = 8
+)
+
+// Options are the tiered store options.
+type Options struct {
+	// LocalTTL is the maximum time to live of the records in the near store. It limits the time the near store
+	// might serve the stale records i.e. when the invalidation message is lost.
+	LocalTTL time.Duration
+	// PubSub is used for the near stores invalidation between multiple instances. If it is not set, the records
+	// changed by other instances are stale for up to the LocalTTL.
+	PubSub xstore.PubSub
+	// Topic is the invalidation messages topic.
+	Topic string
+}
+
+// Option is the function that changes the tiered store options.
+type Option func(o *Options)
+
+// WithLocalTTL sets the maximum time to live of the near store records.
+func WithLocalTTL(ttl time.Duration) Option {
+	return func(o *Options) {
+		o.LocalTTL = ttl
+	}
+}
+
+// WithInvalidation sets the pub/sub used for the near stores invalidation.
+func WithInvalidation(ps xstore.PubSub) Option {
+	return func(o *Options) {
+		o.PubSub = ps
+	}
+}
+
+// WithTopic sets the invalidation messages topic.
+func WithTopic(topic string) Option {
+	return func(o *Options) {
+		o.Topic = topic
+	}
+}
+
+// invalidation is the invalidation message payload.
+type invalidation struct {
+	Source string `json:"source"`
+	Key    string `json:"key"`
+}
+
+// Store is the two-tier store.Store implementation. The records are read through the near store and written through
+// to the far store. The Find method always queries the far store. The near store should be dedicated to the tiered
+// store, as it contains the records in its own format. If the PubSub option is set, the Dial method subscribes for
+// the invalidation messages, which are published on each Set and Delete.
+type Store struct {
+	Options *Options
+	near    store.Store
+	far     store.Store
+	id      string
+
+	sub    xstore.Subscription
+	mu     sync.Mutex
+	done   chan struct{}
+	closed bool
+	wg     sync.WaitGroup
+
+	versions   map[string]*keyVersion
+	versionsMu sync.Mutex
+}
+
+// keyVersion is the invalidation version of the key that is currently read from the far store.
+type keyVersion struct {
+	readers int
+	version uint64
+}
+
+// New creates new tiered store with the 'near' store layered in front of the 'far' store.
+func New(near, far store.Store, options ...Option) (*Store, error) {
+	if near == nil || far == nil {
+		return nil, errors.Wrap(store.ErrInitialization, "both near and far stores are required")
+	}
+	s := &Store{
+		Options:  &Options{LocalTTL: DefaultLocalTTL, Topic: DefaultTopic},
+		near:     near,
+		far:      far,
+		done:     make(chan struct{}),
+		versions: map[string]*keyVersion{},
+	}
+	for _, option := range options {
+		option(s.Options)
+	}
+	if s.Options.LocalTTL <= 0 {
+		return nil, errors.Wrap(store.ErrInitialization, "local ttl must be greater than zero")
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Wrapf(store.ErrInitialization, "generating instance id failed: %v", err)
+	}
+	s.id = hex.EncodeToString(id)
+	return s, nil
+}
+
+// Dial implements service.Dialer interface. It dials both stores if they implement service.Dialer and subscribes
+// for the invalidation messages.
+func (s *Store) Dial(ctx context.Context) error {
+	for _, st := range []store.Store{s.far, s.near} {
+		if dialer, ok := st.(service.Dialer); ok {
+			if err := dialer.Dial(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if s.Options.PubSub == nil {
+		return nil
+	}
+	if err := s.subscribe(ctx); err != nil {
+		return err
+	}
+	s.wg.Add(1)
+	go s.invalidate()
+	return nil
+}
+
+// Close implements service.Closer interface. It stops the invalidation subscription and closes both stores
+// if they implement service.Closer.
+func (s *Store) Close(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+		if s.sub != nil {
+			_ = s.sub.Close()
+		}
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+
+	for _, st := range []store.Store{s.near, s.far} {
+		if closer, ok := st.(service.Closer); ok {
+			if err := closer.Close(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Set implements store.Store interface. The record is stored in the far store and then in the near store.
+func (s *Store) Set(ctx context.Context, record *store.Record, options ...store.SetOption) error {
+	if err := s.far.Set(ctx, record, options...); err != nil {
+		return err
+	}
+	s.publish(ctx, record.Key)
+	s.invalidateVersion(record.Key)
+	o := &store.SetOptions{}
+	for _, option := range options {
+		option(o)
+	}
+	s.setNear(ctx, record, o.TTL)
+	return nil
+}
+
+// Get implements store.Store interface. If the record is not found in the near store, it is read from the far store
+// and stored in the near one.
+func (s *Store) Get(ctx context.Context, key string) (*store.Record, error) {
+	nearRecord, err := s.near.Get(ctx, key)
+	if err == nil && len(nearRecord.Value) >= headerSize {
+		record := &store.Record{Key: key, Value: make([]byte, len(nearRecord.Value)-headerSize)}
+		copy(record.Value, nearRecord.Value[headerSize:])
+		if expiresAt := binary.BigEndian.Uint64(nearRecord.Value); expiresAt > 0 {
+			record.ExpiresAt = time.Unix(0, int64(expiresAt))
+		}
+		return record, nil
+	}
+	if err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+		log.Warningf("Tiered store - getting near record: '%s' failed: %v", key, err)
+	}
+	kv, version := s.readVersion(key)
+	defer s.releaseVersion(key, kv)
+	record, err := s.far.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	// The record read from the far store might be already stale if it was invalidated in the meantime.
+	if s.isInvalidated(kv, version) {
+		return record, nil
+	}
+	s.setNear(ctx, record, 0)
+	if s.isInvalidated(kv, version) {
+		s.deleteNear(ctx, key)
+	}
+	return record, nil
+}
+
+// Delete implements store.Store interface.
+func (s *Store) Delete(ctx context.Context, key string) error {
+	err := s.far.Delete(ctx, key)
+	if err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+		return err
+	}
+	s.invalidateVersion(key)
+	s.deleteNear(ctx, key)
+	s.publish(ctx, key)
+	return err
+}
+
+// Find implements store.Store interface. The records are always found in the far store.
+func (s *Store) Find(ctx context.Context, options ...store.FindOption) ([]*store.Record, error) {
+	return s.far.Find(ctx, options...)
+}
+
+// setNear stores the 'record' in the near store. The record expires at the far record expiration time,
+// but not later than after the local ttl. The far record expiration time is set by the 'ttl' if it is
+// greater than zero, otherwise it is taken from the record.
+func (s *Store) setNear(ctx context.Context, record *store.Record, ttl time.Duration) {
+	expiresAt := record.ExpiresAt
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	nearTTL := s.Options.LocalTTL
+	if !expiresAt.IsZero() {
+		if until := time.Until(expiresAt); until < nearTTL {
+			nearTTL = until
+		}
+	}
+	if nearTTL <= 0 {
+		return
+	}
+	value := make([]byte, headerSize+len(record.Value))
+	if !expiresAt.IsZero() {
+		binary.BigEndian.PutUint64(value, uint64(expiresAt.UnixNano()))
+	}
+	copy(value[headerSize:], record.Value)
+	if err := s.near.Set(ctx, &store.Record{Key: record.Key, Value: value}, store.SetWithTTL(nearTTL)); err != nil {
+		log.Warningf("Tiered store - setting near record: '%s' failed: %v", record.Key, err)
+	}
+}
+
+func (s *Store) deleteNear(ctx context.Context, key string) {
+	if err := s.near.Delete(ctx, key); err != nil && !errors.Is(err, store.ErrRecordNotFound) {
+		log.Warningf("Tiered store - deleting near record: '%s' failed: %v", key, err)
+	}
+}
+
+// clearNear deletes all the records from the near store.
+func (s *Store) clearNear(ctx context.Context) {
+	s.invalidateVersions()
+	records, err := s.near.Find(ctx)
+	if err != nil {
+		log.Errorf("Tiered store - finding near records failed: %v", err)
+		return
+	}
+	for _, record := range records {
+		s.deleteNear(ctx, record.Key)
+	}
+}
+
+// readVersion registers the far store read of the 'key' and gets its current invalidation version.
+// The version needs to be released after the read.
+func (s *Store) readVersion(key string) (*keyVersion, uint64) {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	kv, ok := s.versions[key]
+	if !ok {
+		kv = &keyVersion{}
+		s.versions[key] = kv
+	}
+	kv.readers++
+	return kv, kv.version
+}
+
+// releaseVersion releases the far store read of the 'key'.
+func (s *Store) releaseVersion(key string, kv *keyVersion) {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	kv.readers--
+	if kv.readers == 0 {
+		delete(s.versions, key)
+	}
+}
+
+// isInvalidated checks if the key was invalidated since its 'version' was read.
+func (s *Store) isInvalidated(kv *keyVersion, version uint64) bool {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	return kv.version != version
+}
+
+// invalidateVersion changes the invalidation version of the 'key' if it is currently read from the far store.
+// It needs to be called before the near record is changed or deleted.
+func (s *Store) invalidateVersion(key string) {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	if kv, ok := s.versions[key]; ok {
+		kv.version++
+	}
+}
+
+// invalidateVersions changes the invalidation versions of all the keys currently read from the far store.
+func (s *Store) invalidateVersions() {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	for _, kv := range s.versions {
+		kv.version++
+	}
+}
+
+// publish publishes the invalidation message for the 'key'.
+func (s *Store) publish(ctx context.Context, key string) {
+	if s.Options.PubSub == nil {
+		return
+	}
+	payload, err := json.Marshal(invalidation{Source: s.id, Key: key})
+	if err != nil {
+		log.Errorf("Tiered store - marshaling invalidation failed: %v", err)
+		return
+	}
+	if err = s.Options.PubSub.Publish(ctx, s.Options.Topic, payload); err != nil {
+		log.Errorf("Tiered store - publishing invalidation of: '%s' failed: %v", key, err)
+	}
+}
+
+func (s *Store) subscribe(ctx context.Context) error {
+	sub, err := s.Options.PubSub.Subscribe(ctx, s.Options.Topic)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		_ = sub.Close()
+		return xstore.ErrSubscriptionClosed
+	}
+	s.sub = sub
+	return nil
+}
+
+// invalidate deletes the near records invalidated by other instances. If the subscription fails, the near store
+// is cleared - as some invalidation messages might be lost - and the store subscribes again.
+func (s *Store) invalidate() {
+	defer s.wg.Done()
+	ctx := context.Background()
+	for {
+		s.mu.Lock()
+		sub := s.sub
+		s.mu.Unlock()
+		for msg := range sub.Messages() {
+			inv := invalidation{}
+			if err := json.Unmarshal(msg.Payload, &inv); err != nil {
+				log.Warningf("Tiered store - malformed invalidation message: %v", err)
+				continue
+			}
+			if inv.Source != s.id {
+				s.invalidateVersion(inv.Key)
+				s.deleteNear(ctx, inv.Key)
+			}
+		}
+		select {
+		case <-s.done:
+			return
+		default:
+		}
+		log.Errorf("Tiered store - invalidation subscription failed: %v", sub.Err())
+		s.clearNear(ctx)
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-time.After(resubscribeInterval):
+			}
+			err := s.subscribe(ctx)
+			if err == nil {
+				break
+			}
+			if errors.Is(err, xstore.ErrSubscriptionClosed) {
+				return
+			}
+			log.Errorf("Tiered store - subscribing for invalidation failed: %v", err)
+		}
+		// Records might have been changed while the subscription was down.
+		s.clearNear(ctx)
+	}
+}
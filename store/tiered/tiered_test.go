@@ -0,0 +1,198 @@
+package tiered
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+
+	"github.com/neuronlabs/neuron-extensions/store/memory"
+	"github.com/neuronlabs/neuron-extensions/store/redis"
+	"github.com/neuronlabs/neuron-extensions/store/redis/redistest"
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+	"github.com/neuronlabs/neuron-extensions/store/xstore/storetest"
+)
+
+func testingMemory(t *testing.T, options ...store.Option) *memory.Memory {
+	m, err := memory.New(options...)
+	require.NoError(t, err)
+	return m
+}
+
+// testingStore creates and dials new tiered store with the memory near store and provided 'far' store.
+func testingStore(t *testing.T, far store.Store, options ...Option) *Store {
+	s, err := New(testingMemory(t), far, options...)
+	require.NoError(t, err)
+	require.NoError(t, s.Dial(context.Background()))
+	t.Cleanup(func() { _ = s.Close(context.Background()) })
+	return s
+}
+
+func TestStore(t *testing.T) {
+	storetest.TestStore(t, func(t *testing.T, options ...store.Option) store.Store {
+		return testingStore(t, testingMemory(t, options...))
+	})
+}
+
+func TestReadThrough(t *testing.T) {
+	ctx := context.Background()
+	far := testingMemory(t)
+	s := testingStore(t, far, WithLocalTTL(50*time.Millisecond))
+
+	require.NoError(t, far.Set(ctx, &store.Record{Key: "key", Value: []byte("first")}, store.SetWithTTL(time.Minute)))
+	record, err := s.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), record.Value)
+
+	// Without the invalidation the near record is stale until the local ttl passes.
+	require.NoError(t, far.Set(ctx, &store.Record{Key: "key", Value: []byte("second")}, store.SetWithTTL(time.Minute)))
+	record, err = s.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first"), record.Value)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), record.ExpiresAt, time.Second)
+
+	time.Sleep(100 * time.Millisecond)
+	record, err = s.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), record.Value)
+
+	require.NoError(t, far.Delete(ctx, "key"))
+	time.Sleep(100 * time.Millisecond)
+	_, err = s.Get(ctx, "key")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+}
+
+func TestSetTTL(t *testing.T) {
+	ctx := context.Background()
+	server, err := redistest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = server.Close() })
+	far, err := redis.New(store.WithConnectionURL(server.URL()))
+	require.NoError(t, err)
+	s := testingStore(t, far)
+
+	// The near record expires with the ttl provided for the far one, even if the record has no expiration time set.
+	require.NoError(t, s.Set(ctx, &store.Record{Key: "key", Value: []byte("value")}, store.SetWithTTL(50*time.Millisecond)))
+	record, err := s.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(50*time.Millisecond), record.ExpiresAt, 50*time.Millisecond)
+
+	time.Sleep(100 * time.Millisecond)
+	_, err = s.near.Get(ctx, "key")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+	_, err = s.Get(ctx, "key")
+	assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+}
+
+func TestInvalidation(t *testing.T) {
+	for name, stores := range map[string]func(t *testing.T) (first, second store.Store, ps xstore.PubSub){
+		"Memory": func(t *testing.T) (store.Store, store.Store, xstore.PubSub) {
+			far := testingMemory(t)
+			ps := memory.NewPubSub()
+			t.Cleanup(func() { _ = ps.Close(context.Background()) })
+			return far, far, ps
+		},
+		"Redis": func(t *testing.T) (store.Store, store.Store, xstore.PubSub) {
+			server, err := redistest.NewServer()
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = server.Close() })
+			var stores []*redis.Redis
+			for i := 0; i < 2; i++ {
+				r, err := redis.New(store.WithConnectionURL(server.URL()))
+				require.NoError(t, err)
+				require.NoError(t, r.Dial(context.Background()))
+				stores = append(stores, r)
+			}
+			return stores[0], stores[1], redis.NewPubSub(stores[0])
+		},
+	} {
+		stores := stores
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			firstFar, secondFar, ps := stores(t)
+			first := testingStore(t, firstFar, WithInvalidation(ps))
+			second := testingStore(t, secondFar, WithInvalidation(ps))
+
+			require.NoError(t, first.Set(ctx, &store.Record{Key: "key", Value: []byte("first")}))
+			record, err := second.Get(ctx, "key")
+			require.NoError(t, err)
+			assert.Equal(t, []byte("first"), record.Value)
+
+			require.NoError(t, first.Set(ctx, &store.Record{Key: "key", Value: []byte("second")}))
+			assert.Eventually(t, func() bool {
+				record, err := second.Get(ctx, "key")
+				return err == nil && string(record.Value) == "second"
+			}, time.Second, 10*time.Millisecond)
+
+			// The instance doesn't invalidate its own near records.
+			record, err = first.near.Get(ctx, "key")
+			require.NoError(t, err)
+
+			require.NoError(t, first.Delete(ctx, "key"))
+			assert.Eventually(t, func() bool {
+				_, err := second.Get(ctx, "key")
+				return errors.Is(err, store.ErrRecordNotFound)
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+}
+
+// getHookStore calls the 'afterGet' function after the record is read, and before it is returned.
+type getHookStore struct {
+	store.Store
+	afterGet func()
+}
+
+func (g *getHookStore) Get(ctx context.Context, key string) (*store.Record, error) {
+	record, err := g.Store.Get(ctx, key)
+	if g.afterGet != nil {
+		afterGet := g.afterGet
+		g.afterGet = nil
+		afterGet()
+	}
+	return record, err
+}
+
+func TestInvalidationDuringRead(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Set", func(t *testing.T) {
+		far := &getHookStore{Store: testingMemory(t)}
+		s := testingStore(t, far)
+		require.NoError(t, far.Set(ctx, &store.Record{Key: "key", Value: []byte("first")}))
+
+		// The record is changed after it was read from the far store.
+		far.afterGet = func() {
+			require.NoError(t, s.Set(ctx, &store.Record{Key: "key", Value: []byte("second")}))
+		}
+		record, err := s.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("first"), record.Value)
+
+		// The stale record doesn't replace the near one.
+		record, err = s.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("second"), record.Value)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		far := &getHookStore{Store: testingMemory(t)}
+		s := testingStore(t, far)
+		require.NoError(t, far.Set(ctx, &store.Record{Key: "key", Value: []byte("first")}))
+
+		far.afterGet = func() {
+			require.NoError(t, s.Delete(ctx, "key"))
+		}
+		_, err := s.Get(ctx, "key")
+		require.NoError(t, err)
+
+		_, err = s.near.Get(ctx, "key")
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+		assert.Empty(t, s.versions)
+	})
+}
@@ -0,0 +1,41 @@
+package xstore
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+
+	"github.com/neuronlabs/neuron/store"
+)
+
+// AtomicStore is the store extension that provides atomic primitives used i.e. by the rate limiters, lockouts
+// and idempotency keys.
+type AtomicStore interface {
+	store.Store
+	// SetIfNotExists sets the record only if there is no record stored with its key.
+	// Returns true if the record was set.
+	SetIfNotExists(ctx context.Context, record *store.Record, options ...store.SetOption) (bool, error)
+	// CompareAndSwap replaces the record only if the version of currently stored value is equal to provided 'version'.
+	// Returns true if the record was swapped. If there is no record stored with given key the function returns
+	// store.ErrRecordNotFound error.
+	CompareAndSwap(ctx context.Context, record *store.Record, version Version, options ...store.SetOption) (bool, error)
+	// Increment atomically increments the integer counter stored with provided 'key' by the 'delta' and returns
+	// its new value. A negative 'delta' decrements the counter. If the counter doesn't exists it is created with
+	// the TTL from the options, which is not changed by later increments. The counter value is stored as
+	// a decimal string. If the stored value is not an integer the ErrNotCounter error is returned.
+	Increment(ctx context.Context, key string, delta int64, options ...store.SetOption) (int64, error)
+}
+
+// Version is the version of the record value used for the compare and swap operations.
+type Version string
+
+// ValueVersion gets the version of provided record 'value'. It is a hex encoded SHA-1 sum of the value.
+func ValueVersion(value []byte) Version {
+	sum := sha1.Sum(value)
+	return Version(hex.EncodeToString(sum[:]))
+}
+
+// RecordVersion gets the version of the record value.
+func RecordVersion(record *store.Record) Version {
+	return ValueVersion(record.Value)
+}
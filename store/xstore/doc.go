@@ -0,0 +1,3 @@
+// Package xstore contains the extension interfaces for the neuron key-value stores along with their helpers.
+// The stores implements these interfaces optionally, thus the users should check if the store.Store implements it.
+package xstore
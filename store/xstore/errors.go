@@ -0,0 +1,19 @@
+package xstore
+
+import (
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+)
+
+var (
+	// ErrNotCounter is the error returned when the value of the record is not a valid integer counter.
+	ErrNotCounter = errors.Wrap(store.ErrStore, "record value is not a counter")
+	// ErrGroupsNotSupported is the error returned when the subscriber doesn't support the consumer groups.
+	ErrGroupsNotSupported = errors.Wrap(store.ErrStore, "consumer groups not supported")
+	// ErrSubscriptionClosed is the error returned when the subscription or the publisher is already closed.
+	ErrSubscriptionClosed = errors.Wrap(store.ErrStore, "subscription closed")
+	// ErrLockHeld is the error returned when the lock is already held by another owner.
+	ErrLockHeld = errors.Wrap(store.ErrStore, "lock held by another owner")
+	// ErrLockNotHeld is the error returned when the lock expired or was acquired by another owner.
+	ErrLockNotHeld = errors.Wrap(store.ErrStore, "lock not held")
+)
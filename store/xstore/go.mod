@@ -0,0 +1,8 @@
+module github.com/neuronlabs/neuron-extensions/store/xstore
+
+go 1.13
+
+require (
+	github.com/neuronlabs/neuron v0.21.6
+	github.com/stretchr/testify v1.4.0
+)
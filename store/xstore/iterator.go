@@ -0,0 +1,37 @@
+package xstore
+
+import (
+	"context"
+
+	"github.com/neuronlabs/neuron/store"
+)
+
+// IterableStore is the store extension that allows to stream the records matching the find options,
+// without loading all of them into the memory.
+type IterableStore interface {
+	store.Store
+	// Iterate creates new iterator over the records matching provided find 'options'.
+	Iterate(ctx context.Context, options ...store.FindOption) Iterator
+}
+
+// Iterator is the store records iterator. The records are fetched lazily in batches.
+//
+//	it := s.Iterate(ctx, store.FindWithPrefix("tokens_"))
+//	defer it.Close()
+//	for it.Next(ctx) {
+//		record := it.Record()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator interface {
+	// Next prepares next record to be taken with the Record method. Returns false if there is no more records
+	// or an error occurred.
+	Next(ctx context.Context) bool
+	// Record gets current iterator record.
+	Record() *store.Record
+	// Err gets the error that occurred during the iteration.
+	Err() error
+	// Close releases the iterator resources.
+	Close() error
+}
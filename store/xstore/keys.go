@@ -0,0 +1,8 @@
+package xstore
+
+// HashTag prepends the hash tag to provided 'key'. The stores that partition the keys, i.e. the redis cluster,
+// keep the keys with the same 'tag' in the same partition, so that these could be used together within the scripts
+// and transactions. For the other stores the tag is just a part of the key.
+func HashTag(tag, key string) string {
+	return "{" + tag + "}" + key
+}
@@ -0,0 +1,111 @@
+package xstore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/log"
+	"github.com/neuronlabs/neuron/store"
+)
+
+// Locker is the distributed lock provider. It allows i.e. the scheduled jobs or the repository migrations
+// running in multiple service replicas not to be executed concurrently.
+type Locker interface {
+	// Acquire acquires the lock with provided 'name' for the 'ttl' duration. If the lock is held by another owner
+	// the ErrLockHeld error is returned.
+	Acquire(ctx context.Context, name string, ttl time.Duration) (Lock, error)
+}
+
+// Lock is the acquired distributed lock.
+type Lock interface {
+	// Name gets the lock name.
+	Name() string
+	// Token gets the lock ownership token. It is unique for each lock acquisition.
+	Token() string
+	// Fence gets the fencing counter of the lock. It increases with each acquisition of the lock with given name,
+	// so that the resources protected by the lock could reject the operations with outdated fence.
+	Fence() int64
+	// Refresh extends the lock expiration to the 'ttl' from now. If the lock expired or is held by another owner
+	// the ErrLockNotHeld error is returned.
+	Refresh(ctx context.Context, ttl time.Duration) error
+	// Release releases the lock. If the lock expired or is held by another owner the ErrLockNotHeld error is returned.
+	Release(ctx context.Context) error
+}
+
+// AcquireWait tries to acquire the lock with the 'name' every 'retry' interval until it is acquired
+// or the context is done.
+func AcquireWait(ctx context.Context, locker Locker, name string, ttl, retry time.Duration) (Lock, error) {
+	for {
+		lock, err := locker.Acquire(ctx, name, ttl)
+		if err == nil {
+			return lock, nil
+		}
+		if !errors.Is(err, ErrLockHeld) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, errors.Wrapf(ErrLockHeld, "waiting for the lock: '%s' failed: %v", name, ctx.Err())
+		case <-time.After(retry):
+		}
+	}
+}
+
+// WithLock acquires the lock with the 'name' and executes the function 'fn' while holding it. The lock is refreshed
+// every third of the 'ttl' and released when the function is done. If the lock is lost during the execution
+// the function context is canceled. If the lock is held by another owner the ErrLockHeld error is returned.
+// The 'ttl' which is too short to be divided into the refresh periods is rejected.
+//
+//	err := xstore.WithLock(ctx, locker, "migrations", time.Minute, func(ctx context.Context, _ xstore.Lock) error {
+//		return db.MigrateModels(ctx, models...)
+//	})
+func WithLock(ctx context.Context, locker Locker, name string, ttl time.Duration, fn func(ctx context.Context, lock Lock) error) error {
+	if ttl/3 <= 0 {
+		return errors.Wrapf(store.ErrInternal, "lock: '%s' ttl: '%s' is too short to be refreshed", name, ttl)
+	}
+	lock, err := locker.Acquire(ctx, name, ttl)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	refreshed := make(chan struct{})
+	go func() {
+		defer close(refreshed)
+		ticker := time.NewTicker(ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := lock.Refresh(ctx, ttl); err != nil {
+					log.Errorf("Refreshing lock: '%s' failed: %v", name, err)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+	err = fn(ctx, lock)
+	cancel()
+	<-refreshed
+
+	if releaseErr := lock.Release(context.Background()); releaseErr != nil && err == nil {
+		err = releaseErr
+	}
+	return err
+}
+
+// NewLockToken creates new random lock ownership token.
+func NewLockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrapf(store.ErrInternal, "generating lock token failed: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
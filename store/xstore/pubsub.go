@@ -0,0 +1,113 @@
+package xstore
+
+import (
+	"context"
+	"time"
+)
+
+// Publisher publishes the messages on the topics.
+type Publisher interface {
+	// Publish publishes the message with the 'payload' on the 'topic'.
+	Publish(ctx context.Context, topic string, payload []byte) error
+}
+
+// Subscriber subscribes for the topic messages.
+type Subscriber interface {
+	// Subscribe creates new subscription for the 'topic' messages. Without the consumer group option each subscription
+	// receives all the messages published after the subscription was created. With the consumer group each message
+	// is delivered to a single consumer of the group and it needs to be acknowledged - otherwise it is redelivered
+	// after the acknowledge timeout.
+	Subscribe(ctx context.Context, topic string, options ...SubscribeOption) (Subscription, error)
+}
+
+// PubSub is the messaging extension used next to the store.Store. It allows i.e. to emit the domain events
+// from the model hooks to other services.
+type PubSub interface {
+	Publisher
+	Subscriber
+}
+
+// Subscription is the topic subscription.
+type Subscription interface {
+	// Messages gets the channel of the subscribed messages. The channel is closed when the subscription is closed
+	// or failed.
+	Messages() <-chan *Message
+	// Err gets the error that stopped the subscription.
+	Err() error
+	// Close closes the subscription.
+	Close() error
+}
+
+// Message is the message received by the subscription.
+type Message struct {
+	// ID is the message identifier. For the consumer groups it is unique within the topic.
+	ID string
+	// Topic is the topic the message was published on.
+	Topic string
+	// Payload is the message content. It should not be modified as it might be shared between the subscriptions.
+	Payload []byte
+	// PublishedAt is the time when the message was published.
+	PublishedAt time.Time
+	// Deliveries is the number of the message deliveries. It is greater than one for redelivered consumer group messages.
+	Deliveries int
+	// AckFunc is the acknowledge function set by the implementations for the consumer group messages.
+	AckFunc func(ctx context.Context) error
+}
+
+// Ack acknowledges the message, so that it would not be redelivered. For the messages without the consumer
+// group it is a no-op.
+func (m *Message) Ack(ctx context.Context) error {
+	if m.AckFunc == nil {
+		return nil
+	}
+	return m.AckFunc(ctx)
+}
+
+// DefaultAckTimeout is the default consumer group message acknowledge timeout.
+const DefaultAckTimeout = 30 * time.Second
+
+// SubscribeOptions are the options for the subscription.
+type SubscribeOptions struct {
+	// Group is the consumer group name.
+	Group string
+	// Consumer is the name of the consumer within the group.
+	Consumer string
+	// AckTimeout is the duration after which not acknowledged consumer group message is redelivered.
+	AckTimeout time.Duration
+	// BufferSize is the size of the subscription messages channel buffer.
+	BufferSize int
+}
+
+// SubscribeOption is the function that changes subscribe options.
+type SubscribeOption func(o *SubscribeOptions)
+
+// NewSubscribeOptions creates new subscribe options with the defaults and applies provided 'options'.
+func NewSubscribeOptions(options ...SubscribeOption) *SubscribeOptions {
+	o := &SubscribeOptions{AckTimeout: DefaultAckTimeout, BufferSize: 64}
+	for _, option := range options {
+		option(o)
+	}
+	return o
+}
+
+// SubscribeGroup sets the consumer 'group' and the 'consumer' name for the subscription.
+func SubscribeGroup(group, consumer string) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.Group = group
+		o.Consumer = consumer
+	}
+}
+
+// SubscribeAckTimeout sets the consumer group messages acknowledge timeout.
+func SubscribeAckTimeout(timeout time.Duration) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.AckTimeout = timeout
+	}
+}
+
+// SubscribeBufferSize sets the subscription messages channel buffer size.
+func SubscribeBufferSize(size int) SubscribeOption {
+	return func(o *SubscribeOptions) {
+		o.BufferSize = size
+	}
+}
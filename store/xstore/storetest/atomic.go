@@ -0,0 +1,181 @@
+package storetest
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// AtomicFactory creates new, empty xstore.AtomicStore for the test.
+type AtomicFactory func(t *testing.T) xstore.AtomicStore
+
+// TestAtomic runs the conformance test suite for the xstore.AtomicStore implementation.
+func TestAtomic(t *testing.T, factory AtomicFactory) {
+	t.Run("SetIfNotExists", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		set, err := s.SetIfNotExists(ctx, &store.Record{Key: "nx", Value: []byte("first")})
+		require.NoError(t, err)
+		assert.True(t, set)
+
+		set, err = s.SetIfNotExists(ctx, &store.Record{Key: "nx", Value: []byte("second")})
+		require.NoError(t, err)
+		assert.False(t, set)
+
+		record, err := s.Get(ctx, "nx")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("first"), record.Value)
+	})
+
+	t.Run("SetIfNotExistsExpired", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		set, err := s.SetIfNotExists(ctx, &store.Record{Key: "nx-ttl", Value: []byte("first")}, store.SetWithTTL(50*time.Millisecond))
+		require.NoError(t, err)
+		require.True(t, set)
+
+		time.Sleep(100 * time.Millisecond)
+
+		_, err = s.Get(ctx, "nx-ttl")
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+
+		set, err = s.SetIfNotExists(ctx, &store.Record{Key: "nx-ttl", Value: []byte("second")})
+		require.NoError(t, err)
+		assert.True(t, set)
+	})
+
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		_, err := s.CompareAndSwap(ctx, &store.Record{Key: "cas", Value: []byte("v1")}, xstore.ValueVersion(nil))
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "cas", Value: []byte("v1")}))
+		record, err := s.Get(ctx, "cas")
+		require.NoError(t, err)
+		version := xstore.RecordVersion(record)
+
+		swapped, err := s.CompareAndSwap(ctx, &store.Record{Key: "cas", Value: []byte("v2")}, version)
+		require.NoError(t, err)
+		assert.True(t, swapped)
+
+		// The version is outdated now.
+		swapped, err = s.CompareAndSwap(ctx, &store.Record{Key: "cas", Value: []byte("v3")}, version)
+		require.NoError(t, err)
+		assert.False(t, swapped)
+
+		record, err = s.Get(ctx, "cas")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("v2"), record.Value)
+	})
+
+	t.Run("CompareAndSwapConcurrent", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "cas-concurrent", Value: []byte("0")}))
+		const workers = 10
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			swapped int
+		)
+		version := xstore.ValueVersion([]byte("0"))
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func(i int) {
+				defer wg.Done()
+				ok, err := s.CompareAndSwap(ctx, &store.Record{Key: "cas-concurrent", Value: []byte(strconv.Itoa(i + 1))}, version)
+				assert.NoError(t, err)
+				if ok {
+					mu.Lock()
+					swapped++
+					mu.Unlock()
+				}
+			}(i)
+		}
+		wg.Wait()
+		assert.Equal(t, 1, swapped)
+	})
+
+	t.Run("Increment", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		value, err := s.Increment(ctx, "counter", 5)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), value)
+
+		value, err = s.Increment(ctx, "counter", -2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), value)
+
+		record, err := s.Get(ctx, "counter")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("3"), record.Value)
+
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "counter", Value: []byte("10")}))
+		value, err = s.Increment(ctx, "counter", 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), value)
+
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "counter", Value: []byte("not a number")}))
+		_, err = s.Increment(ctx, "counter", 1)
+		assert.True(t, errors.Is(err, xstore.ErrNotCounter))
+	})
+
+	t.Run("IncrementExpired", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		value, err := s.Increment(ctx, "counter-ttl", 1, store.SetWithTTL(50*time.Millisecond))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), value)
+
+		// The ttl is not changed by the following increments.
+		value, err = s.Increment(ctx, "counter-ttl", 1, store.SetWithTTL(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), value)
+
+		time.Sleep(100 * time.Millisecond)
+
+		value, err = s.Increment(ctx, "counter-ttl", 1, store.SetWithTTL(50*time.Millisecond))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), value)
+	})
+
+	t.Run("IncrementConcurrent", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		const workers, increments = 20, 50
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				for j := 0; j < increments; j++ {
+					_, err := s.Increment(ctx, "counter-concurrent", 1)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		record, err := s.Get(ctx, "counter-concurrent")
+		require.NoError(t, err)
+		assert.Equal(t, []byte(strconv.Itoa(workers*increments)), record.Value)
+	})
+}
@@ -0,0 +1,3 @@
+// Package storetest contains the conformance test suites for the neuron key-value stores and its extensions.
+// The store implementations should run the suites within their own tests.
+package storetest
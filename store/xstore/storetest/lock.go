@@ -0,0 +1,151 @@
+package storetest
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// LockerFactory creates new xstore.Locker for the test.
+type LockerFactory func(t *testing.T) xstore.Locker
+
+// TestLocker runs the conformance test suite for the xstore.Locker implementation.
+func TestLocker(t *testing.T, factory LockerFactory) {
+	t.Run("AcquireRelease", func(t *testing.T) {
+		locker := factory(t)
+		ctx := context.Background()
+
+		lock, err := locker.Acquire(ctx, "job", time.Minute)
+		require.NoError(t, err)
+		assert.Equal(t, "job", lock.Name())
+		assert.NotEmpty(t, lock.Token())
+
+		_, err = locker.Acquire(ctx, "job", time.Minute)
+		assert.True(t, errors.Is(err, xstore.ErrLockHeld))
+
+		other, err := locker.Acquire(ctx, "other-job", time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, other.Release(ctx))
+
+		require.NoError(t, lock.Release(ctx))
+		err = lock.Release(ctx)
+		assert.True(t, errors.Is(err, xstore.ErrLockNotHeld))
+
+		next, err := locker.Acquire(ctx, "job", time.Minute)
+		require.NoError(t, err)
+		assert.NotEqual(t, lock.Token(), next.Token())
+		assert.True(t, next.Fence() > lock.Fence(), "fence should increase")
+		require.NoError(t, next.Release(ctx))
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		locker := factory(t)
+		ctx := context.Background()
+
+		lock, err := locker.Acquire(ctx, "job", 50*time.Millisecond)
+		require.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+
+		next, err := locker.Acquire(ctx, "job", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, next.Fence() > lock.Fence(), "fence should increase")
+
+		// The expired lock owner could not refresh nor release the lock of the new owner.
+		assert.True(t, errors.Is(lock.Refresh(ctx, time.Minute), xstore.ErrLockNotHeld))
+		assert.True(t, errors.Is(lock.Release(ctx), xstore.ErrLockNotHeld))
+		require.NoError(t, next.Release(ctx))
+	})
+
+	t.Run("Refresh", func(t *testing.T) {
+		locker := factory(t)
+		ctx := context.Background()
+
+		lock, err := locker.Acquire(ctx, "job", 100*time.Millisecond)
+		require.NoError(t, err)
+		require.NoError(t, lock.Refresh(ctx, time.Minute))
+		time.Sleep(150 * time.Millisecond)
+
+		_, err = locker.Acquire(ctx, "job", time.Minute)
+		assert.True(t, errors.Is(err, xstore.ErrLockHeld))
+		require.NoError(t, lock.Release(ctx))
+	})
+
+	t.Run("MutualExclusion", func(t *testing.T) {
+		locker := factory(t)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		const workers = 10
+		var (
+			wg       sync.WaitGroup
+			holders  int32
+			overlaps int32
+			mu       sync.Mutex
+			fences   []int64
+		)
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				lock, err := xstore.AcquireWait(ctx, locker, "job", time.Minute, 5*time.Millisecond)
+				if !assert.NoError(t, err) {
+					return
+				}
+				if atomic.AddInt32(&holders, 1) > 1 {
+					atomic.AddInt32(&overlaps, 1)
+				}
+				mu.Lock()
+				fences = append(fences, lock.Fence())
+				mu.Unlock()
+				time.Sleep(2 * time.Millisecond)
+				atomic.AddInt32(&holders, -1)
+				assert.NoError(t, lock.Release(ctx))
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(0), overlaps)
+		// The fences are increasing in the order of the acquisitions.
+		for i := 1; i < len(fences); i++ {
+			assert.True(t, fences[i] > fences[i-1], "fences: %v", fences)
+		}
+	})
+
+	t.Run("WithLock", func(t *testing.T) {
+		locker := factory(t)
+		ctx := context.Background()
+
+		var executed bool
+		err := xstore.WithLock(ctx, locker, "migrations", 60*time.Millisecond, func(ctx context.Context, lock xstore.Lock) error {
+			executed = true
+			// The lock is refreshed while the function is executed.
+			time.Sleep(150 * time.Millisecond)
+			_, err := locker.Acquire(ctx, "migrations", time.Minute)
+			assert.True(t, errors.Is(err, xstore.ErrLockHeld))
+			return ctx.Err()
+		})
+		require.NoError(t, err)
+		assert.True(t, executed)
+
+		lock, err := locker.Acquire(ctx, "migrations", time.Minute)
+		require.NoError(t, err)
+		require.NoError(t, lock.Release(ctx))
+
+		// The ttl which could not be refreshed is rejected without acquiring the lock.
+		for _, ttl := range []time.Duration{0, -time.Second, 2 * time.Nanosecond} {
+			err = xstore.WithLock(ctx, locker, "migrations", ttl, func(context.Context, xstore.Lock) error {
+				t.Error("function executed with invalid ttl")
+				return nil
+			})
+			assert.True(t, errors.Is(err, store.ErrInternal), ttl)
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package storetest
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/neuronlabs/neuron-extensions/store/xstore"
+)
+
+// PubSubFactory creates new xstore.PubSub for the test.
+type PubSubFactory func(t *testing.T) xstore.PubSub
+
+// receiveTimeout is the maximum time of waiting for the message.
+const receiveTimeout = 2 * time.Second
+
+// TestPubSub runs the conformance test suite for the xstore.PubSub implementation without the consumer groups.
+func TestPubSub(t *testing.T, factory PubSubFactory) {
+	t.Run("Broadcast", func(t *testing.T) {
+		ps := factory(t)
+		ctx := context.Background()
+
+		first := subscribe(t, ps, "events")
+		second := subscribe(t, ps, "events")
+		other := subscribe(t, ps, "other")
+
+		require.NoError(t, ps.Publish(ctx, "events", []byte("registered")))
+		for _, sub := range []xstore.Subscription{first, second} {
+			msg := receive(t, sub)
+			assert.Equal(t, "events", msg.Topic)
+			assert.Equal(t, []byte("registered"), msg.Payload)
+			assert.NoError(t, msg.Ack(ctx))
+		}
+		expectNone(t, other, 50*time.Millisecond)
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		ps := factory(t)
+		ctx := context.Background()
+
+		sub := subscribe(t, ps, "events")
+		const count = 20
+		for i := 0; i < count; i++ {
+			require.NoError(t, ps.Publish(ctx, "events", []byte(strconv.Itoa(i))))
+		}
+		for i := 0; i < count; i++ {
+			assert.Equal(t, []byte(strconv.Itoa(i)), receive(t, sub).Payload)
+		}
+	})
+
+	t.Run("Close", func(t *testing.T) {
+		ps := factory(t)
+		ctx := context.Background()
+
+		sub := subscribe(t, ps, "events")
+		require.NoError(t, sub.Close())
+		require.NoError(t, ps.Publish(ctx, "events", []byte("after close")))
+
+		select {
+		case _, ok := <-sub.Messages():
+			assert.False(t, ok, "no messages expected after close")
+		case <-time.After(receiveTimeout):
+			t.Fatal("messages channel not closed")
+		}
+		assert.NoError(t, sub.Err())
+	})
+}
+
+// TestConsumerGroups runs the conformance test suite for the xstore.PubSub implementation with the consumer groups.
+func TestConsumerGroups(t *testing.T, factory PubSubFactory) {
+	t.Run("ExactlyOnceInGroup", func(t *testing.T) {
+		ps := factory(t)
+		ctx := context.Background()
+
+		consumers := []xstore.Subscription{
+			subscribe(t, ps, "accounts", xstore.SubscribeGroup("mailer", "mailer-1")),
+			subscribe(t, ps, "accounts", xstore.SubscribeGroup("mailer", "mailer-2")),
+		}
+		audit := subscribe(t, ps, "accounts", xstore.SubscribeGroup("audit", "audit-1"))
+
+		const count = 20
+		for i := 0; i < count; i++ {
+			require.NoError(t, ps.Publish(ctx, "accounts", []byte(strconv.Itoa(i))))
+		}
+
+		var (
+			mu       sync.Mutex
+			received = map[string]int{}
+			wg       sync.WaitGroup
+			total    int
+		)
+		done := make(chan struct{})
+		for _, consumer := range consumers {
+			wg.Add(1)
+			go func(sub xstore.Subscription) {
+				defer wg.Done()
+				for {
+					select {
+					case msg, ok := <-sub.Messages():
+						if !ok {
+							return
+						}
+						assert.NoError(t, msg.Ack(ctx))
+						mu.Lock()
+						received[string(msg.Payload)]++
+						total++
+						if total == count {
+							close(done)
+						}
+						mu.Unlock()
+					case <-done:
+						return
+					}
+				}
+			}(consumer)
+		}
+		select {
+		case <-done:
+		case <-time.After(receiveTimeout):
+			t.Fatal("not all the messages received by the group")
+		}
+		wg.Wait()
+		for i := 0; i < count; i++ {
+			assert.Equal(t, 1, received[strconv.Itoa(i)], "message: %d", i)
+		}
+		// Other groups receives all the messages as well.
+		for i := 0; i < count; i++ {
+			msg := receive(t, audit)
+			assert.Equal(t, []byte(strconv.Itoa(i)), msg.Payload)
+			assert.NoError(t, msg.Ack(ctx))
+		}
+		for _, consumer := range consumers {
+			expectNone(t, consumer, 50*time.Millisecond)
+		}
+	})
+
+	t.Run("Redelivery", func(t *testing.T) {
+		ps := factory(t)
+		ctx := context.Background()
+
+		sub := subscribe(t, ps, "accounts", xstore.SubscribeGroup("mailer", "mailer-1"), xstore.SubscribeAckTimeout(100*time.Millisecond))
+		require.NoError(t, ps.Publish(ctx, "accounts", []byte("registered")))
+
+		msg := receive(t, sub)
+		assert.Equal(t, 1, msg.Deliveries)
+
+		// Not acknowledged message is redelivered.
+		redelivered := receive(t, sub)
+		assert.Equal(t, msg.ID, redelivered.ID)
+		assert.Equal(t, []byte("registered"), redelivered.Payload)
+		assert.True(t, redelivered.Deliveries > 1)
+		require.NoError(t, redelivered.Ack(ctx))
+
+		expectNone(t, sub, 300*time.Millisecond)
+	})
+
+	t.Run("RedeliveryToOtherConsumer", func(t *testing.T) {
+		ps := factory(t)
+		ctx := context.Background()
+
+		failing := subscribe(t, ps, "accounts", xstore.SubscribeGroup("mailer", "mailer-1"), xstore.SubscribeAckTimeout(100*time.Millisecond))
+		require.NoError(t, ps.Publish(ctx, "accounts", []byte("registered")))
+		msg := receive(t, failing)
+		require.NoError(t, failing.Close())
+
+		other := subscribe(t, ps, "accounts", xstore.SubscribeGroup("mailer", "mailer-2"), xstore.SubscribeAckTimeout(100*time.Millisecond))
+		redelivered := receive(t, other)
+		assert.Equal(t, msg.ID, redelivered.ID)
+		require.NoError(t, redelivered.Ack(ctx))
+	})
+}
+
+func subscribe(t *testing.T, ps xstore.PubSub, topic string, options ...xstore.SubscribeOption) xstore.Subscription {
+	sub, err := ps.Subscribe(context.Background(), topic, options...)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if err := sub.Close(); err != nil && !errors.Is(err, xstore.ErrSubscriptionClosed) {
+			t.Errorf("closing subscription failed: %v", err)
+		}
+	})
+	return sub
+}
+
+func receive(t *testing.T, sub xstore.Subscription) *xstore.Message {
+	t.Helper()
+	select {
+	case msg, ok := <-sub.Messages():
+		require.True(t, ok, "subscription closed: %v", sub.Err())
+		return msg
+	case <-time.After(receiveTimeout):
+		t.Fatal("message not received")
+	}
+	return nil
+}
+
+func expectNone(t *testing.T, sub xstore.Subscription, wait time.Duration) {
+	t.Helper()
+	select {
+	case msg, ok := <-sub.Messages():
+		if ok {
+			t.Errorf("unexpected message: %s", msg.Payload)
+		}
+	case <-time.After(wait):
+	}
+}
@@ -0,0 +1,124 @@
+package storetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/neuronlabs/neuron/errors"
+	"github.com/neuronlabs/neuron/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// StoreFactory creates new, empty store.Store with provided 'options' for the test.
+type StoreFactory func(t *testing.T, options ...store.Option) store.Store
+
+// TestStore runs the conformance test suite for the store.Store implementation.
+func TestStore(t *testing.T, factory StoreFactory) {
+	t.Run("SetGet", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		_, err := s.Get(ctx, "key")
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "key", Value: []byte("value")}))
+		record, err := s.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "key", record.Key)
+		assert.Equal(t, []byte("value"), record.Value)
+		assert.True(t, record.ExpiresAt.IsZero())
+
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "key", Value: []byte("other")}))
+		record, err = s.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("other"), record.Value)
+	})
+
+	t.Run("Expiration", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		now := time.Now()
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "key", Value: []byte("value")}, store.SetWithTTL(time.Minute)))
+		record, err := s.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.WithinDuration(t, now.Add(time.Minute), record.ExpiresAt, time.Second)
+
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "short", Value: []byte("value")}, store.SetWithTTL(50*time.Millisecond)))
+		time.Sleep(100 * time.Millisecond)
+		_, err = s.Get(ctx, "short")
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		s := factory(t)
+		ctx := context.Background()
+
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "key", Value: []byte("value")}))
+		require.NoError(t, s.Delete(ctx, "key"))
+
+		_, err := s.Get(ctx, "key")
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+
+		err = s.Delete(ctx, "key")
+		assert.True(t, errors.Is(err, store.ErrRecordNotFound))
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		s := factory(t, store.WithPrefix("prefix_"), store.WithSuffix("_suffix"))
+		ctx := context.Background()
+
+		now := time.Now()
+		for _, key := range []string{"session_c", "session_a", "session_b", "token_a"} {
+			require.NoError(t, s.Set(ctx, &store.Record{Key: key, Value: []byte(key)}, store.SetWithTTL(time.Minute)))
+		}
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "session_expired", Value: []byte("expired")}, store.SetWithTTL(50*time.Millisecond)))
+		require.NoError(t, s.Set(ctx, &store.Record{Key: "session_persistent", Value: []byte("persistent")}))
+		time.Sleep(100 * time.Millisecond)
+
+		t.Run("All", func(t *testing.T) {
+			records, err := s.Find(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"session_a", "session_b", "session_c", "session_persistent", "token_a"}, recordKeys(records))
+		})
+
+		t.Run("Prefix", func(t *testing.T) {
+			records, err := s.Find(ctx, store.FindWithPrefix("session_"))
+			require.NoError(t, err)
+			require.Equal(t, []string{"session_a", "session_b", "session_c", "session_persistent"}, recordKeys(records))
+
+			for _, record := range records[:3] {
+				assert.Equal(t, []byte(record.Key), record.Value)
+				assert.WithinDuration(t, now.Add(time.Minute), record.ExpiresAt, time.Second)
+			}
+			assert.Equal(t, []byte("persistent"), records[3].Value)
+			assert.True(t, records[3].ExpiresAt.IsZero())
+		})
+
+		t.Run("Suffix", func(t *testing.T) {
+			records, err := s.Find(ctx, store.FindWithSuffix("_a"))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"session_a", "token_a"}, recordKeys(records))
+		})
+
+		t.Run("LimitOffset", func(t *testing.T) {
+			records, err := s.Find(ctx, store.FindWithPrefix("session_"), store.FindWithLimit(2), store.FindWithOffset(1))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"session_b", "session_c"}, recordKeys(records))
+
+			records, err = s.Find(ctx, store.FindWithPrefix("session_"), store.FindWithOffset(10))
+			require.NoError(t, err)
+			assert.Empty(t, records)
+		})
+	})
+}
+
+func recordKeys(records []*store.Record) []string {
+	keys := make([]string, len(records))
+	for i, record := range records {
+		keys[i] = record.Key
+	}
+	return keys
+}